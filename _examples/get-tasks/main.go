@@ -10,8 +10,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strconv"
-	"time"
 
 	"github.com/Guitarbum722/clickup-client-go"
 )
@@ -48,9 +46,3 @@ func main() {
 		}
 	}
 }
-
-func msToTime(ms string) time.Time {
-	msInt, _ := strconv.ParseInt(ms, 10, 64)
-
-	return time.Unix(0, msInt*int64(time.Millisecond))
-}
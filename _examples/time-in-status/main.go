@@ -10,8 +10,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strconv"
-	"time"
 
 	"github.com/Guitarbum722/clickup-client-go"
 )
@@ -39,21 +37,15 @@ func main() {
 
 	fmt.Println("Task:", taskID)
 	fmt.Printf("Current Status: %s\n", timeInStatusResponse.CurrentStatus.Status)
-	fmt.Printf("Current Since: %s\n", msToTime(timeInStatusResponse.CurrentStatus.TotalTime.Since))
+	fmt.Printf("Current Since: %s\n", timeInStatusResponse.CurrentStatus.TotalTime.Since.Time)
 	fmt.Printf("Current Duration: %v days\n", minsToDays(timeInStatusResponse.CurrentStatus.TotalTime.ByMinute))
 	fmt.Println("-------------")
 
 	for _, v := range timeInStatusResponse.StatusHistory {
-		fmt.Printf("Status: %-15s\t%s\t%v days\tOrder: %v\n", v.Status, msToTime(v.TotalTime.Since), minsToDays(v.TotalTime.ByMinute), v.Orderindex)
+		fmt.Printf("Status: %-15s\t%s\t%v days\tOrder: %v\n", v.Status, v.TotalTime.Since.Time, minsToDays(v.TotalTime.ByMinute), v.Orderindex)
 	}
 }
 
-func msToTime(ms string) time.Time {
-	msInt, _ := strconv.ParseInt(ms, 10, 64)
-
-	return time.Unix(0, msInt*int64(time.Millisecond))
-}
-
 func minsToDays(mins int) int {
 	return mins / 60 / 24
 }
@@ -0,0 +1,391 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package analytics computes flow metrics from the status history of tasks: cycle time, lead time, the
+// time spent in each stage of a workflow, weekly throughput and cumulative flow.
+//
+// Clickup reports, for every status a task has been in, the total time spent in it and when the task
+// first entered it.  A task is taken to have moved forward through the workflow, so it reached a stage
+// when it first entered any of its statuses.  Lead time runs from the creation of a task to when it
+// reached the Done stage, and cycle time from when it reached the Start stage.
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// Minutes is a length of time in whole minutes, the unit of Clickup's time in status.
+type Minutes int
+
+func (m Minutes) Duration() time.Duration {
+	return time.Duration(m) * time.Minute
+}
+
+func minutes(d time.Duration) Minutes {
+	return Minutes(d / time.Minute)
+}
+
+// Options controls a report.  A nil *Options uses DefaultWorkflow.
+type Options struct {
+	Workflow *Workflow
+	// Percentiles of cycle time, lead time and stage time to report.  They default to 50, 75, 85 and 95.
+	Percentiles []float64
+	// Since leaves out tasks completed before it and starts the cumulative flow at it.
+	Since time.Time
+	// Location is where weeks and days start.  It defaults to UTC.
+	Location *time.Location
+	// Now is the time the report is generated at.  It defaults to time.Now.
+	Now time.Time
+	// Fetch is used by ForTasks to request the status history, and may be nil.
+	Fetch *clickup.TimeInStatusOptions
+}
+
+var defaultPercentiles = []float64{50, 75, 85, 95}
+
+// Report holds the metrics of a set of tasks.  Durations are in minutes, and stage values are in the
+// order of Stages.
+type Report struct {
+	GeneratedAt    time.Time      `json:"generated_at"`
+	Stages         []string       `json:"stages"`
+	Tasks          []TaskMetrics  `json:"tasks"` // sorted by id
+	CycleTime      Summary        `json:"cycle_time"`
+	LeadTime       Summary        `json:"lead_time"`
+	StageTime      []StageSummary `json:"stage_time"`
+	Throughput     []Throughput   `json:"throughput"`
+	CumulativeFlow []FlowPoint    `json:"cumulative_flow"`
+	Unmapped       []string       `json:"unmapped,omitempty"` // statuses that belong to no stage
+}
+
+type TaskMetrics struct {
+	ID        string     `json:"id"`
+	Status    string     `json:"status"`
+	Stage     string     `json:"stage"` // empty if the status belongs to no stage
+	Created   time.Time  `json:"created"`
+	Started   *time.Time `json:"started,omitempty"`
+	Completed *time.Time `json:"completed,omitempty"`
+	LeadTime  *Minutes   `json:"lead_time_minutes,omitempty"`
+	CycleTime *Minutes   `json:"cycle_time_minutes,omitempty"`
+	StageTime []Minutes  `json:"stage_minutes"`
+
+	arrivals []time.Time // when the task reached each stage, zero if it did not
+	visited  []bool
+}
+
+// Summary describes a set of durations.  The percentiles are nearest rank.
+type Summary struct {
+	Count       int          `json:"count"`
+	Mean        Minutes      `json:"mean_minutes"`
+	Min         Minutes      `json:"min_minutes"`
+	Max         Minutes      `json:"max_minutes"`
+	Percentiles []Percentile `json:"percentiles"`
+}
+
+type Percentile struct {
+	Percent float64 `json:"percent"`
+	Value   Minutes `json:"minutes"`
+}
+
+// StageSummary describes the time spent in a stage by the tasks that were in it.
+type StageSummary struct {
+	Stage string `json:"stage"`
+	Summary
+}
+
+// Throughput is the number of tasks completed in the week starting on Monday Week.
+type Throughput struct {
+	Week      time.Time `json:"week"`
+	Completed int       `json:"completed"`
+}
+
+// FlowPoint is the number of tasks in each stage at the end of Date.
+type FlowPoint struct {
+	Date  time.Time `json:"date"`
+	Tasks []int     `json:"tasks"`
+}
+
+// ForTasks requests the status history of taskIDs with AllTaskTimeInStatus and analyzes it.
+func ForTasks(ctx context.Context, client *clickup.Client, taskIDs []string, opts *Options) (*Report, error) {
+	o, wf, err := opts.compile()
+	if err != nil {
+		return nil, err
+	}
+	histories, err := client.AllTaskTimeInStatus(ctx, taskIDs, o.Fetch)
+	if err != nil {
+		return nil, err
+	}
+	return analyze(histories, o, wf), nil
+}
+
+// Analyze computes the metrics of the tasks in histories, which are keyed by task id.
+func Analyze(histories map[string]clickup.TaskTimeInStatusResponse, opts *Options) (*Report, error) {
+	o, wf, err := opts.compile()
+	if err != nil {
+		return nil, err
+	}
+	return analyze(histories, o, wf), nil
+}
+
+func (opts *Options) compile() (Options, *workflow, error) {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Workflow == nil {
+		o.Workflow = DefaultWorkflow()
+	}
+	if o.Percentiles == nil {
+		o.Percentiles = defaultPercentiles
+	}
+	for _, p := range o.Percentiles {
+		if p <= 0 || p > 100 {
+			return o, nil, fmt.Errorf("percentile %v is not in (0, 100]: %w", p, clickup.ErrValidation)
+		}
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	wf, err := o.Workflow.compile()
+	return o, wf, err
+}
+
+func analyze(histories map[string]clickup.TaskTimeInStatusResponse, o Options, wf *workflow) *Report {
+	report := &Report{
+		GeneratedAt: o.Now,
+		Stages:      wf.names,
+		Tasks:       make([]TaskMetrics, 0, len(histories)),
+	}
+
+	unmapped := make(map[string]bool)
+	for id, history := range histories {
+		task := taskMetrics(id, history, wf, unmapped)
+		if task.Completed != nil && task.Completed.Before(o.Since) {
+			continue
+		}
+		report.Tasks = append(report.Tasks, task)
+	}
+	sort.Slice(report.Tasks, func(i, j int) bool { return report.Tasks[i].ID < report.Tasks[j].ID })
+	for status := range unmapped {
+		report.Unmapped = append(report.Unmapped, status)
+	}
+	sort.Strings(report.Unmapped)
+
+	var cycle, lead []Minutes
+	stages := make([][]Minutes, len(wf.names))
+	for _, task := range report.Tasks {
+		if task.CycleTime != nil {
+			cycle = append(cycle, *task.CycleTime)
+		}
+		if task.LeadTime != nil {
+			lead = append(lead, *task.LeadTime)
+		}
+		for i, visited := range task.visited {
+			if visited {
+				stages[i] = append(stages[i], task.StageTime[i])
+			}
+		}
+	}
+	report.CycleTime = summarize(cycle, o.Percentiles)
+	report.LeadTime = summarize(lead, o.Percentiles)
+	report.StageTime = make([]StageSummary, len(wf.names))
+	for i, name := range wf.names {
+		report.StageTime[i] = StageSummary{Stage: name, Summary: summarize(stages[i], o.Percentiles)}
+	}
+
+	report.Throughput = throughput(report.Tasks, o)
+	report.CumulativeFlow = cumulativeFlow(report.Tasks, len(wf.names), o)
+	return report
+}
+
+// statusEntry is a status that a task has been in.
+type statusEntry struct {
+	status, typ string
+	minutes     int
+	since       time.Time
+}
+
+func taskMetrics(id string, history clickup.TaskTimeInStatusResponse, wf *workflow, unmapped map[string]bool) TaskMetrics {
+	entries := make([]statusEntry, 0, len(history.StatusHistory)+1)
+	current := history.CurrentStatus
+	currentType, listed := "", false
+	for _, h := range history.StatusHistory {
+		entries = append(entries, statusEntry{h.Status, h.Type, h.TotalTime.ByMinute, h.TotalTime.Since.Time})
+		if strings.EqualFold(h.Status, current.Status) {
+			currentType, listed = h.Type, true
+		}
+	}
+	if !listed && current.Status != "" {
+		entries = append(entries, statusEntry{current.Status, "", current.TotalTime.ByMinute, current.TotalTime.Since.Time})
+	}
+
+	task := TaskMetrics{
+		ID:        id,
+		Status:    current.Status,
+		StageTime: make([]Minutes, len(wf.names)),
+		arrivals:  make([]time.Time, len(wf.names)),
+		visited:   make([]bool, len(wf.names)),
+	}
+	for _, entry := range entries {
+		if !entry.since.IsZero() && (task.Created.IsZero() || entry.since.Before(task.Created)) {
+			task.Created = entry.since
+		}
+		i := wf.stage(entry.status, entry.typ)
+		if i < 0 {
+			unmapped[entry.status] = true
+			continue
+		}
+		task.visited[i] = true
+		task.StageTime[i] += Minutes(entry.minutes)
+		if arrival := task.arrivals[i]; !entry.since.IsZero() && (arrival.IsZero() || entry.since.Before(arrival)) {
+			task.arrivals[i] = entry.since
+		}
+	}
+
+	stage := wf.stage(current.Status, currentType)
+	if stage < 0 {
+		return task
+	}
+	task.Stage = wf.names[stage]
+	if stage < wf.done {
+		if started := earliest(task.arrivals[wf.start:]); !started.IsZero() && stage >= wf.start {
+			task.Started = &started
+		}
+		return task
+	}
+
+	completed := earliest(task.arrivals[wf.done:])
+	if completed.IsZero() {
+		completed = current.TotalTime.Since.Time
+	}
+	if completed.IsZero() {
+		return task
+	}
+	started := earliest(task.arrivals[wf.start:])
+	if started.IsZero() || started.After(completed) {
+		started = completed
+	}
+	cycle := minutes(completed.Sub(started))
+	task.Started, task.Completed, task.CycleTime = &started, &completed, &cycle
+	if !task.Created.IsZero() {
+		lead := minutes(completed.Sub(task.Created))
+		task.LeadTime = &lead
+	}
+	return task
+}
+
+// earliest returns the earliest of times that is not zero.
+func earliest(times []time.Time) time.Time {
+	var first time.Time
+	for _, t := range times {
+		if !t.IsZero() && (first.IsZero() || t.Before(first)) {
+			first = t
+		}
+	}
+	return first
+}
+
+func summarize(values []Minutes, percentiles []float64) Summary {
+	s := Summary{Count: len(values), Percentiles: make([]Percentile, 0, len(percentiles))}
+	if len(values) == 0 {
+		return s
+	}
+	sorted := append([]Minutes(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total int
+	for _, v := range sorted {
+		total += int(v)
+	}
+	s.Mean = Minutes(total / len(sorted))
+	s.Min, s.Max = sorted[0], sorted[len(sorted)-1]
+	for _, p := range percentiles {
+		rank := int(p / 100 * float64(len(sorted)))
+		if float64(rank) < p/100*float64(len(sorted)) {
+			rank++
+		}
+		s.Percentiles = append(s.Percentiles, Percentile{Percent: p, Value: sorted[rank-1]})
+	}
+	return s
+}
+
+// startOfDay returns midnight of the day of t in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// startOfWeek returns midnight of the Monday of the week of t in loc.
+func startOfWeek(t time.Time, loc *time.Location) time.Time {
+	day := startOfDay(t, loc)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// throughput counts the completed tasks per week from the first week with a completion, or Since, to
+// the week of Now.
+func throughput(tasks []TaskMetrics, o Options) []Throughput {
+	counts := make(map[time.Time]int)
+	first := o.Since
+	for _, task := range tasks {
+		if task.Completed == nil {
+			continue
+		}
+		counts[startOfWeek(*task.Completed, o.Location)]++
+		if first.IsZero() || task.Completed.Before(first) {
+			first = *task.Completed
+		}
+	}
+	if first.IsZero() {
+		return []Throughput{}
+	}
+
+	weeks := []Throughput{}
+	last := startOfWeek(o.Now, o.Location)
+	for week := startOfWeek(first, o.Location); !week.After(last); week = week.AddDate(0, 0, 7) {
+		weeks = append(weeks, Throughput{Week: week, Completed: counts[week]})
+	}
+	return weeks
+}
+
+// cumulativeFlow counts the tasks in each stage at the end of every day from Since, or the creation of
+// the first task, to Now.  A task is in the furthest stage it had reached.
+func cumulativeFlow(tasks []TaskMetrics, stages int, o Options) []FlowPoint {
+	first := o.Since
+	if first.IsZero() {
+		for _, task := range tasks {
+			if arrival := earliest(task.arrivals); !arrival.IsZero() && (first.IsZero() || arrival.Before(first)) {
+				first = arrival
+			}
+		}
+	}
+	if first.IsZero() {
+		return []FlowPoint{}
+	}
+
+	points := []FlowPoint{}
+	last := startOfDay(o.Now, o.Location)
+	for day := startOfDay(first, o.Location); !day.After(last); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		point := FlowPoint{Date: day, Tasks: make([]int, stages)}
+		for _, task := range tasks {
+			for i := stages - 1; i >= 0; i-- {
+				if arrival := task.arrivals[i]; !arrival.IsZero() && arrival.Before(end) {
+					point.Tasks[i]++
+					break
+				}
+			}
+		}
+		points = append(points, point)
+	}
+	return points
+}
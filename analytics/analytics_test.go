@@ -0,0 +1,251 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+type status struct {
+	name, typ string
+	minutes   int
+	since     time.Time
+}
+
+// history returns the time in status of a task that is in current and has been in statuses.
+func history(t *testing.T, current string, statuses ...status) clickup.TaskTimeInStatusResponse {
+	t.Helper()
+	var entries []string
+	currentJSON := ""
+	for _, s := range statuses {
+		entry := fmt.Sprintf(`{"by_minute": %d, "since": "%d"}`, s.minutes, clickup.NewTimestamp(s.since).Millis())
+		entries = append(entries, fmt.Sprintf(`{"status": %q, "type": %q, "total_time": %s}`, s.name, s.typ, entry))
+		if s.name == current {
+			currentJSON = fmt.Sprintf(`{"status": %q, "total_time": %s}`, s.name, entry)
+		}
+	}
+	body := fmt.Sprintf(`{"current_status": %s, "status_history": [%s]}`, currentJSON, strings.Join(entries, ","))
+
+	var response clickup.TaskTimeInStatusResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+	return response
+}
+
+func day(d, hour, minute int) time.Time {
+	return time.Date(2022, 6, d, hour, minute, 0, 0, time.UTC)
+}
+
+func testHistories(t *testing.T) map[string]clickup.TaskTimeInStatusResponse {
+	return map[string]clickup.TaskTimeInStatusResponse{
+		"a": history(t, "complete",
+			status{"to do", "open", 60, day(34, 9, 0)},
+			status{"in progress", "custom", 120, day(34, 10, 0)},
+			status{"complete", "closed", 2880, day(34, 12, 0)},
+		),
+		"b": history(t, "review",
+			status{"to do", "open", 30, day(35, 8, 0)},
+			status{"review", "custom", 600, day(35, 8, 30)},
+		),
+		"c": history(t, "complete",
+			status{"to do", "open", 720, day(29, 0, 0)},
+			status{"in progress", "custom", 720, day(29, 12, 0)},
+			status{"complete", "closed", 9000, day(30, 0, 0)},
+		),
+	}
+}
+
+func summary(count, mean, min, max int, percentiles ...int) Summary {
+	s := Summary{Count: count, Mean: Minutes(mean), Min: Minutes(min), Max: Minutes(max), Percentiles: []Percentile{}}
+	for i, p := range percentiles {
+		s.Percentiles = append(s.Percentiles, Percentile{Percent: defaultPercentiles[i], Value: Minutes(p)})
+	}
+	return s
+}
+
+func TestAnalyze(t *testing.T) {
+	now := day(36, 12, 0)
+	report, err := Analyze(testHistories(t), &Options{Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"To do", "In progress", "Done"}; !reflect.DeepEqual(report.Stages, want) {
+		t.Errorf("Stages = %v, want %v", report.Stages, want)
+	}
+
+	type taskWant struct {
+		id, stage          string
+		started, completed time.Time
+		lead, cycle        int
+		stageTime          []Minutes
+	}
+	tasks := []taskWant{
+		{"a", "Done", day(34, 10, 0), day(34, 12, 0), 180, 120, []Minutes{60, 120, 2880}},
+		{"b", "In progress", day(35, 8, 30), time.Time{}, 0, 0, []Minutes{30, 600, 0}},
+		{"c", "Done", day(29, 12, 0), day(30, 0, 0), 1440, 720, []Minutes{720, 720, 9000}},
+	}
+	if len(report.Tasks) != len(tasks) {
+		t.Fatalf("got %d tasks, want %d", len(report.Tasks), len(tasks))
+	}
+	for i, want := range tasks {
+		got := report.Tasks[i]
+		if got.ID != want.id || got.Stage != want.stage || !reflect.DeepEqual(got.StageTime, want.stageTime) {
+			t.Errorf("task %d = %s in %q with %v, want %s in %q with %v", i, got.ID, got.Stage, got.StageTime, want.id, want.stage, want.stageTime)
+		}
+		if got.Started == nil || !got.Started.Equal(want.started) {
+			t.Errorf("task %s started = %v, want %v", want.id, got.Started, want.started)
+		}
+		if want.completed.IsZero() {
+			if got.Completed != nil || got.LeadTime != nil || got.CycleTime != nil {
+				t.Errorf("open task %s has completion %v, lead time %v and cycle time %v", want.id, got.Completed, got.LeadTime, got.CycleTime)
+			}
+			continue
+		}
+		if got.Completed == nil || !got.Completed.Equal(want.completed) || *got.LeadTime != Minutes(want.lead) || *got.CycleTime != Minutes(want.cycle) {
+			t.Errorf("task %s completed %v after %v and %v, want %v after %d and %d", want.id, got.Completed, *got.LeadTime, *got.CycleTime,
+				want.completed, want.lead, want.cycle)
+		}
+	}
+
+	if want := summary(2, 420, 120, 720, 120, 720, 720, 720); !reflect.DeepEqual(report.CycleTime, want) {
+		t.Errorf("CycleTime = %+v, want %+v", report.CycleTime, want)
+	}
+	if want := summary(2, 810, 180, 1440, 180, 1440, 1440, 1440); !reflect.DeepEqual(report.LeadTime, want) {
+		t.Errorf("LeadTime = %+v, want %+v", report.LeadTime, want)
+	}
+	stageTime := []StageSummary{
+		{Stage: "To do", Summary: summary(3, 270, 30, 720, 60, 720, 720, 720)},
+		{Stage: "In progress", Summary: summary(3, 480, 120, 720, 600, 720, 720, 720)},
+		{Stage: "Done", Summary: summary(2, 5940, 2880, 9000, 2880, 9000, 9000, 9000)},
+	}
+	if !reflect.DeepEqual(report.StageTime, stageTime) {
+		t.Errorf("StageTime = %+v, want %+v", report.StageTime, stageTime)
+	}
+
+	throughput := []Throughput{{Week: day(27, 0, 0), Completed: 1}, {Week: day(34, 0, 0), Completed: 1}}
+	if !reflect.DeepEqual(report.Throughput, throughput) {
+		t.Errorf("Throughput = %+v, want %+v", report.Throughput, throughput)
+	}
+
+	flow := [][]int{{0, 1, 0}, {0, 0, 1}, {0, 0, 1}, {0, 0, 1}, {0, 0, 1}, {0, 0, 2}, {0, 1, 2}, {0, 1, 2}}
+	if len(report.CumulativeFlow) != len(flow) {
+		t.Fatalf("got %d days of cumulative flow, want %d", len(report.CumulativeFlow), len(flow))
+	}
+	for i, want := range flow {
+		point := report.CumulativeFlow[i]
+		if !point.Date.Equal(day(29+i, 0, 0)) || !reflect.DeepEqual(point.Tasks, want) {
+			t.Errorf("cumulative flow %d = %s %v, want %s %v", i, point.Date, point.Tasks, day(29+i, 0, 0), want)
+		}
+	}
+}
+
+func TestAnalyze_workflow(t *testing.T) {
+	histories := testHistories(t)
+	histories["d"] = history(t, "blocked",
+		status{"to do", "open", 10, day(35, 0, 0)},
+		status{"blocked", "custom", 90, day(35, 1, 0)},
+	)
+	opts := &Options{
+		Workflow: &Workflow{
+			Stages: []Stage{
+				{Name: "Backlog", Types: []string{"open"}},
+				{Name: "Doing", Statuses: []string{"In Progress"}},
+				{Name: "Review", Statuses: []string{"review"}},
+				{Name: "Done", Types: []string{"closed"}},
+			},
+			Start: "review",
+		},
+		Percentiles: []float64{100},
+		Since:       day(31, 0, 0),
+		Now:         day(36, 12, 0),
+	}
+	report, err := Analyze(histories, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids, stages []string
+	for _, task := range report.Tasks {
+		ids = append(ids, task.ID)
+		stages = append(stages, task.Stage)
+	}
+	if want := []string{"a", "b", "d"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("tasks = %v, want %v without the task completed before Since", ids, want)
+	}
+	if want := []string{"Done", "Review", ""}; !reflect.DeepEqual(stages, want) {
+		t.Errorf("stages = %v, want %v", stages, want)
+	}
+	if want := []string{"blocked"}; !reflect.DeepEqual(report.Unmapped, want) {
+		t.Errorf("Unmapped = %v, want %v", report.Unmapped, want)
+	}
+	// a skipped review, so its cycle starts when it was done.
+	if a := report.Tasks[0]; *a.CycleTime != 0 || *a.LeadTime != 180 {
+		t.Errorf("task a cycle time %d and lead time %d, want 0 and 180", *a.CycleTime, *a.LeadTime)
+	}
+	if want := []Percentile{{Percent: 100, Value: 0}}; report.CycleTime.Count != 1 || !reflect.DeepEqual(report.CycleTime.Percentiles, want) {
+		t.Errorf("CycleTime = %+v, want a single task with percentiles %v", report.CycleTime, want)
+	}
+	if report.Throughput[0].Week != day(27, 0, 0) || len(report.Throughput) != 2 {
+		t.Errorf("Throughput = %+v, want the weeks from Since", report.Throughput)
+	}
+	if len(report.CumulativeFlow) != 6 || !report.CumulativeFlow[0].Date.Equal(day(31, 0, 0)) {
+		t.Errorf("CumulativeFlow starts %v with %d days, want 2022-07-01 with 6", report.CumulativeFlow[0].Date, len(report.CumulativeFlow))
+	}
+
+	if _, err := Analyze(histories, &Options{Percentiles: []float64{0}}); err == nil {
+		t.Error("Analyze() with a 0th percentile should fail")
+	}
+}
+
+func TestForTasks(t *testing.T) {
+	var paths []string
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		body := `{"a": {"current_status": {"status": "open", "total_time": {"by_minute": 5, "since": "1656633600000"}}},
+			"b": {"current_status": {"status": "closed", "total_time": {"by_minute": 5, "since": "1656633600000"}}}}`
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body)), Request: req}, nil
+	})
+	client := clickup.NewClient(&clickup.ClientOpts{Doer: doer, Authenticator: &clickup.APITokenAuthenticator{}})
+
+	opts := &Options{
+		Workflow: &Workflow{Stages: []Stage{{Name: "Open", Statuses: []string{"open"}}, {Name: "Closed", Statuses: []string{"closed"}}}},
+		Now:      day(36, 0, 0),
+	}
+	report, err := ForTasks(context.Background(), client, []string{"a", "b"}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/api/v2/task/bulk_time_in_status/task_ids/"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("requests = %v, want %v", paths, want)
+	}
+	if len(report.Tasks) != 2 || report.Tasks[1].Stage != "Closed" || report.CycleTime.Count != 1 {
+		t.Errorf("report = %+v", report)
+	}
+
+	opts.Workflow.Done = "Archived"
+	if _, err := ForTasks(context.Background(), client, []string{"a", "b"}, opts); err == nil || len(paths) != 1 {
+		t.Errorf("ForTasks() with an invalid workflow = %v after %d requests, want an error before any", err, len(paths))
+	}
+}
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteJSON writes the report to w as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteTasksCSV writes a row per task with its dates, lead and cycle time and a column of minutes per stage.
+func (r *Report) WriteTasksCSV(w io.Writer) error {
+	header := []string{"id", "status", "stage", "created", "started", "completed", "lead_time_minutes", "cycle_time_minutes"}
+	for _, stage := range r.Stages {
+		header = append(header, stage+" minutes")
+	}
+	return writeCSV(w, header, len(r.Tasks), func(i int) []string {
+		task := r.Tasks[i]
+		row := []string{
+			task.ID, task.Status, task.Stage, formatTime(&task.Created), formatTime(task.Started),
+			formatTime(task.Completed), formatMinutes(task.LeadTime), formatMinutes(task.CycleTime),
+		}
+		for _, m := range task.StageTime {
+			row = append(row, strconv.Itoa(int(m)))
+		}
+		return row
+	})
+}
+
+// WriteSummaryCSV writes a row each for cycle time, lead time and the time in every stage.
+func (r *Report) WriteSummaryCSV(w io.Writer) error {
+	header := []string{"metric", "count", "mean_minutes", "min_minutes", "max_minutes"}
+	for _, p := range r.CycleTime.Percentiles {
+		header = append(header, "p"+strconv.FormatFloat(p.Percent, 'f', -1, 64))
+	}
+
+	metrics := []StageSummary{{Stage: "cycle_time", Summary: r.CycleTime}, {Stage: "lead_time", Summary: r.LeadTime}}
+	for _, stage := range r.StageTime {
+		metrics = append(metrics, StageSummary{Stage: "stage:" + stage.Stage, Summary: stage.Summary})
+	}
+	return writeCSV(w, header, len(metrics), func(i int) []string {
+		s := metrics[i]
+		row := []string{s.Stage, strconv.Itoa(s.Count), "", "", ""}
+		if s.Count > 0 {
+			row[2], row[3], row[4] = strconv.Itoa(int(s.Mean)), strconv.Itoa(int(s.Min)), strconv.Itoa(int(s.Max))
+		}
+		for j := range header[5:] {
+			value := ""
+			if j < len(s.Percentiles) {
+				value = strconv.Itoa(int(s.Percentiles[j].Value))
+			}
+			row = append(row, value)
+		}
+		return row
+	})
+}
+
+// WriteThroughputCSV writes a row per week with the number of tasks completed in it.
+func (r *Report) WriteThroughputCSV(w io.Writer) error {
+	return writeCSV(w, []string{"week", "completed"}, len(r.Throughput), func(i int) []string {
+		return []string{r.Throughput[i].Week.Format("2006-01-02"), strconv.Itoa(r.Throughput[i].Completed)}
+	})
+}
+
+// WriteCumulativeFlowCSV writes a row per day with the number of tasks in each stage.
+func (r *Report) WriteCumulativeFlowCSV(w io.Writer) error {
+	header := append([]string{"date"}, r.Stages...)
+	return writeCSV(w, header, len(r.CumulativeFlow), func(i int) []string {
+		point := r.CumulativeFlow[i]
+		row := []string{point.Date.Format("2006-01-02")}
+		for _, n := range point.Tasks {
+			row = append(row, strconv.Itoa(n))
+		}
+		return row
+	})
+}
+
+func writeCSV(w io.Writer, header []string, rows int, row func(i int) []string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for i := 0; i < rows; i++ {
+		if err := writer.Write(row(i)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatMinutes(m *Minutes) string {
+	if m == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*m))
+}
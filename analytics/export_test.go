@@ -0,0 +1,120 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReport_WriteCSV(t *testing.T) {
+	report, err := Analyze(testHistories(t), &Options{Now: day(36, 12, 0), Percentiles: []float64{50, 90}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		write func(w io.Writer) error
+		want  string
+	}{
+		{
+			name:  "Tasks",
+			write: report.WriteTasksCSV,
+			want: `id,status,stage,created,started,completed,lead_time_minutes,cycle_time_minutes,To do minutes,In progress minutes,Done minutes
+a,complete,Done,2022-07-04T09:00:00Z,2022-07-04T10:00:00Z,2022-07-04T12:00:00Z,180,120,60,120,2880
+b,review,In progress,2022-07-05T08:00:00Z,2022-07-05T08:30:00Z,,,,30,600,0
+c,complete,Done,2022-06-29T00:00:00Z,2022-06-29T12:00:00Z,2022-06-30T00:00:00Z,1440,720,720,720,9000
+`,
+		},
+		{
+			name:  "Summary",
+			write: report.WriteSummaryCSV,
+			want: `metric,count,mean_minutes,min_minutes,max_minutes,p50,p90
+cycle_time,2,420,120,720,120,720
+lead_time,2,810,180,1440,180,1440
+stage:To do,3,270,30,720,60,720
+stage:In progress,3,480,120,720,600,720
+stage:Done,2,5940,2880,9000,2880,9000
+`,
+		},
+		{
+			name:  "Throughput",
+			write: report.WriteThroughputCSV,
+			want: `week,completed
+2022-06-27,1
+2022-07-04,1
+`,
+		},
+		{
+			name:  "Cumulative flow",
+			write: report.WriteCumulativeFlowCSV,
+			want: `date,To do,In progress,Done
+2022-06-29,0,1,0
+2022-06-30,0,0,1
+2022-07-01,0,0,1
+2022-07-02,0,0,1
+2022-07-03,0,0,1
+2022-07-04,0,0,2
+2022-07-05,0,1,2
+2022-07-06,0,1,2
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := tt.write(&b); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	report, err := Analyze(testHistories(t), &Options{Now: day(36, 12, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		CycleTime struct {
+			Count       int `json:"count"`
+			Percentiles []struct {
+				Percent float64 `json:"percent"`
+				Minutes int     `json:"minutes"`
+			} `json:"percentiles"`
+		} `json:"cycle_time"`
+		StageTime []struct {
+			Stage string `json:"stage"`
+			Mean  int    `json:"mean_minutes"`
+		} `json:"stage_time"`
+		Tasks []map[string]interface{} `json:"tasks"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.CycleTime.Count != 2 || decoded.CycleTime.Percentiles[0].Minutes != 120 {
+		t.Errorf("cycle_time = %+v", decoded.CycleTime)
+	}
+	if len(decoded.StageTime) != 3 || decoded.StageTime[2].Stage != "Done" || decoded.StageTime[2].Mean != 5940 {
+		t.Errorf("stage_time = %+v", decoded.StageTime)
+	}
+	if _, ok := decoded.Tasks[1]["cycle_time_minutes"]; ok {
+		t.Errorf("open task has a cycle time: %v", decoded.Tasks[1])
+	}
+}
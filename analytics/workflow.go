@@ -0,0 +1,121 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package analytics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// Stage is a step of a workflow that one or more statuses belong to.  A status belongs to the first
+// stage that names it, case insensitively, or else to the first stage with its type.
+type Stage struct {
+	Name     string
+	Statuses []string // status names
+	Types    []string // status types: "open", "custom", "closed" or "done"
+}
+
+// Workflow is the ordered stages that tasks move through.
+type Workflow struct {
+	Stages []Stage
+	// Start is the stage at which cycle time starts.  It defaults to the second stage.
+	Start string
+	// Done is the stage at which a task is complete.  It defaults to the last stage.
+	Done string
+}
+
+// DefaultWorkflow groups statuses by their type: open statuses are to do, custom statuses are in
+// progress and closed statuses are done.
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Stages: []Stage{
+			{Name: "To do", Types: []string{"open"}},
+			{Name: "In progress", Types: []string{"custom"}},
+			{Name: "Done", Types: []string{"closed", "done"}},
+		},
+	}
+}
+
+// workflow is a validated Workflow.
+type workflow struct {
+	names  []string
+	byName map[string]int // lower case status name to stage index
+	byType map[string]int
+	start  int
+	done   int
+}
+
+func (w *Workflow) compile() (*workflow, error) {
+	if len(w.Stages) == 0 {
+		return nil, fmt.Errorf("a workflow must have at least one stage: %w", clickup.ErrValidation)
+	}
+	c := &workflow{
+		byName: make(map[string]int),
+		byType: make(map[string]int),
+		start:  -1,
+		done:   -1,
+	}
+	for i, stage := range w.Stages {
+		if stage.Name == "" {
+			return nil, fmt.Errorf("stage %d has no name: %w", i+1, clickup.ErrValidation)
+		}
+		for _, name := range c.names {
+			if strings.EqualFold(name, stage.Name) {
+				return nil, fmt.Errorf("duplicate stage %q: %w", stage.Name, clickup.ErrValidation)
+			}
+		}
+		c.names = append(c.names, stage.Name)
+		for _, status := range stage.Statuses {
+			if _, ok := c.byName[strings.ToLower(status)]; !ok {
+				c.byName[strings.ToLower(status)] = i
+			}
+		}
+		for _, typ := range stage.Types {
+			if _, ok := c.byType[strings.ToLower(typ)]; !ok {
+				c.byType[strings.ToLower(typ)] = i
+			}
+		}
+		if strings.EqualFold(stage.Name, w.Start) {
+			c.start = i
+		}
+		if strings.EqualFold(stage.Name, w.Done) {
+			c.done = i
+		}
+	}
+
+	switch {
+	case w.Start == "" && len(w.Stages) > 1:
+		c.start = 1
+	case w.Start == "":
+		c.start = 0
+	case c.start < 0:
+		return nil, fmt.Errorf("unknown start stage %q: %w", w.Start, clickup.ErrValidation)
+	}
+	switch {
+	case w.Done == "":
+		c.done = len(w.Stages) - 1
+	case c.done < 0:
+		return nil, fmt.Errorf("unknown done stage %q: %w", w.Done, clickup.ErrValidation)
+	}
+	if c.start > c.done {
+		return nil, fmt.Errorf("start stage %q is after done stage %q: %w", c.names[c.start], c.names[c.done], clickup.ErrValidation)
+	}
+	return c, nil
+}
+
+// stage returns the index of the stage of a status, or -1 if it is in none.
+func (w *workflow) stage(status, typ string) int {
+	if i, ok := w.byName[strings.ToLower(status)]; ok {
+		return i
+	}
+	if i, ok := w.byType[strings.ToLower(typ)]; ok {
+		return i
+	}
+	return -1
+}
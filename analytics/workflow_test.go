@@ -0,0 +1,84 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package analytics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func TestWorkflow_compile(t *testing.T) {
+	stages := []Stage{
+		{Name: "Backlog", Types: []string{"open"}},
+		{Name: "Doing", Statuses: []string{"in progress", "Review"}, Types: []string{"custom"}},
+		{Name: "Done", Statuses: []string{"review"}, Types: []string{"closed", "done"}},
+	}
+	tests := []struct {
+		name      string
+		workflow  Workflow
+		wantStart int
+		wantDone  int
+		wantErr   bool
+	}{
+		{name: "Defaults", workflow: Workflow{Stages: stages}, wantStart: 1, wantDone: 2},
+		{name: "Named stages", workflow: Workflow{Stages: stages, Start: "backlog", Done: "Doing"}, wantStart: 0, wantDone: 1},
+		{name: "Single stage", workflow: Workflow{Stages: stages[:1]}, wantStart: 0, wantDone: 0},
+		{name: "No stages", wantErr: true},
+		{name: "Unnamed stage", workflow: Workflow{Stages: []Stage{{Types: []string{"open"}}}}, wantErr: true},
+		{name: "Duplicate stage", workflow: Workflow{Stages: []Stage{{Name: "Done"}, {Name: "done"}}}, wantErr: true},
+		{name: "Unknown start", workflow: Workflow{Stages: stages, Start: "Testing"}, wantErr: true},
+		{name: "Unknown done", workflow: Workflow{Stages: stages, Done: "Shipped"}, wantErr: true},
+		{name: "Start after done", workflow: Workflow{Stages: stages, Start: "Done", Done: "Doing"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := tt.workflow.compile()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, clickup.ErrValidation) {
+					t.Errorf("compile() error = %v, want ErrValidation", err)
+				}
+				return
+			}
+			if wf.start != tt.wantStart || wf.done != tt.wantDone {
+				t.Errorf("start, done = %d, %d, want %d, %d", wf.start, wf.done, tt.wantStart, tt.wantDone)
+			}
+		})
+	}
+}
+
+func TestWorkflow_stage(t *testing.T) {
+	wf, err := (&Workflow{Stages: []Stage{
+		{Name: "Backlog", Types: []string{"open"}},
+		{Name: "Doing", Statuses: []string{"in progress", "Review"}, Types: []string{"custom"}},
+		{Name: "Done", Statuses: []string{"review"}, Types: []string{"closed", "done"}},
+	}}).compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		status, typ string
+		want        int
+	}{
+		{"to do", "open", 0},
+		{"In Progress", "custom", 1},
+		{"review", "closed", 1}, // a name comes before a type, and the first stage to name a status wins
+		{"blocked", "custom", 1},
+		{"complete", "done", 2},
+		{"archived", "", -1},
+	}
+	for _, tt := range tests {
+		if got := wf.stage(tt.status, tt.typ); got != tt.want {
+			t.Errorf("stage(%q, %q) = %d, want %d", tt.status, tt.typ, got, tt.want)
+		}
+	}
+}
@@ -7,73 +7,213 @@
 package clickup
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
+	"mime"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 type CreateAttachmentResponse struct {
-	ID             string `json:"id"`
-	Version        string `json:"version"`
-	Date           int    `json:"date"`
-	Title          string `json:"title"`
-	Extension      string `json:"extension"`
-	ThumbnailSmall string `json:"thumbnail_small"`
-	ThumbnailLarge string `json:"thumbnail_large"`
-	URL            string `json:"url"`
+	ID             string    `json:"id"`
+	Version        string    `json:"version"`
+	Date           Timestamp `json:"date"`
+	Title          string    `json:"title"`
+	Extension      string    `json:"extension"`
+	ThumbnailSmall string    `json:"thumbnail_small"`
+	ThumbnailLarge string    `json:"thumbnail_large"`
+	URL            string    `json:"url"`
 }
 
+// Attachment is a file attached to a task, as returned with SingleTask.
+type Attachment struct {
+	ID               string    `json:"id"`
+	Date             Timestamp `json:"date"`
+	Title            string    `json:"title"`
+	Extension        string    `json:"extension"`
+	Mimetype         string    `json:"mimetype"`
+	Size             int64     `json:"size"`
+	Hidden           bool      `json:"hidden"`
+	Deleted          bool      `json:"deleted"`
+	ParentID         string    `json:"parent_id"` // the task the attachment belongs to
+	ThumbnailSmall   string    `json:"thumbnail_small"`
+	ThumbnailMedium  string    `json:"thumbnail_medium"`
+	ThumbnailLarge   string    `json:"thumbnail_large"`
+	URL              string    `json:"url"`
+	URLWithQuery     string    `json:"url_w_query"`
+	URLWithHost      string    `json:"url_w_host"`
+	TotalComments    int       `json:"total_comments"`
+	ResolvedComments int       `json:"resolved_comments"`
+	User             TeamUser  `json:"user"` // the uploader
+}
+
+// ProgressFunc is called as an attachment is uploaded.  total is -1 if the size of the
+// attachment is not known ahead of time.
+type ProgressFunc func(fileName string, written, total int64)
+
 type AttachmentParams struct {
 	FileName string
 	Reader   io.Reader
+	// ContentType is optional.  If it is not provided, it is worked out from the extension of FileName,
+	// falling back to sniffing the first 512 bytes of Reader.
+	ContentType string
+	// Size is optional and only used as the total for Progress.  It is detected for *os.File and for readers
+	// with a Len() method (such as *bytes.Buffer) if not provided.
+	Size     int64
+	Progress ProgressFunc
 }
 
 // CreateTaskAttachment attaches a binary document such as an image, text file, etc. to a specific Clickup task using the
-// io.Reader on params.
+// io.Reader on params.  The contents of the Reader are streamed to Clickup rather than buffered in memory, so this
+// is suitable for large files.
 func (c *Client) CreateTaskAttachment(ctx context.Context, taskID, workspaceID string, useCustomTaskIDs bool, params *AttachmentParams) (*CreateAttachmentResponse, error) {
 	if useCustomTaskIDs && workspaceID == "" {
 		return nil, fmt.Errorf("workspaceID must be provided if querying by custom task id: %w", ErrValidation)
 	}
+	if params == nil || params.Reader == nil {
+		return nil, fmt.Errorf("must provide a reader for the attachment: %w", ErrValidation)
+	}
+
+	urlValues := url.Values{}
+	urlValues.Set("custom_task_ids", strconv.FormatBool(useCustomTaskIDs))
+	urlValues.Add("team_id", workspaceID)
+
+	endpoint := fmt.Sprintf("%s/task/%s/attachment/?%s", c.baseURL, taskID, urlValues.Encode())
+
+	return c.uploadAttachment(ctx, endpoint, params)
+}
+
+// CreateTaskAttachments uploads each of params to taskID, one after the other.  If an upload fails,
+// the attachments that were already created are returned along with the error.
+func (c *Client) CreateTaskAttachments(ctx context.Context, taskID, workspaceID string, useCustomTaskIDs bool, params ...*AttachmentParams) ([]CreateAttachmentResponse, error) {
+	attachments := make([]CreateAttachmentResponse, 0, len(params))
+	for _, p := range params {
+		attachment, err := c.CreateTaskAttachment(ctx, taskID, workspaceID, useCustomTaskIDs, p)
+		if err != nil {
+			name := ""
+			if p != nil {
+				name = p.FileName
+			}
+			return attachments, fmt.Errorf("failed to upload attachment %q: %w", name, err)
+		}
+		attachments = append(attachments, *attachment)
+	}
+	return attachments, nil
+}
+
+// CreateTaskCommentWithAttachmentLinks uploads params as attachments of the task comment.TaskID and then
+// posts comment with a line linking to each of them appended to it.  The files are attachments of the
+// task, not of the comment: the Clickup API has no endpoint that attaches files to a comment.
+// If an upload fails, the comment is not posted and the attachments that were already created are returned.
+func (c *Client) CreateTaskCommentWithAttachmentLinks(ctx context.Context, comment CreateTaskCommentRequest, params ...*AttachmentParams) (*CreateTaskCommentResponse, []CreateAttachmentResponse, error) {
+	if comment.TaskID == "" {
+		return nil, nil, fmt.Errorf("must provide a task id to create a task comment: %w", ErrValidation)
+	}
+	if comment.UseCustomTaskIDs && comment.WorkspaceID == "" {
+		return nil, nil, fmt.Errorf("must provide a workspace id for a new task comment if using custom task ID: %w", ErrValidation)
+	}
 
-	contents, err := ioutil.ReadAll(params.Reader)
+	attachments, err := c.CreateTaskAttachments(ctx, comment.TaskID, comment.WorkspaceID, comment.UseCustomTaskIDs, params...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read contents of Reader: %w", err)
+		return nil, attachments, err
+	}
+
+	// plain text comments are converted so that the attachments can be appended as blocks.
+	if comment.Comment == nil && comment.CommentText != "" {
+		comment.Comment = []ComplexComment{{Text: comment.CommentText}}
+		comment.CommentText = ""
+	}
+	for _, attachment := range attachments {
+		comment.Comment = append(comment.Comment, ComplexComment{
+			Text: fmt.Sprintf("\n%s: %s", attachment.Title, attachment.URL),
+		})
 	}
 
-	var body bytes.Buffer
-	multipartWriter := multipart.NewWriter(&body)
-	part, err := multipartWriter.CreateFormFile("attachment", filepath.Base(params.FileName)) // must be "attachment"
+	commentResponse, err := c.CreateTaskComment(ctx, comment)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create multipart field: %w", err)
+		return nil, attachments, err
 	}
-	part.Write(contents)
 
-	if err := multipartWriter.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	return commentResponse, attachments, nil
+}
+
+// uploadAttachment streams params as a multipart form to endpoint through an io.Pipe.
+func (c *Client) uploadAttachment(ctx context.Context, endpoint string, params *AttachmentParams) (*CreateAttachmentResponse, error) {
+	fileName := filepath.Base(params.FileName)
+	size := attachmentSize(params) // before anything is read from params.Reader
+
+	contents := bufio.NewReader(params.Reader)
+	contentType, err := attachmentContentType(fileName, params.ContentType, contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contents of Reader: %w", err)
 	}
 
-	urlValues := url.Values{}
-	urlValues.Set("custom_task_ids", strconv.FormatBool(useCustomTaskIDs))
-	urlValues.Add("team_id", workspaceID)
+	var body io.Reader = contents
+	if params.Progress != nil {
+		body = &progressReader{
+			reader:   contents,
+			fileName: fileName,
+			total:    size,
+			progress: params.Progress,
+		}
+	}
 
-	endpoint := fmt.Sprintf("%s/task/%s/attachment/?%s", c.baseURL, taskID, urlValues.Encode())
+	pr, pw := io.Pipe()
+	multipartWriter := multipart.NewWriter(pw)
+
+	// The writer is waited for before returning, so that params.Reader is not read after the upload
+	// has returned, even when the request ends before the whole body is sent.
+	written := make(chan error, 1)
+	go func() {
+		err := writeAttachmentPart(multipartWriter, fileName, contentType, body)
+		pw.CloseWithError(err)
+		written <- err
+	}()
+
+	attachment, err := c.postAttachment(ctx, endpoint, pr, multipartWriter.FormDataContentType())
+	pr.Close()
+	if werr := <-written; werr != nil && !errors.Is(werr, io.ErrClosedPipe) {
+		return nil, werr
+	}
+	return attachment, err
+}
+
+// writeAttachmentPart writes contents as the attachment field of a multipart form and closes the form.
+func writeAttachmentPart(w *multipart.Writer, fileName, contentType string, contents io.Reader) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="attachment"; filename="%s"`, escapeQuotes(fileName))) // must be "attachment"
+	header.Set("Content-Type", contentType)
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("failed to create multipart field: %w", err)
+	}
+	if _, err := io.Copy(part, contents); err != nil {
+		return fmt.Errorf("failed to read contents of Reader: %w", err)
+	}
+	return w.Close()
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+// postAttachment sends the multipart form read from body to endpoint.
+func (c *Client) postAttachment(ctx context.Context, endpoint string, body io.Reader, contentType string) (*CreateAttachmentResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
 	if err != nil {
 		return nil, fmt.Errorf("create attachment request failed: %w", err)
 	}
 	if err := c.AuthenticateFor(req); err != nil {
 		return nil, fmt.Errorf("failed to authenticate client: %w", err)
 	}
-	req.Header.Add("Content-Type", multipartWriter.FormDataContentType())
+	req.Header.Add("Content-Type", contentType)
 
 	res, err := c.doer.Do(req)
 	if err != nil {
@@ -95,3 +235,218 @@ func (c *Client) CreateTaskAttachment(ctx context.Context, taskID, workspaceID s
 
 	return &attachmentResponse, nil
 }
+
+// attachmentContentType returns contentType if provided, otherwise the type registered for the extension
+// of fileName, otherwise the type sniffed from the start of contents.
+func attachmentContentType(fileName, contentType string, contents *bufio.Reader) (string, error) {
+	if contentType != "" {
+		return contentType, nil
+	}
+	if byExtension := mime.TypeByExtension(filepath.Ext(fileName)); byExtension != "" {
+		return byExtension, nil
+	}
+	head, err := contents.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return "", err
+	}
+	return http.DetectContentType(head), nil
+}
+
+func attachmentSize(params *AttachmentParams) int64 {
+	if params.Size > 0 {
+		return params.Size
+	}
+	switch r := params.Reader.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case *os.File:
+		if info, err := r.Stat(); err == nil {
+			return info.Size()
+		}
+	}
+	return -1
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+type progressReader struct {
+	reader   io.Reader
+	fileName string
+	written  int64
+	total    int64
+	progress ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	if n > 0 {
+		p.written += int64(n)
+		p.progress(p.fileName, p.written, p.total)
+	}
+	return n, err
+}
+
+// DownloadAttachment streams the contents of attachment to w using the client's Doer, and returns the
+// number of bytes written.  The client's Authenticator is only used for https urls of Clickup hosts;
+// link attachments and files kept in external storage are requested without credentials.
+func (c *Client) DownloadAttachment(ctx context.Context, attachment Attachment, w io.Writer) (int64, error) {
+	if attachment.URL == "" {
+		return 0, fmt.Errorf("attachment %s has no url to download: %w", attachment.ID, ErrValidation)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, attachment.URL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("download attachment request failed: %w", err)
+	}
+	if isClickupURL(req.URL) {
+		if err := c.AuthenticateFor(req); err != nil {
+			return 0, fmt.Errorf("failed to authenticate client: %w", err)
+		}
+	}
+
+	res, err := c.doer.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to make download attachment request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, errorFromResponse(res, json.NewDecoder(res.Body))
+	}
+
+	written, err := io.Copy(w, res.Body)
+	if err != nil {
+		return written, fmt.Errorf("failed to download attachment %s: %w", attachment.ID, err)
+	}
+
+	return written, nil
+}
+
+// clickupDomains are the domains that attachments uploaded to Clickup are served from.
+var clickupDomains = []string{"clickup.com", "clickup-attachments.com"}
+
+// isClickupURL reports whether u is an https url of a Clickup host, so credentials may be sent to it.
+func isClickupURL(u *url.URL) bool {
+	if u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, domain := range clickupDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// AttachmentDestination opens the writer that a downloaded attachment of task is written to.
+// The writer is closed once the download completes.
+type AttachmentDestination func(task SingleTask, attachment Attachment) (io.WriteCloser, error)
+
+// AttachmentsToDir returns an AttachmentDestination that writes each attachment to
+// dir/<task id>/<attachment id>-<attachment title>, creating directories as needed.
+func AttachmentsToDir(dir string) AttachmentDestination {
+	return func(task SingleTask, attachment Attachment) (io.WriteCloser, error) {
+		taskDir := filepath.Join(dir, filepath.Base(task.ID))
+		if err := os.MkdirAll(taskDir, 0o755); err != nil {
+			return nil, err
+		}
+		name := attachment.ID
+		if title := filepath.Base(attachment.Title); title != "." && title != string(filepath.Separator) {
+			name = fmt.Sprintf("%s-%s", attachment.ID, title)
+		}
+		return os.Create(filepath.Join(taskDir, name))
+	}
+}
+
+type DownloadAttachmentsOptions struct {
+	Destination    AttachmentDestination // required
+	QueryOptions   *TaskQueryOptions     // narrows the tasks in the list, may be nil
+	MaxConcurrency int                   // maximum number of in flight requests.  Defaults to 4.
+}
+
+// AttachmentDownloadResult describes the download of a single attachment.  Err is set if
+// that attachment could not be downloaded.
+type AttachmentDownloadResult struct {
+	TaskID     string
+	Attachment Attachment
+	Written    int64
+	Err        error
+}
+
+// DownloadListAttachments downloads every attachment of every task in listID to opts.Destination.
+// Clickup only returns attachments when querying a single task, so each task in the list is queried
+// individually.  Failing to query the list or its tasks stops the download and returns an error, but a
+// failure to download an individual attachment is only reported in its AttachmentDownloadResult.
+// Attachments that have been deleted are skipped.
+func (c *Client) DownloadListAttachments(ctx context.Context, listID string, opts DownloadAttachmentsOptions) ([]AttachmentDownloadResult, error) {
+	if listID == "" {
+		return nil, fmt.Errorf("must provide a list id to download attachments: %w", ErrValidation)
+	}
+	if opts.Destination == nil {
+		return nil, fmt.Errorf("must provide a destination for downloaded attachments: %w", ErrValidation)
+	}
+	concurrency := opts.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	tasks, err := c.AllTasksForList(ctx, listID, opts.QueryOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = runConcurrently(ctx, len(tasks), concurrency, func(ctx context.Context, i int) error {
+		return retryOnRateLimit(ctx, func() error {
+			task, err := c.TaskByID(ctx, tasks[i].ID, "", false, false)
+			if err != nil {
+				return fmt.Errorf("failed to query attachments for task %s: %w", tasks[i].ID, err)
+			}
+			tasks[i] = *task
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []AttachmentDownloadResult
+	var owners []SingleTask
+	for _, task := range tasks {
+		for _, attachment := range task.Attachments {
+			if attachment.Deleted {
+				continue
+			}
+			results = append(results, AttachmentDownloadResult{TaskID: task.ID, Attachment: attachment})
+			owners = append(owners, task)
+		}
+	}
+
+	err = runConcurrently(ctx, len(results), concurrency, func(ctx context.Context, i int) error {
+		result := &results[i]
+
+		w, err := opts.Destination(owners[i], result.Attachment)
+		if err != nil {
+			result.Err = fmt.Errorf("failed to open destination: %w", err)
+			return nil
+		}
+
+		result.Written, result.Err = c.DownloadAttachment(ctx, result.Attachment, w)
+		if err := w.Close(); err != nil && result.Err == nil {
+			result.Err = fmt.Errorf("failed to close destination: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
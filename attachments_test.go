@@ -9,10 +9,16 @@ package clickup
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/iotest"
+	"time"
 )
 
 func TestClient_CreateTaskAttachment(t *testing.T) {
@@ -109,3 +115,343 @@ func TestClient_CreateTaskAttachment(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_CreateTaskAttachment_streamsMultipart(t *testing.T) {
+	tests := []struct {
+		name            string
+		params          *AttachmentParams
+		wantContentType string
+		wantProgress    int64
+		wantTotal       int64
+	}{
+		{
+			name: "Content type from extension",
+			params: &AttachmentParams{
+				FileName: "notes.json",
+				Reader:   bytes.NewBufferString(`{"a": 1}`),
+			},
+			wantContentType: "application/json",
+			wantProgress:    8,
+			wantTotal:       8,
+		},
+		{
+			name: "Content type sniffed from contents with unknown size",
+			params: &AttachmentParams{
+				FileName: "image",
+				Reader:   ioutil.NopCloser(strings.NewReader("\x89PNG\x0D\x0A\x1A\x0A rest of image")),
+			},
+			wantContentType: "image/png",
+			wantProgress:    22,
+			wantTotal:       -1,
+		},
+		{
+			name: "Explicit content type",
+			params: &AttachmentParams{
+				FileName:    "data.bin",
+				Reader:      strings.NewReader("abc"),
+				ContentType: "application/x-custom",
+			},
+			wantContentType: "application/x-custom",
+			wantProgress:    3,
+			wantTotal:       3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var written, total int64
+			tt.params.Progress = func(fileName string, w, tot int64) {
+				written, total = w, tot
+			}
+
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if err := req.ParseMultipartForm(1 << 20); err != nil {
+						t.Fatalf("ParseMultipartForm() error = %v", err)
+					}
+					files := req.MultipartForm.File["attachment"]
+					if len(files) != 1 {
+						t.Fatalf("got %d attachment parts, want 1", len(files))
+					}
+					if ct := files[0].Header.Get("Content-Type"); ct != tt.wantContentType {
+						t.Errorf("part Content-Type = %s, want %s", ct, tt.wantContentType)
+					}
+					body := `{"id": "test-attachment-id", "title": "` + files[0].Filename + `"}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			got, err := c.CreateTaskAttachment(context.Background(), "test-task-id", "", false, tt.params)
+			if err != nil {
+				t.Fatalf("Client.CreateTaskAttachment() error = %v", err)
+			}
+			if got.Title != tt.params.FileName {
+				t.Errorf("Client.CreateTaskAttachment() title = %s, want %s", got.Title, tt.params.FileName)
+			}
+			if written != tt.wantProgress || total != tt.wantTotal {
+				t.Errorf("progress = %d/%d, want %d/%d", written, total, tt.wantProgress, tt.wantTotal)
+			}
+		})
+	}
+}
+
+// slowReader returns a byte per Read after a pause, forever, and counts the reads started.
+type slowReader struct {
+	reads int32
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	atomic.AddInt32(&r.reads, 1)
+	time.Sleep(5 * time.Millisecond)
+	p[0] = 'a'
+	return 1, nil
+}
+
+func TestClient_CreateTaskAttachment_waitsForReader(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "Request error", err: errors.New("connection reset")},
+		{name: "Error response"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &slowReader{}
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					// Read the body until the contents have started, as a server that fails the
+					// request early would.
+					var body []byte
+					for !bytes.HasSuffix(body, []byte("aa")) {
+						b := make([]byte, 1)
+						if _, err := req.Body.Read(b); err != nil {
+							t.Fatalf("Read() error = %v", err)
+						}
+						body = append(body, b...)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &http.Response{
+						StatusCode: http.StatusBadRequest,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"err": "too large", "ECODE": "ATTACH_001"}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			_, err := c.CreateTaskAttachment(context.Background(), "test-task-id", "", false, &AttachmentParams{
+				FileName: "big.txt",
+				Reader:   reader,
+			})
+			if err == nil {
+				t.Fatal("Client.CreateTaskAttachment() error = nil, want error")
+			}
+			reads := atomic.LoadInt32(&reader.reads)
+			time.Sleep(20 * time.Millisecond)
+			if atomic.LoadInt32(&reader.reads) != reads {
+				t.Errorf("Reader was read after Client.CreateTaskAttachment() returned")
+			}
+		})
+	}
+}
+
+func TestClient_CreateTaskAttachment_readerError(t *testing.T) {
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			_, err := ioutil.ReadAll(req.Body)
+			return nil, err
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+	failed := errors.New("disk error")
+	_, err := c.CreateTaskAttachment(context.Background(), "test-task-id", "", false, &AttachmentParams{
+		FileName: "notes.txt",
+		Reader:   io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(failed)),
+	})
+	if !errors.Is(err, failed) {
+		t.Errorf("Client.CreateTaskAttachment() error = %v, want %v", err, failed)
+	}
+}
+
+func TestClient_CreateTaskCommentWithAttachmentLinks(t *testing.T) {
+	var commentBody string
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			body := `{"id": "attachment-id", "title": "a.txt", "url": "https://attachments/a.txt"}`
+			if strings.Contains(req.URL.Path, "/comment") {
+				b, _ := ioutil.ReadAll(req.Body)
+				commentBody = string(b)
+				body = `{"id": 1}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+
+	comment := NewCreateTaskCommentRequest("test-task-id", false, "")
+	comment.CommentText = "see attached"
+
+	_, attachments, err := c.CreateTaskCommentWithAttachmentLinks(context.Background(), *comment,
+		&AttachmentParams{FileName: "a.txt", Reader: strings.NewReader("a")},
+		&AttachmentParams{FileName: "b.txt", Reader: strings.NewReader("b")},
+	)
+	if err != nil {
+		t.Fatalf("Client.CreateTaskCommentWithAttachmentLinks() error = %v", err)
+	}
+	if len(attachments) != 2 {
+		t.Errorf("Client.CreateTaskCommentWithAttachmentLinks() attachments = %d, want 2", len(attachments))
+	}
+	want := `{"comment":[{"text":"see attached"},{"text":"\na.txt: https://attachments/a.txt"},{"text":"\na.txt: https://attachments/a.txt"}]}`
+	if commentBody != want {
+		t.Errorf("comment body = %s, want %s", commentBody, want)
+	}
+}
+
+func TestClient_DownloadAttachment(t *testing.T) {
+	tests := []struct {
+		name       string
+		attachment Attachment
+		status     int
+		want       string
+		wantAuth   string
+		wantErr    bool
+	}{
+		{
+			name:       "TestSuccessful download",
+			attachment: Attachment{ID: "a1", URL: "https://t123.p.clickup-attachments.com/t123/a1/file.txt"},
+			status:     http.StatusOK,
+			want:       "file contents",
+			wantAuth:   "token",
+		},
+		{
+			name:       "TestSuccessful download without credentials from a foreign host",
+			attachment: Attachment{ID: "a1", URL: "https://storage.example/a1/file.txt"},
+			status:     http.StatusOK,
+			want:       "file contents",
+		},
+		{
+			name:       "TestSuccessful download without credentials over http",
+			attachment: Attachment{ID: "a1", URL: "http://attachments.clickup.com/a1/file.txt"},
+			status:     http.StatusOK,
+			want:       "file contents",
+		},
+		{
+			name:       "TestSuccessful download without credentials from a lookalike host",
+			attachment: Attachment{ID: "a1", URL: "https://clickup.com.example/a1/file.txt"},
+			status:     http.StatusOK,
+			want:       "file contents",
+		},
+		{
+			name:       "TestFailure not found",
+			attachment: Attachment{ID: "a1", URL: "https://attachments.clickup.com/a1/file.txt"},
+			status:     http.StatusNotFound,
+			wantErr:    true,
+			wantAuth:   "token",
+		},
+		{
+			name:       "TestFailure missing url",
+			attachment: Attachment{ID: "a1"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if got := req.Header.Get("Authorization"); got != tt.wantAuth {
+						t.Errorf("Authorization header = %q, want %q", got, tt.wantAuth)
+					}
+					return &http.Response{
+						StatusCode: tt.status,
+						Body:       ioutil.NopCloser(strings.NewReader(tt.want)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{APIToken: "token"},
+			}
+			var buf bytes.Buffer
+			written, err := c.DownloadAttachment(context.Background(), tt.attachment, &buf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.DownloadAttachment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if buf.String() != tt.want || written != int64(len(tt.want)) {
+				t.Errorf("Client.DownloadAttachment() = %q (%d bytes), want %q", buf.String(), written, tt.want)
+			}
+		})
+	}
+}
+
+type closingBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *closingBuffer) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestClient_DownloadListAttachments(t *testing.T) {
+	responses := map[string]string{
+		"/list/list-id/task/": `{"tasks": [{"id": "t1"}, {"id": "t2"}]}`,
+		"/task/t1/":           `{"id": "t1", "attachments": [{"id": "a1", "title": "one.txt", "url": "https://attachments.example/a1"}, {"id": "gone", "deleted": true, "url": "https://attachments.example/gone"}]}`,
+		"/task/t2/":           `{"id": "t2", "attachments": [{"id": "a2", "title": "two.txt", "url": "https://attachments.example/a2"}, {"id": "a3", "url": "https://attachments.example/missing"}]}`,
+		"/a1":                 "first",
+		"/a2":                 "second",
+	}
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			body, ok := responses[req.URL.Path]
+			status := http.StatusOK
+			if !ok {
+				status = http.StatusNotFound
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+
+	var mu sync.Mutex
+	written := map[string]*closingBuffer{}
+	results, err := c.DownloadListAttachments(context.Background(), "list-id", DownloadAttachmentsOptions{
+		Destination: func(task SingleTask, attachment Attachment) (io.WriteCloser, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			buf := &closingBuffer{}
+			written[task.ID+"/"+attachment.ID] = buf
+			return buf, nil
+		},
+		MaxConcurrency: 2,
+	})
+	if err != nil {
+		t.Fatalf("Client.DownloadListAttachments() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Client.DownloadListAttachments() results = %d, want 3", len(results))
+	}
+	for _, result := range results {
+		if (result.Err != nil) != (result.Attachment.ID == "a3") {
+			t.Errorf("result for %s error = %v", result.Attachment.ID, result.Err)
+		}
+	}
+	if got := written["t1/a1"]; got.String() != "first" || !got.closed {
+		t.Errorf("t1/a1 = %q closed %v, want first closed true", got.String(), got.closed)
+	}
+	if got := written["t2/a2"]; got.String() != "second" || !got.closed {
+		t.Errorf("t2/a2 = %q closed %v, want second closed true", got.String(), got.closed)
+	}
+}
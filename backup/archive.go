@@ -0,0 +1,210 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package backup copies a Clickup workspace into a versioned zip archive of JSON files, and restores
+// an archive into a workspace.
+//
+// A restore recreates the spaces, folders, lists and tasks of the archive as new objects, so it can
+// recover a workspace after a disaster or clone a template workspace:
+//
+//	f, _ := os.Create("workspace.zip")
+//	manifest, err := backup.Backup(ctx, client, workspaceID, f, nil)
+//
+//	f, _ = os.Open("workspace.zip")
+//	info, _ := f.Stat()
+//	archive, err := backup.Open(f, info.Size())
+//	result, err := backup.Restore(ctx, client, archive, otherWorkspaceID, nil)
+//
+// The archive holds these files:
+//
+//	manifest.json          the Manifest
+//	spaces.json            spaces and their tags
+//	folders.json           folders
+//	lists.json             lists and their comments
+//	tasks/<list id>.json   the tasks of a list, with their comments
+//	goals.json             goals and their key results
+//	views.json             views of the workspace, spaces, folders and lists
+//	attachments/<id>       the content of a task attachment
+package backup
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// Version is the archive format written by Backup.  Open reads archives up to this version.
+const Version = 1
+
+const (
+	manifestFile   = "manifest.json"
+	spacesFile     = "spaces.json"
+	foldersFile    = "folders.json"
+	listsFile      = "lists.json"
+	tasksDir       = "tasks/"
+	goalsFile      = "goals.json"
+	viewsFile      = "views.json"
+	attachmentsDir = "attachments/"
+)
+
+// ErrNotArchive is returned by Open for a zip file without a manifest.
+var ErrNotArchive = errors.New("not a backup archive")
+
+// Manifest describes an archive.
+type Manifest struct {
+	Version     int               `json:"version"`
+	WorkspaceID string            `json:"workspace_id"`
+	Created     clickup.Timestamp `json:"created"`
+	Spaces      int               `json:"spaces"`
+	Folders     int               `json:"folders"`
+	Lists       int               `json:"lists"`
+	Tasks       int               `json:"tasks"`
+	Comments    int               `json:"comments"`
+	Goals       int               `json:"goals"`
+	Views       int               `json:"views"`
+	Attachments int               `json:"attachments"`
+}
+
+// Space is an archived space.
+type Space struct {
+	Space clickup.SingleSpace `json:"space"`
+	Tags  []clickup.Tag       `json:"tags"`
+}
+
+// List is an archived list.  FolderID is empty for a list that is not in a folder.
+type List struct {
+	SpaceID  string             `json:"space_id"`
+	FolderID string             `json:"folder_id"`
+	List     clickup.SingleList `json:"list"`
+	Comments []Comment          `json:"comments"`
+}
+
+// Task is an archived task.  Its checklists, dependencies, links and attachments are part of Task.
+type Task struct {
+	Task     clickup.SingleTask `json:"task"`
+	Comments []Comment          `json:"comments"`
+}
+
+// Comment is an archived task or list comment.
+type Comment struct {
+	ID          string                   `json:"id"`
+	Comment     []clickup.ComplexComment `json:"comment"`
+	CommentText string                   `json:"comment_text"`
+	User        *clickup.TeamUser        `json:"user"`
+	Date        clickup.Timestamp        `json:"date"`
+}
+
+// Archive is the content of an archive read by Open.  Attachments are read on demand.
+type Archive struct {
+	Manifest Manifest
+	Spaces   []Space
+	Folders  []clickup.SingleFolder
+	Lists    []List
+	Tasks    []Task // in the order of Lists
+	Goals    []clickup.GoalResponse
+	Views    []clickup.SingleView
+
+	zr *zip.Reader
+}
+
+// Open reads the archive in r, which is size bytes long.
+func Open(r io.ReaderAt, size int64) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotArchive, err)
+	}
+	a := &Archive{zr: zr}
+
+	found, err := a.readJSON(manifestFile, &a.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: missing %s", ErrNotArchive, manifestFile)
+	}
+	if a.Manifest.Version < 1 || a.Manifest.Version > Version {
+		return nil, fmt.Errorf("archive version %d is not supported, want 1 to %d", a.Manifest.Version, Version)
+	}
+
+	for name, v := range map[string]interface{}{
+		spacesFile:  &a.Spaces,
+		foldersFile: &a.Folders,
+		listsFile:   &a.Lists,
+		goalsFile:   &a.Goals,
+		viewsFile:   &a.Views,
+	} {
+		if _, err := a.readJSON(name, v); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, list := range a.Lists {
+		var tasks []Task
+		if _, err := a.readJSON(tasksFile(list.List.ID), &tasks); err != nil {
+			return nil, err
+		}
+		a.Tasks = append(a.Tasks, tasks...)
+	}
+
+	return a, nil
+}
+
+// Attachment opens the archived content of the attachment with id.  It returns an error wrapping
+// fs.ErrNotExist if the attachment was not archived.
+func (a *Archive) Attachment(id string) (io.ReadCloser, error) {
+	return a.zr.Open(attachmentsDir + id)
+}
+
+// Files returns the names of the files in the archive, sorted.
+func (a *Archive) Files() []string {
+	names := make([]string, 0, len(a.zr.File))
+	for _, f := range a.zr.File {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// readJSON decodes the file name into v.  It returns false if the archive has no such file.
+func (a *Archive) readJSON(name string, v interface{}) (bool, error) {
+	f, err := a.zr.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer f.Close()
+
+	if err := json.NewDecoder(f).Decode(v); err != nil {
+		return true, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return true, nil
+}
+
+func tasksFile(listID string) string {
+	return tasksDir + listID + ".json"
+}
+
+// writeJSON adds the file name holding v to zw.
+func writeJSON(zw *zip.Writer, name string, v interface{}) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// Options controls what Backup archives.  A nil *Options archives everything that is not archived in
+// Clickup.
+type Options struct {
+	// IncludeArchived also archives archived spaces, folders, lists and tasks.
+	IncludeArchived bool
+	SkipComments    bool
+	SkipAttachments bool
+	// Log receives a line for every list as it is archived.
+	Log io.Writer
+}
+
+// Backup writes an archive of workspaceID to w.  It walks the spaces, folders and lists of the
+// workspace, archiving the tasks of every list with their comments, checklists and attachments, and
+// the tags, goals and views of the workspace.  Requests that are rate limited are retried.
+//
+// Backup returns the Manifest written to the archive.  w does not hold a usable archive if Backup
+// returns an error.
+func Backup(ctx context.Context, client *clickup.Client, workspaceID string, w io.Writer, opts *Options) (*Manifest, error) {
+	if workspaceID == "" {
+		return nil, fmt.Errorf("must provide a workspace id to back up: %w", clickup.ErrValidation)
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	b := &backup{
+		ctx:    ctx,
+		client: client,
+		opts:   opts,
+		zw:     zip.NewWriter(w),
+		manifest: Manifest{
+			Version:     Version,
+			WorkspaceID: workspaceID,
+			Created:     clickup.NewTimestamp(time.Now()),
+		},
+	}
+	if err := b.workspace(workspaceID); err != nil {
+		return nil, err
+	}
+	if err := b.zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return &b.manifest, nil
+}
+
+type backup struct {
+	ctx      context.Context
+	client   *clickup.Client
+	opts     *Options
+	zw       *zip.Writer
+	manifest Manifest
+
+	spaces  []Space
+	folders []clickup.SingleFolder
+	lists   []List
+	goals   []clickup.GoalResponse
+	views   []clickup.SingleView
+}
+
+// call runs fn, retrying it while it is rate limited.
+func (b *backup) call(fn func() error) error {
+	return clickup.RetryOnRateLimit(b.ctx, fn)
+}
+
+func (b *backup) workspace(workspaceID string) error {
+	var spaces *clickup.SpacesResponse
+	if err := b.call(func() (err error) {
+		spaces, err = b.client.SpacesForWorkspace(b.ctx, workspaceID, b.opts.IncludeArchived)
+		return err
+	}); err != nil {
+		return fmt.Errorf("spaces: %w", err)
+	}
+	for _, space := range spaces.Spaces {
+		if err := b.space(space); err != nil {
+			return fmt.Errorf("space %s: %w", space.ID, err)
+		}
+	}
+
+	if err := b.addViews(clickup.TypeTeam, workspaceID); err != nil {
+		return fmt.Errorf("workspace views: %w", err)
+	}
+
+	var goals *clickup.GetGoalsResponse
+	if err := b.call(func() (err error) {
+		goals, err = b.client.GoalsForWorkspace(b.ctx, workspaceID, true)
+		return err
+	}); err != nil {
+		return fmt.Errorf("goals: %w", err)
+	}
+	for _, goal := range goals.AllGoals() {
+		// Goals are listed without their key results.
+		var full *clickup.GoalResponse
+		if err := b.call(func() (err error) {
+			full, err = b.client.GoalForWorkSpace(b.ctx, goal.ID)
+			return err
+		}); err != nil {
+			return fmt.Errorf("goal %s: %w", goal.ID, err)
+		}
+		if full.FolderID == "" {
+			full.FolderID = goal.FolderID
+		}
+		b.goals = append(b.goals, *full)
+	}
+
+	b.manifest.Spaces = len(b.spaces)
+	b.manifest.Folders = len(b.folders)
+	b.manifest.Lists = len(b.lists)
+	b.manifest.Goals = len(b.goals)
+	b.manifest.Views = len(b.views)
+
+	for _, file := range []struct {
+		name string
+		v    interface{}
+	}{
+		{spacesFile, b.spaces},
+		{foldersFile, b.folders},
+		{listsFile, b.lists},
+		{goalsFile, b.goals},
+		{viewsFile, b.views},
+		{manifestFile, b.manifest},
+	} {
+		if err := writeJSON(b.zw, file.name, file.v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *backup) space(space clickup.SingleSpace) error {
+	var tags *clickup.TagsQueryResponse
+	if err := b.call(func() (err error) {
+		tags, err = b.client.TagsForSpace(b.ctx, space.ID)
+		return err
+	}); err != nil {
+		return fmt.Errorf("tags: %w", err)
+	}
+	b.spaces = append(b.spaces, Space{Space: space, Tags: tags.Tags})
+
+	if err := b.addViews(clickup.TypeSpace, space.ID); err != nil {
+		return fmt.Errorf("views: %w", err)
+	}
+
+	var folders *clickup.FoldersResponse
+	if err := b.call(func() (err error) {
+		folders, err = b.client.FoldersForSpace(b.ctx, space.ID, b.opts.IncludeArchived)
+		return err
+	}); err != nil {
+		return fmt.Errorf("folders: %w", err)
+	}
+	for _, folder := range folders.Folders {
+		b.folders = append(b.folders, folder)
+		if err := b.addViews(clickup.TypeFolder, folder.ID); err != nil {
+			return fmt.Errorf("folder %s views: %w", folder.ID, err)
+		}
+
+		var lists *clickup.ListsResponse
+		if err := b.call(func() (err error) {
+			lists, err = b.client.ListsForFolder(b.ctx, folder.ID, b.opts.IncludeArchived)
+			return err
+		}); err != nil {
+			return fmt.Errorf("folder %s lists: %w", folder.ID, err)
+		}
+		for _, list := range lists.Lists {
+			if err := b.list(space.ID, folder.ID, list); err != nil {
+				return fmt.Errorf("list %s: %w", list.ID, err)
+			}
+		}
+	}
+
+	var lists *clickup.ListsResponse
+	if err := b.call(func() (err error) {
+		lists, err = b.client.FolderlessLists(b.ctx, space.ID, b.opts.IncludeArchived)
+		return err
+	}); err != nil {
+		return fmt.Errorf("folderless lists: %w", err)
+	}
+	for _, list := range lists.Lists {
+		if err := b.list(space.ID, "", list); err != nil {
+			return fmt.Errorf("list %s: %w", list.ID, err)
+		}
+	}
+	return nil
+}
+
+// list archives the tasks of list as soon as they are read, so only the lists themselves are kept in
+// memory until the end of the backup.
+func (b *backup) list(spaceID, folderID string, list clickup.SingleList) error {
+	archived := List{SpaceID: spaceID, FolderID: folderID, List: list}
+
+	if err := b.addViews(clickup.TypeList, list.ID); err != nil {
+		return fmt.Errorf("views: %w", err)
+	}
+	if !b.opts.SkipComments {
+		comments, err := b.comments(clickup.CommentsQuery{ListID: list.ID})
+		if err != nil {
+			return err
+		}
+		archived.Comments = comments
+	}
+	b.lists = append(b.lists, archived)
+
+	var tasks []clickup.SingleTask
+	if err := b.call(func() (err error) {
+		tasks, err = b.client.AllTasksForList(b.ctx, list.ID, &clickup.TaskQueryOptions{
+			IncludeArchived: b.opts.IncludeArchived,
+			IncludeSubtasks: true,
+			IncludeClosed:   true,
+		})
+		return err
+	}); err != nil {
+		return fmt.Errorf("tasks: %w", err)
+	}
+
+	archivedTasks := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		archivedTask := Task{Task: task}
+		if !b.opts.SkipComments {
+			comments, err := b.comments(clickup.CommentsQuery{TaskID: task.ID})
+			if err != nil {
+				return fmt.Errorf("task %s: %w", task.ID, err)
+			}
+			archivedTask.Comments = comments
+		}
+		if !b.opts.SkipAttachments {
+			for _, attachment := range task.Attachments {
+				if attachment.Deleted {
+					continue
+				}
+				if err := b.attachment(attachment); err != nil {
+					return fmt.Errorf("task %s: %w", task.ID, err)
+				}
+			}
+		}
+		archivedTasks = append(archivedTasks, archivedTask)
+	}
+	b.manifest.Tasks += len(archivedTasks)
+
+	if b.opts.Log != nil {
+		fmt.Fprintf(b.opts.Log, "list %s %q: %d tasks\n", list.ID, list.Name, len(archivedTasks))
+	}
+	return writeJSON(b.zw, tasksFile(list.ID), archivedTasks)
+}
+
+// comments returns the comments of the task or list in query.
+func (b *backup) comments(query clickup.CommentsQuery) ([]Comment, error) {
+	var res clickup.CommentsResponse
+	if err := b.call(func() (err error) {
+		if query.ListID != "" {
+			res, err = b.client.ListComments(b.ctx, clickup.CommentsForListQuery{CommentsQuery: query})
+		} else {
+			res, err = b.client.TaskComments(b.ctx, clickup.CommentsForTaskQuery{CommentsQuery: query})
+		}
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("comments: %w", err)
+	}
+
+	comments := make([]Comment, 0, len(res.Comments))
+	for _, c := range res.Comments {
+		comments = append(comments, Comment{
+			ID:          c.ID,
+			Comment:     c.Comment,
+			CommentText: c.CommentText,
+			User:        c.User,
+			Date:        c.Date,
+		})
+	}
+	b.manifest.Comments += len(comments)
+	return comments, nil
+}
+
+func (b *backup) attachment(attachment clickup.Attachment) error {
+	// The download is buffered so that a rate limited request can be retried from the start.
+	var buf bytes.Buffer
+	if err := b.call(func() error {
+		buf.Reset()
+		_, err := b.client.DownloadAttachment(b.ctx, attachment, &buf)
+		return err
+	}); err != nil {
+		return fmt.Errorf("attachment %s: %w", attachment.ID, err)
+	}
+
+	f, err := b.zw.Create(attachmentsDir + attachment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to add attachment %s: %w", attachment.ID, err)
+	}
+	if _, err := buf.WriteTo(f); err != nil {
+		return fmt.Errorf("failed to write attachment %s: %w", attachment.ID, err)
+	}
+	b.manifest.Attachments++
+	return nil
+}
+
+func (b *backup) addViews(parentType clickup.ViewListType, id string) error {
+	var views *clickup.GetViewsResponse
+	if err := b.call(func() (err error) {
+		views, err = b.client.ViewsFor(b.ctx, parentType, id)
+		return err
+	}); err != nil {
+		return err
+	}
+	b.views = append(b.views, views.Views...)
+	return nil
+}
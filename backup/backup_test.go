@@ -0,0 +1,248 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// fakeClickup answers GET requests from routes, keyed by method and path, and records every POST and
+// PUT.  They are answered with an id numbered by their position in posts, in every shape Clickup
+// wraps a created object in, unless their body contains reject.  Comment ids are numbers.
+type fakeClickup struct {
+	routes map[string]string
+	posts  []string
+	reject string
+}
+
+func (f *fakeClickup) Do(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + strings.TrimPrefix(req.URL.Path, "/api/v2")
+	if req.URL.Host == "files.example.com" {
+		key = req.Method + " " + req.URL.String()
+	}
+	status, body := http.StatusOK, ""
+	if req.Method == http.MethodPost || req.Method == http.MethodPut {
+		b, _ := ioutil.ReadAll(req.Body)
+		if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+			b = []byte("<file>")
+		}
+		f.posts = append(f.posts, key+" "+string(b))
+		id := fmt.Sprintf(`"id":"n%d"`, len(f.posts))
+		body = fmt.Sprintf(`{%s,"goal":{%s},"key_result":{%s},"view":{%s},"checklist":{%s,"items":[{%s}]}}`, id, id, id, id, id, id)
+		if strings.Contains(key, "/comment") {
+			body = fmt.Sprintf(`{"id":%d}`, len(f.posts))
+		}
+		if f.reject != "" && strings.Contains(string(b), f.reject) {
+			status, body = http.StatusBadRequest, `{"err":"Status not found","ECODE":"ITEM_114"}`
+		}
+	} else if b, ok := f.routes[key]; ok {
+		body = b
+	} else {
+		status, body = http.StatusNotFound, `{"err":"Route not found","ECODE":"APP_001"}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func newTestClient(doer clickup.ClientDoer) *clickup.Client {
+	return clickup.NewClient(&clickup.ClientOpts{
+		Doer:          doer,
+		Authenticator: &clickup.APITokenAuthenticator{APIToken: "pk_test"},
+	})
+}
+
+var workspaceRoutes = map[string]string{
+	"GET /team/w1/space/":   `{"spaces":[{"id":"s1","name":"Engineering","multiple_assignees":true}]}`,
+	"GET /space/s1/tag":     `{"tags":[{"name":"bug","tag_fg":"#fff","tag_bg":"#f00"}]}`,
+	"GET /space/s1/view":    `{"views":[{"id":"v-s","name":"Board","type":"board","parent":{"id":"s1","type":4}}]}`,
+	"GET /space/s1/folder/": `{"folders":[{"id":"f1","name":"Roadmap","space":{"id":"s1"}}]}`,
+	"GET /folder/f1/view":   `{"views":[]}`,
+	"GET /folder/f1/list/":  `{"lists":[{"id":"l1","name":"Q3","content":"Plans"}]}`,
+	"GET /space/s1/list/":   `{"lists":[{"id":"l2","name":"Inbox"}]}`,
+	"GET /list/l1/view":     `{"views":[]}`,
+	"GET /list/l2/view":     `{"views":[{"id":"v-l","name":"Open","type":"list","parent":{"id":"l2","type":6}}]}`,
+	"GET /list/l1/comment":  `{"comments":[{"id":"c-l","comment_text":"kickoff","date":"1656633600000"}]}`,
+	"GET /list/l2/comment":  `{"comments":[]}`,
+	"GET /list/l1/task/": `{"tasks":[
+		{"id":"t1","name":"Parent","list":{"id":"l1"},"attachments":[{"id":"a1","title":"notes.txt","url":"https://files.example.com/a1"},{"id":"a2","deleted":true}]},
+		{"id":"t2","name":"Child","parent":"t1","list":{"id":"l1"}}
+	]}`,
+	"GET /list/l2/task/":               `{"tasks":[]}`,
+	"GET /task/t1/comment/":            `{"comments":[{"id":"c1","comment":[{"text":"hello"}],"comment_text":"hello","user":{"id":7},"date":"1656633600000"}]}`,
+	"GET /task/t2/comment/":            `{"comments":[]}`,
+	"GET https://files.example.com/a1": "attached notes",
+	"GET /team/w1/view":                `{"views":[{"id":"v-w","name":"Everything","type":"list","parent":{"id":"w1","type":7}}]}`,
+	"GET /team/w1/goal/":               `{"goals":[{"id":"g1","name":"Ship"}],"folders":[{"id":"gf1","name":"Q3","goals":[{"id":"g2","name":"Hire"}]}]}`,
+	"GET /goal/g1":                     `{"id":"g1","name":"Ship","key_results":[{"id":"k1","name":"Tasks done","type":"automatic","task_ids":["t1"]}]}`,
+	"GET /goal/g2":                     `{"id":"g2","name":"Hire","key_results":[]}`,
+}
+
+func TestBackup(t *testing.T) {
+	var buf bytes.Buffer
+	var log strings.Builder
+	manifest, err := Backup(context.Background(), newTestClient(&fakeClickup{routes: workspaceRoutes}), "w1", &buf, &Options{Log: &log})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Manifest{
+		Version:     Version,
+		WorkspaceID: "w1",
+		Created:     manifest.Created,
+		Spaces:      1,
+		Folders:     1,
+		Lists:       2,
+		Tasks:       2,
+		Comments:    2,
+		Goals:       2,
+		Views:       3,
+		Attachments: 1,
+	}
+	if *manifest != want {
+		t.Errorf("Backup() manifest = %+v, want %+v", *manifest, want)
+	}
+	if want := "list l1 \"Q3\": 2 tasks\nlist l2 \"Inbox\": 0 tasks\n"; log.String() != want {
+		t.Errorf("log = %q, want %q", log.String(), want)
+	}
+
+	archive, err := Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFiles := []string{
+		"attachments/a1",
+		"folders.json",
+		"goals.json",
+		"lists.json",
+		"manifest.json",
+		"spaces.json",
+		"tasks/l1.json",
+		"tasks/l2.json",
+		"views.json",
+	}
+	if got := archive.Files(); !reflect.DeepEqual(got, wantFiles) {
+		t.Errorf("Files() = %v, want %v", got, wantFiles)
+	}
+	if archive.Manifest.Created.Millis() != manifest.Created.Millis() || archive.Manifest.Tasks != 2 {
+		t.Errorf("archived manifest = %+v, want %+v", archive.Manifest, *manifest)
+	}
+	if len(archive.Spaces) != 1 || len(archive.Spaces[0].Tags) != 1 || archive.Spaces[0].Tags[0].TagBg != "#f00" {
+		t.Errorf("spaces = %+v", archive.Spaces)
+	}
+	if len(archive.Lists) != 2 || archive.Lists[0].FolderID != "f1" || archive.Lists[1].FolderID != "" ||
+		archive.Lists[1].SpaceID != "s1" || archive.Lists[0].List.Content != "Plans" || len(archive.Lists[0].Comments) != 1 {
+		t.Errorf("lists = %+v", archive.Lists)
+	}
+	if len(archive.Tasks) != 2 || archive.Tasks[1].Task.Parent != "t1" || archive.Tasks[0].Comments[0].Comment[0].Text != "hello" {
+		t.Errorf("tasks = %+v", archive.Tasks)
+	}
+	if len(archive.Goals) != 2 || len(archive.Goals[0].KeyResults) != 1 || archive.Goals[1].FolderID != "gf1" {
+		t.Errorf("goals = %+v", archive.Goals)
+	}
+
+	f, err := archive.Attachment("a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if b, _ := ioutil.ReadAll(f); string(b) != "attached notes" {
+		t.Errorf("attachment = %q, want %q", b, "attached notes")
+	}
+}
+
+func TestBackup_Errors(t *testing.T) {
+	routes := make(map[string]string)
+	for k, v := range workspaceRoutes {
+		routes[k] = v
+	}
+	delete(routes, "GET /list/l1/task/")
+
+	_, err := Backup(context.Background(), newTestClient(&fakeClickup{routes: routes}), "w1", ioutil.Discard, nil)
+	if err == nil || !strings.Contains(err.Error(), "list l1: tasks") {
+		t.Errorf("Backup() error = %v, want a list l1 tasks error", err)
+	}
+
+	_, err = Backup(context.Background(), newTestClient(&fakeClickup{}), "", ioutil.Discard, nil)
+	if !errors.Is(err, clickup.ErrValidation) {
+		t.Errorf("Backup() without a workspace error = %v, want ErrValidation", err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	zipOf := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, content := range files {
+			f, _ := zw.Create(name)
+			f.Write([]byte(content))
+		}
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name    string
+		archive []byte
+		wantErr string
+	}{
+		{
+			name:    "Minimal archive",
+			archive: zipOf(map[string]string{"manifest.json": `{"version":1,"workspace_id":"w1"}`}),
+		},
+		{
+			name:    "Not a zip file",
+			archive: []byte("tasks"),
+			wantErr: "not a backup archive",
+		},
+		{
+			name:    "Missing manifest",
+			archive: zipOf(map[string]string{"spaces.json": `[]`}),
+			wantErr: "not a backup archive: missing manifest.json",
+		},
+		{
+			name:    "Newer version",
+			archive: zipOf(map[string]string{"manifest.json": `{"version":2}`}),
+			wantErr: "archive version 2 is not supported",
+		},
+		{
+			name:    "Invalid file",
+			archive: zipOf(map[string]string{"manifest.json": `{"version":1}`, "lists.json": `{`}),
+			wantErr: "failed to read lists.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive, err := Open(bytes.NewReader(tt.archive), int64(len(tt.archive)))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if archive.Manifest.WorkspaceID != "w1" {
+					t.Errorf("manifest = %+v", archive.Manifest)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Open() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,836 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// RestoreOptions controls a Restore.  A nil *RestoreOptions restores everything and keeps user ids.
+type RestoreOptions struct {
+	// Users maps the user ids of the archived workspace to users of the target workspace, for
+	// assignees, goal owners and comment mentions.  When nil, user ids are kept, which suits a restore
+	// into the same workspace or one with the same members.  Otherwise users missing from Users are
+	// left out.
+	Users           map[int]int
+	SkipComments    bool
+	SkipAttachments bool
+	SkipGoals       bool
+	SkipViews       bool
+	// Log receives a line for every warning as it happens.
+	Log io.Writer
+}
+
+// IDMap maps the ids of archived objects to the ids of the objects a Restore created for them.
+type IDMap struct {
+	Spaces     map[string]string
+	Folders    map[string]string
+	Lists      map[string]string
+	Tasks      map[string]string
+	Checklists map[string]string
+	Goals      map[string]string
+	KeyResults map[string]string
+	Views      map[string]string
+}
+
+// RestoreResult is the outcome of a Restore.
+type RestoreResult struct {
+	IDs IDMap
+	// Warnings describe the parts of the archive that could not be restored, such as a custom field
+	// the new list does not have or a comment that Clickup rejected.
+	Warnings []string
+}
+
+// Restore recreates archive in workspaceID.  Spaces, folders, lists and tasks are created as new
+// objects, subtasks under their new parents, and the dependencies, links, goals and views of the
+// archive are pointed at the new objects.  Restore stops at the first space, folder, list or task
+// that cannot be created; anything else that fails is recorded as a warning.
+//
+// Clickup does not let statuses or custom fields be created with the API.  A task whose status the
+// new list does not have is created with the default status, and custom field values are only
+// restored into fields, matched by name, that the new lists inherit from the workspace.  Goal
+// folders are not restored.
+//
+// The result is returned even when Restore fails, so that the objects created so far can be found.
+func Restore(ctx context.Context, client *clickup.Client, archive *Archive, workspaceID string, opts *RestoreOptions) (*RestoreResult, error) {
+	if opts == nil {
+		opts = &RestoreOptions{}
+	}
+	r := &restorer{
+		ctx:         ctx,
+		client:      client,
+		archive:     archive,
+		workspaceID: workspaceID,
+		opts:        opts,
+		result: &RestoreResult{IDs: IDMap{
+			Spaces:     make(map[string]string),
+			Folders:    make(map[string]string),
+			Lists:      make(map[string]string),
+			Tasks:      make(map[string]string),
+			Checklists: make(map[string]string),
+			Goals:      make(map[string]string),
+			KeyResults: make(map[string]string),
+			Views:      make(map[string]string),
+		}},
+		fields: make(map[string][]clickup.ListCustomField),
+	}
+	if workspaceID == "" {
+		return r.result, fmt.Errorf("must provide a workspace id to restore into: %w", clickup.ErrValidation)
+	}
+
+	steps := []func() error{r.spaces, r.folders, r.lists, r.tasks, r.checklists, r.dependencies}
+	if !opts.SkipComments {
+		steps = append(steps, r.comments)
+	}
+	if !opts.SkipAttachments {
+		steps = append(steps, r.attachments)
+	}
+	if !opts.SkipGoals {
+		steps = append(steps, r.goals)
+	}
+	if !opts.SkipViews {
+		steps = append(steps, r.views)
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return r.result, err
+		}
+	}
+	return r.result, nil
+}
+
+type restorer struct {
+	ctx         context.Context
+	client      *clickup.Client
+	archive     *Archive
+	workspaceID string
+	opts        *RestoreOptions
+	result      *RestoreResult
+
+	fields map[string][]clickup.ListCustomField // by new list id
+}
+
+// call runs fn, retrying it while it is rate limited.
+func (r *restorer) call(fn func() error) error {
+	return clickup.RetryOnRateLimit(r.ctx, fn)
+}
+
+// warn records a warning for err, or returns err if the restore has been cancelled.
+func (r *restorer) warn(err error, format string, args ...interface{}) error {
+	if ctxErr := r.ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	warning := fmt.Sprintf(format, args...)
+	if err != nil {
+		warning += ": " + err.Error()
+	}
+	r.result.Warnings = append(r.result.Warnings, warning)
+	if r.opts.Log != nil {
+		fmt.Fprintln(r.opts.Log, warning)
+	}
+	return nil
+}
+
+// user returns the user in the target workspace for the archived user id.
+func (r *restorer) user(id int) (int, bool) {
+	if r.opts.Users == nil {
+		return id, id != 0
+	}
+	newID, ok := r.opts.Users[id]
+	return newID, ok
+}
+
+func (r *restorer) users(users []clickup.TeamUser) []int {
+	ids := make([]int, 0, len(users))
+	for _, u := range users {
+		if id, ok := r.user(u.ID); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (r *restorer) spaces() error {
+	for _, space := range r.archive.Spaces {
+		request := clickup.CreateSpaceRequest{
+			WorkspaceID:       r.workspaceID,
+			Name:              space.Space.Name,
+			MultipleAssignees: space.Space.MultipleAssignees,
+		}
+		// The features of a space are returned and sent with the same names.
+		if b, err := json.Marshal(space.Space.Features); err == nil {
+			var features clickup.Features
+			if json.Unmarshal(b, &features) == nil {
+				request.Features = &features
+			}
+		}
+
+		var created *clickup.SingleSpace
+		if err := r.call(func() (err error) {
+			created, err = r.client.CreateSpaceForWorkspace(r.ctx, request)
+			return err
+		}); err != nil {
+			return fmt.Errorf("space %s: %w", space.Space.ID, err)
+		}
+		r.result.IDs.Spaces[space.Space.ID] = created.ID
+
+		for _, tag := range space.Tags {
+			tag := clickup.Tag{Name: tag.Name, TagFg: tag.TagFg, TagBg: tag.TagBg}
+			if err := r.call(func() error {
+				return r.client.CreateSpaceTag(r.ctx, created.ID, tag)
+			}); err != nil {
+				if err := r.warn(err, "space %s: tag %q", space.Space.ID, tag.Name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *restorer) folders() error {
+	for _, folder := range r.archive.Folders {
+		spaceID, ok := r.result.IDs.Spaces[folder.Space.ID]
+		if !ok {
+			return fmt.Errorf("folder %s: space %s is not in the archive", folder.ID, folder.Space.ID)
+		}
+
+		var created *clickup.SingleFolder
+		if err := r.call(func() (err error) {
+			created, err = r.client.CreateFolder(r.ctx, clickup.CreateFolderRequest{SpaceID: spaceID, Name: folder.Name})
+			return err
+		}); err != nil {
+			return fmt.Errorf("folder %s: %w", folder.ID, err)
+		}
+		r.result.IDs.Folders[folder.ID] = created.ID
+	}
+	return nil
+}
+
+func (r *restorer) lists() error {
+	for _, list := range r.archive.Lists {
+		request := clickup.CreateListRequest{
+			Name:    list.List.Name,
+			Content: list.List.Content,
+		}
+		if !list.List.DueDate.IsZero() {
+			request.DueDate = list.List.DueDate.Ptr()
+		}
+		var ok bool
+		if list.FolderID != "" {
+			request.FolderID, ok = r.result.IDs.Folders[list.FolderID]
+		} else {
+			request.SpaceID, ok = r.result.IDs.Spaces[list.SpaceID]
+		}
+		if !ok {
+			return fmt.Errorf("list %s: its folder or space is not in the archive", list.List.ID)
+		}
+
+		var created *clickup.SingleList
+		if err := r.call(func() (err error) {
+			created, err = r.client.CreateList(r.ctx, request)
+			return err
+		}); err != nil {
+			return fmt.Errorf("list %s: %w", list.List.ID, err)
+		}
+		r.result.IDs.Lists[list.List.ID] = created.ID
+	}
+	return nil
+}
+
+// tasks creates every task after its parent, so that subtasks can be created under the new parent.
+func (r *restorer) tasks() error {
+	byID := make(map[string]*Task, len(r.archive.Tasks))
+	for i := range r.archive.Tasks {
+		byID[r.archive.Tasks[i].Task.ID] = &r.archive.Tasks[i]
+	}
+
+	visiting := make(map[string]bool)
+	var create func(task *Task) error
+	create = func(task *Task) error {
+		if _, done := r.result.IDs.Tasks[task.Task.ID]; done || visiting[task.Task.ID] {
+			return nil
+		}
+		visiting[task.Task.ID] = true
+		if parent, ok := byID[task.Task.Parent]; ok {
+			if err := create(parent); err != nil {
+				return err
+			}
+		}
+		return r.task(task.Task)
+	}
+
+	for i := range r.archive.Tasks {
+		if err := create(&r.archive.Tasks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *restorer) task(task clickup.SingleTask) error {
+	listID, ok := r.result.IDs.Lists[task.List.ID]
+	if !ok {
+		return r.warn(nil, "task %s: list %s is not in the archive", task.ID, task.List.ID)
+	}
+
+	request := clickup.TaskRequest{
+		Name:        task.Name,
+		Description: task.Description,
+		Assignees:   r.users(task.Assignees),
+		Status:      task.Status.Status,
+	}
+	for _, tag := range task.Tags {
+		request.Tags = append(request.Tags, tag.Name)
+	}
+	if priority, err := strconv.Atoi(task.Priority.ID); err == nil {
+		request.Priority = &priority
+	}
+	if !task.DueDate.IsZero() {
+		request.DueDate, request.DueDateTime = task.DueDate.Ptr(), hasTime(task.DueDate)
+	}
+	if !task.StartDate.IsZero() {
+		request.StartDate, request.StartDateTime = task.StartDate.Ptr(), hasTime(task.StartDate)
+	}
+	if task.Parent != "" {
+		if parent, ok := r.result.IDs.Tasks[task.Parent]; ok {
+			request.Parent = parent
+		} else if err := r.warn(nil, "task %s: parent %s was not restored, restoring it as a task", task.ID, task.Parent); err != nil {
+			return err
+		}
+	}
+	fields, err := r.customFields(task, listID)
+	if err != nil {
+		return err
+	}
+	request.CustomFields = fields
+
+	created, err := r.createTask(listID, request)
+	var clickupErr *clickup.ErrClickupResponse
+	if err != nil && request.Status != "" && errors.As(err, &clickupErr) && clickupErr.StatusCode < 500 {
+		// The new list may not have the status, which cannot be created with the API.
+		if err := r.warn(err, "task %s: restoring it without status %q", task.ID, request.Status); err != nil {
+			return err
+		}
+		request.Status = ""
+		created, err = r.createTask(listID, request)
+	}
+	if err != nil {
+		return fmt.Errorf("task %s: %w", task.ID, err)
+	}
+	r.result.IDs.Tasks[task.ID] = created.ID
+	return nil
+}
+
+func (r *restorer) createTask(listID string, request clickup.TaskRequest) (created *clickup.SingleTask, err error) {
+	err = r.call(func() error {
+		created, err = r.client.CreateTask(r.ctx, listID, request)
+		return err
+	})
+	return created, err
+}
+
+// readOnlyFieldTypes are custom field types whose values are computed by Clickup.
+var readOnlyFieldTypes = map[string]bool{
+	"formula":            true,
+	"automatic_progress": true,
+	"rollup":             true,
+}
+
+// customFields returns the custom field values of task for the fields of the same name on the new
+// list listID.
+func (r *restorer) customFields(task clickup.SingleTask, listID string) ([]clickup.TaskCustomField, error) {
+	var values []clickup.TaskCustomField
+	for _, field := range task.CustomFields {
+		if field.Value == nil || readOnlyFieldTypes[field.Type] {
+			continue
+		}
+		fields, err := r.listFields(listID)
+		if err != nil {
+			return nil, err
+		}
+		var target *clickup.ListCustomField
+		for i := range fields {
+			if fields[i].Name == field.Name && fields[i].Type == field.Type {
+				target = &fields[i]
+				break
+			}
+		}
+		if target == nil {
+			if err := r.warn(nil, "task %s: list %s has no %s field %q", task.ID, listID, field.Type, field.Name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var value interface{}
+		switch field.Type {
+		case "drop_down":
+			// The value is the option id or its orderindex, which is its position.
+			for i, option := range field.TypeConfig.Options {
+				if option.ID == field.Value || field.Value == float64(i) {
+					value = optionID(target, option.Name)
+					break
+				}
+			}
+		case "labels":
+			ids, _ := field.Value.([]interface{})
+			var newIDs []string
+			for _, id := range ids {
+				for _, option := range field.TypeConfig.Options {
+					if option.ID != id {
+						continue
+					}
+					if newID := optionID(target, option.Label); newID != nil {
+						newIDs = append(newIDs, newID.(string))
+					}
+				}
+			}
+			if newIDs != nil {
+				value = newIDs
+			}
+		case "users":
+			users, _ := field.Value.([]interface{})
+			var add []int
+			for _, u := range users {
+				user, _ := u.(map[string]interface{})
+				id, _ := user["id"].(float64)
+				if newID, ok := r.user(int(id)); ok {
+					add = append(add, newID)
+				}
+			}
+			if add != nil {
+				value = map[string][]int{"add": add}
+			}
+		case "date":
+			s, _ := field.Value.(string)
+			if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+				value = ms
+			}
+		default:
+			value = field.Value
+		}
+		if value == nil {
+			if err := r.warn(nil, "task %s: the value of field %q could not be restored", task.ID, field.Name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		values = append(values, clickup.TaskCustomField{ID: target.ID, Value: value})
+	}
+	return values, nil
+}
+
+// optionID returns the id of the option of field with name or label, or nil if there is none.
+func optionID(field *clickup.ListCustomField, name string) interface{} {
+	for _, option := range field.TypeConfig.Options {
+		if name != "" && (option.Name == name || option.Label == name) {
+			return option.ID
+		}
+	}
+	return nil
+}
+
+// listFields returns the custom fields of the new list listID.
+func (r *restorer) listFields(listID string) ([]clickup.ListCustomField, error) {
+	if fields, ok := r.fields[listID]; ok {
+		return fields, nil
+	}
+	var res *clickup.CustomFieldsResponse
+	if err := r.call(func() (err error) {
+		res, err = r.client.CustomFieldsForList(r.ctx, listID)
+		return err
+	}); err != nil {
+		if err := r.warn(err, "list %s: custom fields", listID); err != nil {
+			return nil, err
+		}
+		res = &clickup.CustomFieldsResponse{}
+	}
+	r.fields[listID] = res.Fields
+	return res.Fields, nil
+}
+
+func (r *restorer) checklists() error {
+	for _, archived := range r.archive.Tasks {
+		taskID, ok := r.result.IDs.Tasks[archived.Task.ID]
+		if !ok {
+			continue
+		}
+		for _, checklist := range archived.Task.Checklists {
+			var created *clickup.ChecklistResponse
+			if err := r.call(func() (err error) {
+				created, err = r.client.CreateChecklist(r.ctx, &clickup.CreateChecklistRequest{TaskID: taskID, WorkspaceID: r.workspaceID, Name: checklist.Name})
+				return err
+			}); err != nil {
+				if err := r.warn(err, "task %s: checklist %q", archived.Task.ID, checklist.Name); err != nil {
+					return err
+				}
+				continue
+			}
+			checklistID := created.Checklist.ID
+			r.result.IDs.Checklists[checklist.ID] = checklistID
+
+			for _, item := range checklist.Items {
+				if err := r.call(func() (err error) {
+					created, err = r.client.CreateChecklistItem(r.ctx, &clickup.CreateChecklistItemRequest{ChecklistID: checklistID, Name: item.Name})
+					return err
+				}); err != nil {
+					if err := r.warn(err, "task %s: checklist item %q", archived.Task.ID, item.Name); err != nil {
+						return err
+					}
+					continue
+				}
+
+				assignee, assigned := r.user(item.Assignee.ID)
+				if !item.Resolved && !assigned || len(created.Checklist.Items) == 0 {
+					continue
+				}
+				// Items are added at the end of the checklist.
+				update := &clickup.UpdateChecklistItemRequest{
+					ChecklistID:     checklistID,
+					ChecklistItemID: created.Checklist.Items[len(created.Checklist.Items)-1].ID,
+				}
+				if item.Resolved {
+					update.Resolved = clickup.OptBool(true)
+				}
+				if assigned {
+					update.Assignee = clickup.OptInt(assignee)
+				}
+				if err := r.call(func() error {
+					_, err := r.client.UpdateChecklistItem(r.ctx, update)
+					return err
+				}); err != nil {
+					if err := r.warn(err, "task %s: checklist item %q", archived.Task.ID, item.Name); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// dependencies restores the dependencies and links between restored tasks.  Both tasks of a
+// dependency or link are archived with it, so each is only added once.
+func (r *restorer) dependencies() error {
+	links := make(map[[2]string]bool)
+	for _, archived := range r.archive.Tasks {
+		for _, dependency := range archived.Task.Dependencies {
+			if dependency.Type != clickup.DependencyWaitingOn || dependency.TaskID != archived.Task.ID {
+				continue
+			}
+			taskID, ok1 := r.result.IDs.Tasks[dependency.TaskID]
+			dependsOn, ok2 := r.result.IDs.Tasks[dependency.DependsOn]
+			if !ok1 || !ok2 {
+				if err := r.warn(nil, "task %s: dependency on %s was not restored", dependency.TaskID, dependency.DependsOn); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.call(func() error {
+				return r.client.AddDependencyForTask(r.ctx, clickup.AddDependencyRequest{TaskID: taskID, DependsOn: dependsOn, WorkspaceID: r.workspaceID})
+			}); err != nil {
+				if err := r.warn(err, "task %s: dependency on %s", dependency.TaskID, dependency.DependsOn); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, link := range archived.Task.LinkedTasks {
+			pair := [2]string{link.TaskID, link.LinkID}
+			if pair[0] > pair[1] {
+				pair[0], pair[1] = pair[1], pair[0]
+			}
+			if links[pair] {
+				continue
+			}
+			links[pair] = true
+
+			taskID, ok1 := r.result.IDs.Tasks[link.TaskID]
+			linkID, ok2 := r.result.IDs.Tasks[link.LinkID]
+			if !ok1 || !ok2 {
+				if err := r.warn(nil, "task %s: link to %s was not restored", link.TaskID, link.LinkID); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.call(func() error {
+				_, err := r.client.AddTaskLinkForTask(r.ctx, clickup.AddTaskLinkRequest{TaskID: taskID, LinksToTaskID: linkID, WorkspaceID: r.workspaceID})
+				return err
+			}); err != nil {
+				if err := r.warn(err, "task %s: link to %s", link.TaskID, link.LinkID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// comments restores list and task comments, oldest first.  They are posted by the restoring user.
+func (r *restorer) comments() error {
+	for _, list := range r.archive.Lists {
+		listID, ok := r.result.IDs.Lists[list.List.ID]
+		if !ok {
+			continue
+		}
+		for _, comment := range oldestFirst(list.Comments) {
+			request := clickup.CreateListCommentRequest{CreateCommentRequest: r.comment(comment), ListID: listID}
+			if err := r.call(func() error {
+				_, err := r.client.CreateListComment(r.ctx, request)
+				return err
+			}); err != nil {
+				if err := r.warn(err, "list %s: comment %s", list.List.ID, comment.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, task := range r.archive.Tasks {
+		taskID, ok := r.result.IDs.Tasks[task.Task.ID]
+		if !ok {
+			continue
+		}
+		for _, comment := range oldestFirst(task.Comments) {
+			request := clickup.CreateTaskCommentRequest{CreateCommentRequest: r.comment(comment), TaskID: taskID}
+			if err := r.call(func() error {
+				_, err := r.client.CreateTaskComment(r.ctx, request)
+				return err
+			}); err != nil {
+				if err := r.warn(err, "task %s: comment %s", task.Task.ID, comment.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// comment returns the request for an archived comment.  Mentions of users that are not mapped are
+// kept as plain text.
+func (r *restorer) comment(comment Comment) clickup.CreateCommentRequest {
+	if len(comment.Comment) == 0 {
+		return clickup.CreateCommentRequest{CommentText: comment.CommentText}
+	}
+	blocks := make([]clickup.ComplexComment, 0, len(comment.Comment))
+	for _, block := range comment.Comment {
+		if block.User != nil {
+			if id, ok := r.user(block.User.ID); ok {
+				mention := *block.User
+				mention.ID = id
+				block.User = &mention
+			} else {
+				if block.Text == "" && block.User.Username != "" {
+					block.Text = "@" + block.User.Username
+				}
+				block.User = nil
+				block.Type = ""
+			}
+		}
+		blocks = append(blocks, block)
+	}
+	return clickup.CreateCommentRequest{Comment: blocks}
+}
+
+func oldestFirst(comments []Comment) []Comment {
+	sorted := append([]Comment(nil), comments...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date.Time)
+	})
+	return sorted
+}
+
+func (r *restorer) attachments() error {
+	for _, task := range r.archive.Tasks {
+		taskID, ok := r.result.IDs.Tasks[task.Task.ID]
+		if !ok {
+			continue
+		}
+		for _, attachment := range task.Task.Attachments {
+			if attachment.Deleted {
+				continue
+			}
+			err := r.call(func() error {
+				f, err := r.archive.Attachment(attachment.ID)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				_, err = r.client.CreateTaskAttachment(r.ctx, taskID, "", false, &clickup.AttachmentParams{
+					FileName: attachment.Title,
+					Reader:   f,
+				})
+				return err
+			})
+			if errors.Is(err, fs.ErrNotExist) {
+				err = errors.New("not in the archive")
+			}
+			if err != nil {
+				if err := r.warn(err, "task %s: attachment %s", task.Task.ID, attachment.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *restorer) goals() error {
+	for _, goal := range r.archive.Goals {
+		var created *clickup.CreateGoalResponse
+		if err := r.call(func() (err error) {
+			created, err = r.client.CreateGoal(r.ctx, clickup.CreateGoalRequest{
+				WorkspaceID:    r.workspaceID,
+				Name:           goal.Name,
+				DueDate:        goal.DueDate,
+				Description:    goal.Description,
+				MultipleOwners: goal.MultipleOwners,
+				Owners:         r.users(goal.Owners),
+				Color:          goal.Color,
+			})
+			return err
+		}); err != nil {
+			if err := r.warn(err, "goal %s", goal.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		r.result.IDs.Goals[goal.ID] = created.Goal.ID
+
+		for _, keyResult := range goal.KeyResults {
+			// Steps are numbers or numeric strings; those that are neither are restored as 0.
+			stepsStart, _ := keyResult.StepsStart.Float64()
+			stepsEnd, _ := keyResult.StepsEnd.Float64()
+			request := clickup.CreateKeyResultRequest{
+				GoalID:     created.Goal.ID,
+				Name:       keyResult.Name,
+				Owners:     r.users(keyResult.Owners),
+				Type:       clickup.KeyResultType(keyResult.Type),
+				StepsStart: stepsStart,
+				StepsEnd:   stepsEnd,
+				Unit:       keyResult.Unit,
+				TaskIds:    r.mapIDs(r.result.IDs.Tasks, keyResult.TaskIds, "key result %s: task", keyResult.ID),
+				ListIds:    r.mapIDs(r.result.IDs.Lists, keyResult.ListIds, "key result %s: list", keyResult.ID),
+			}
+			var createdKeyResult *clickup.CreateKeyResultResponse
+			if err := r.call(func() (err error) {
+				createdKeyResult, err = r.client.CreateKeyResultForGoal(r.ctx, request)
+				return err
+			}); err != nil {
+				if err := r.warn(err, "goal %s: key result %s", goal.ID, keyResult.ID); err != nil {
+					return err
+				}
+				continue
+			}
+			r.result.IDs.KeyResults[keyResult.ID] = createdKeyResult.KeyResult.ID
+		}
+	}
+	return r.ctx.Err()
+}
+
+// mapIDs returns the new ids for ids, recording a warning for those that were not restored.
+func (r *restorer) mapIDs(m map[string]string, ids []string, format string, args ...interface{}) []string {
+	var mapped []string
+	for _, id := range ids {
+		if newID, ok := m[id]; ok {
+			mapped = append(mapped, newID)
+			continue
+		}
+		r.warn(nil, format+" %s was not restored", append(args, id)...)
+	}
+	return mapped
+}
+
+func (r *restorer) views() error {
+	for _, view := range r.archive.Views {
+		request := clickup.CreateViewRequest{
+			Name:        view.Name,
+			Type:        clickup.ViewType(view.Type),
+			Grouping:    &view.Grouping,
+			Filters:     r.filters(view.Filters),
+			Columns:     &view.Columns,
+			TeamSidebar: &view.TeamSidebar,
+			Settings:    &view.Settings,
+		}
+
+		var ok bool
+		switch view.Parent.Type {
+		case clickup.TypeTeam.ParentType():
+			request.ParentType, request.ParentID, ok = clickup.TypeTeam, r.workspaceID, true
+		case clickup.TypeSpace.ParentType():
+			request.ParentType = clickup.TypeSpace
+			request.ParentID, ok = r.result.IDs.Spaces[view.Parent.ID]
+		case clickup.TypeFolder.ParentType():
+			request.ParentType = clickup.TypeFolder
+			request.ParentID, ok = r.result.IDs.Folders[view.Parent.ID]
+		case clickup.TypeList.ParentType():
+			request.ParentType = clickup.TypeList
+			request.ParentID, ok = r.result.IDs.Lists[view.Parent.ID]
+		}
+		if !ok {
+			if err := r.warn(nil, "view %s: parent %s was not restored", view.ID, view.Parent.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var created *clickup.GetViewResponse
+		if err := r.call(func() (err error) {
+			created, err = r.client.CreateView(r.ctx, request)
+			return err
+		}); err != nil {
+			if err := r.warn(err, "view %s", view.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		r.result.IDs.Views[view.ID] = created.View.ID
+	}
+	return nil
+}
+
+// filters returns a copy of filters with the ids of restored objects in filter values replaced by
+// their new ids.
+func (r *restorer) filters(filters clickup.ViewFilters) *clickup.ViewFilters {
+	fields := make([]clickup.ViewFilterField, len(filters.Fields))
+	for i, field := range filters.Fields {
+		values := make([]interface{}, len(field.Values))
+		for j, value := range field.Values {
+			values[j] = value
+			s, ok := value.(string)
+			if !ok {
+				continue
+			}
+			for _, m := range []map[string]string{r.result.IDs.Tasks, r.result.IDs.Lists, r.result.IDs.Folders, r.result.IDs.Spaces} {
+				if newID, ok := m[s]; ok {
+					values[j] = newID
+					break
+				}
+			}
+		}
+		field.Values = values
+		fields[i] = field
+	}
+	filters.Fields = fields
+	return &filters
+}
+
+// hasTime reports whether t has a time of day, rather than being a date at midnight UTC.
+func hasTime(t clickup.Timestamp) bool {
+	return !t.UTC().Truncate(24 * time.Hour).Equal(t.UTC())
+}
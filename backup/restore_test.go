@@ -0,0 +1,197 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// archiveOf returns the archive with the content of files, keyed by name.
+func archiveOf(t *testing.T, files map[string]string) *Archive {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return archive
+}
+
+var restoreArchive = map[string]string{
+	"manifest.json": `{"version":1,"workspace_id":"w1"}`,
+	"spaces.json":   `[{"space":{"id":"s1","name":"Engineering","multiple_assignees":true},"tags":[{"name":"bug","tag_fg":"#fff","tag_bg":"#f00","creator":9}]}]`,
+	"folders.json":  `[{"id":"f1","name":"Roadmap","space":{"id":"s1"}}]`,
+	"lists.json": `[
+		{"space_id":"s1","folder_id":"f1","list":{"id":"l1","name":"Q3","content":"Plans","due_date":"1656633600000"},"comments":[{"id":"c-l","comment_text":"kickoff"}]},
+		{"space_id":"s1","folder_id":"","list":{"id":"l2","name":"Inbox"}}
+	]`,
+	// The subtask is archived before its parent.
+	"tasks/l1.json": `[
+		{"task":{"id":"t2","name":"Child","parent":"t1","list":{"id":"l1"},"status":{"status":"review"},
+			"dependencies":[{"task_id":"t2","depends_on":"t3","type":0}],"linked_tasks":[{"task_id":"t2","link_id":"t1"}]}},
+		{"task":{"id":"t1","name":"Parent","list":{"id":"l1"},"priority":{"id":"2"},"assignees":[{"id":7},{"id":8}],"tags":[{"name":"bug"}],
+			"due_date":"1656633600000","start_date":"1656667800000",
+			"custom_fields":[
+				{"id":"old-sprint","name":"Sprint","type":"drop_down","value":1,"type_config":{"options":[{"id":"o1","name":"Sprint 1"},{"id":"o2","name":"Sprint 2"}]}},
+				{"id":"old-area","name":"Area","type":"labels","value":["a1"],"type_config":{"options":[{"id":"a1","label":"Backend"}]}},
+				{"id":"old-gone","name":"Gone","type":"text","value":"x"},
+				{"id":"old-total","name":"Total","type":"formula","value":3},
+				{"id":"old-empty","name":"Empty","type":"text"}
+			],
+			"checklists":[{"id":"cl1","name":"Steps","items":[{"id":"i1","name":"one","resolved":true},{"id":"i2","name":"two"}]}],
+			"linked_tasks":[{"task_id":"t1","link_id":"t2"}],
+			"attachments":[{"id":"a1","title":"notes.txt"},{"id":"a9","title":"missing.txt"}]},
+		"comments":[
+			{"id":"c2","comment_text":"second","date":"2000"},
+			{"id":"c1","comment":[{"text":"hi "},{"text":"","type":"tag","user":{"id":8,"username":"bo"}}],"date":"1000"}
+		]}
+	]`,
+	"tasks/l2.json": `[{"task":{"id":"t3","name":"Blocker","list":{"id":"l2"},"dependencies":[{"task_id":"t2","depends_on":"t3","type":1}]}}]`,
+	"goals.json": `[{"id":"g1","name":"Ship","color":"#32a852","owners":[{"id":7}],
+		"key_results":[{"id":"k1","name":"Done","type":"automatic","task_ids":["t1","gone"],"steps_start":0,"steps_end":"12.5"}]},
+		{"id":"g2","name":"Hire","folder_id":"gf1","owners":[{"id":7}],"key_results":[]}]`,
+	"views.json": `[
+		{"id":"v1","name":"Blocked","type":"list","parent":{"id":"l2","type":6},"filters":{"op":"AND","fields":[{"field":"dependency","op":"EQ","idx":0,"values":["t3",5]}]}},
+		{"id":"v2","name":"Orphan","type":"list","parent":{"id":"zz","type":5}}
+	]`,
+	"attachments/a1": "notes",
+}
+
+func TestRestore(t *testing.T) {
+	doer := &fakeClickup{
+		routes: map[string]string{
+			"GET /list/n4/field": `{"fields":[
+				{"id":"new-sprint","name":"Sprint","type":"drop_down","type_config":{"options":[{"id":"new-o1","name":"Sprint 1"},{"id":"new-o2","name":"Sprint 2"}]}},
+				{"id":"new-area","name":"Area","type":"labels","type_config":{"options":[{"id":"new-a1","label":"Backend"}]}}
+			]}`,
+			"GET /list/n5/field": `{"fields":[]}`,
+		},
+		reject: `"status":"review"`,
+	}
+	var log strings.Builder
+	result, err := Restore(context.Background(), newTestClient(doer), archiveOf(t, restoreArchive), "w2", &RestoreOptions{
+		Users: map[int]int{7: 70},
+		Log:   &log,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantPosts := []string{
+		`POST /team/w2/space {"WorkspaceID":"w2","name":"Engineering","multiple_assignees":true,"features":{"due_dates":{"enabled":false,"start_date":false,"remap_due_dates":false,"remap_closed_due_date":false},` +
+			`"time_tracking":{"enabled":false},"tags":{},"time_estimates":{},"custom_fields":{},"remap_dependencies":{},"dependency_warning":{}}}`,
+		`POST /space/n1/tag {"name":"bug","tag_fg":"#fff","tag_bg":"#f00","creator":0}`,
+		`POST /space/n1/folder {"name":"Roadmap"}`,
+		`POST /folder/n3/list {"name":"Q3","content":"Plans","due_date":1656633600000}`,
+		`POST /space/n1/list {"name":"Inbox"}`,
+		`POST /list/n4/task {"name":"Parent","assignees":[70],"tags":["bug"],"priority":2,"due_date":1656633600000,"start_date":1656667800000,"start_date_time":true,` +
+			`"custom_fields":[{"id":"new-sprint","value":"new-o2"},{"id":"new-area","value":["new-a1"]}]}`,
+		`POST /list/n4/task {"name":"Child","status":"review","parent":"n6"}`,
+		`POST /list/n4/task {"name":"Child","parent":"n6"}`,
+		`POST /list/n5/task {"name":"Blocker"}`,
+		`POST /task/n6/checklist/ {"name":"Steps"}`,
+		`POST /checklist/n10/checklist_item {"name":"one"}`,
+		`PUT /checklist/n10/checklist_item/n11 {"resolved":true}`,
+		`POST /checklist/n10/checklist_item {"name":"two"}`,
+		`POST /task/n8/dependency/ {"depends_on":"n9"}`,
+		`POST /task/n8/link/n6/ `,
+		`POST /list/n4/comment {"comment_text":"kickoff"}`,
+		`POST /task/n6/comment/ {"comment":[{"text":"hi "},{"text":"@bo"}]}`,
+		`POST /task/n6/comment/ {"comment_text":"second"}`,
+		`POST /task/n6/attachment/ <file>`,
+		`POST /team/w2/goal {"name":"Ship","due_date":null,"description":"","multiple_owners":false,"owners":[70],"color":"#32a852"}`,
+		`POST /goal/n20/key_result {"name":"Done","owners":[],"type":"automatic","steps_start":0,"steps_end":12.5,"unit":"","task_ids":["n6"],"list_ids":null}`,
+		`POST /team/w2/goal {"name":"Hire","due_date":null,"description":"","multiple_owners":false,"owners":[70],"color":""}`,
+		`POST /list/n5/view ` + `{"name":"Blocked","type":"list","grouping":{"field":"","dir":0,"collapsed":null,"ignore":false},` +
+			`"filters":{"op":"AND","fields":[{"field":"dependency","op":"EQ","idx":0,"values":["n9",5]}],"search":"","search_custom_fields":false,"search_description":false,"search_name":false,"show_closed":false},`,
+	}
+	if len(doer.posts) != len(wantPosts) {
+		t.Fatalf("got %d requests, want %d:\n%s", len(doer.posts), len(wantPosts), strings.Join(doer.posts, "\n"))
+	}
+	for i, want := range wantPosts {
+		// The last request is only compared up to its columns and settings.
+		if got := doer.posts[i]; got != want && !(i == len(wantPosts)-1 && strings.HasPrefix(got, want)) {
+			t.Errorf("request %d =\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+
+	wantIDs := IDMap{
+		Spaces:     map[string]string{"s1": "n1"},
+		Folders:    map[string]string{"f1": "n3"},
+		Lists:      map[string]string{"l1": "n4", "l2": "n5"},
+		Tasks:      map[string]string{"t1": "n6", "t2": "n8", "t3": "n9"},
+		Checklists: map[string]string{"cl1": "n10"},
+		Goals:      map[string]string{"g1": "n20", "g2": "n22"},
+		KeyResults: map[string]string{"k1": "n21"},
+		Views:      map[string]string{"v1": "n23"},
+	}
+	if !reflect.DeepEqual(result.IDs, wantIDs) {
+		t.Errorf("IDs = %+v, want %+v", result.IDs, wantIDs)
+	}
+
+	wantWarnings := []string{
+		`task t1: list n4 has no text field "Gone"`,
+		`task t2: restoring it without status "review"`,
+		"task t1: attachment a9: not in the archive",
+		"key result k1: task gone was not restored",
+		"view v2: parent zz was not restored",
+	}
+	if len(result.Warnings) != len(wantWarnings) {
+		t.Fatalf("warnings =\n%s\nwant\n%s", strings.Join(result.Warnings, "\n"), strings.Join(wantWarnings, "\n"))
+	}
+	for i, want := range wantWarnings {
+		if !strings.Contains(result.Warnings[i], want) {
+			t.Errorf("warning %d = %q, want it to contain %q", i, result.Warnings[i], want)
+		}
+	}
+	if got := strings.Count(log.String(), "\n"); got != len(wantWarnings) {
+		t.Errorf("logged %d warnings, want %d", got, len(wantWarnings))
+	}
+}
+
+func TestRestore_StopsAtHierarchyErrors(t *testing.T) {
+	doer := &fakeClickup{reject: `"name":"Roadmap"`}
+	result, err := Restore(context.Background(), newTestClient(doer), archiveOf(t, restoreArchive), "w2", &RestoreOptions{SkipGoals: true})
+	if err == nil || !strings.Contains(err.Error(), "folder f1") {
+		t.Fatalf("Restore() error = %v, want a folder f1 error", err)
+	}
+	if result.IDs.Spaces["s1"] != "n1" || len(result.IDs.Lists) != 0 {
+		t.Errorf("IDs = %+v, want only the space", result.IDs)
+	}
+}
+
+func TestRestore_KeepsUserIDs(t *testing.T) {
+	files := map[string]string{
+		"manifest.json": `{"version":1}`,
+		"spaces.json":   `[{"space":{"id":"s1","name":"Engineering"}}]`,
+		"lists.json":    `[{"space_id":"s1","list":{"id":"l1","name":"Inbox"}}]`,
+		"tasks/l1.json": `[{"task":{"id":"t1","name":"Mine","list":{"id":"l1"},"assignees":[{"id":7}]}}]`,
+	}
+	doer := &fakeClickup{}
+	if _, err := Restore(context.Background(), newTestClient(doer), archiveOf(t, files), "w1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := `POST /list/n2/task {"name":"Mine","assignees":[7]}`; doer.posts[len(doer.posts)-1] != want {
+		t.Errorf("last request = %s, want %s", doer.posts[len(doer.posts)-1], want)
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package changefeed delivers task events by polling, for deployments that cannot expose an endpoint
+// to receive webhooks.
+//
+// A Poller reads the tasks of lists, spaces and views on every poll and compares them with the tasks
+// of the previous poll.  The differences are emitted as clickup.WebhookEventMessage values with the
+// same WebhookEvent constants and history item fields as webhooks, so a consumer of webhook events
+// can be fed by a Poller instead:
+//
+//	taskCreated          a task appeared
+//	taskStatusUpdated    its status changed, with the status before and after
+//	taskAssigneeUpdated  assignees were added or removed, as assignee_add items with the assignee after
+//	                     and assignee_rem items with the assignee before
+//	taskDueDateUpdated   its due date changed, with the dates before and after as strings of unix
+//	                     milliseconds, or null when not set
+//	taskCommentPosted    a comment was posted, as a comment item with its id, date and author
+//
+// The user of a history item is the user who made the change.  Only the creator of a task and the
+// author of a comment are known, so the user of other items is left empty.
+//
+// The first poll only records the tasks.  Comments are only requested for tasks whose date_updated
+// changed, as posting a comment updates the task, and only the newest page of comments is read.
+// Events for changes that happen between two polls are emitted on the second, so short lived changes,
+// such as a status set and reverted, are missed.
+package changefeed
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// DefaultInterval is how often Run polls.
+const DefaultInterval = time.Minute
+
+// Poller polls tasks for changes.  A Poller keeps the tasks of its last poll and must not be polled
+// concurrently.
+type Poller struct {
+	Client *clickup.Client
+	// Workspace is required to watch spaces.
+	Workspace string
+	ListIDs   []string
+	SpaceIDs  []string
+	// ViewIDs are watched with the filters of the view.  IncludeClosed and IncludeSubtasks only apply
+	// to lists and spaces.
+	ViewIDs         []string
+	IncludeClosed   bool
+	IncludeSubtasks bool
+	// Events are the events to emit, as for a webhook.  It defaults to every event.
+	Events []clickup.WebhookEvent
+	// Interval defaults to DefaultInterval.
+	Interval time.Duration
+
+	polled   time.Time
+	tasks    map[string]clickup.SingleTask
+	comments map[string]map[string]bool // ids of the comments seen on each task
+	now      func() time.Time
+}
+
+// Run polls every Interval until ctx is done and passes each event to fn.  It returns the first error
+// of a poll or of fn.  The tasks of the last poll are kept, so calling Run again carries on from there.
+func (p *Poller) Run(ctx context.Context, fn func(ctx context.Context, event clickup.WebhookEventMessage) error) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		events, err := p.Poll(ctx)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := fn(ctx, event); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll reads the watched tasks and returns the events since the previous poll.  If it fails, the
+// tasks of the previous poll are kept and the next poll returns the events again.
+func (p *Poller) Poll(ctx context.Context) ([]clickup.WebhookEventMessage, error) {
+	if p.Client == nil {
+		return nil, fmt.Errorf("a poller needs a client: %w", clickup.ErrValidation)
+	}
+	if len(p.SpaceIDs) > 0 && p.Workspace == "" {
+		return nil, fmt.Errorf("must provide a workspace id to watch spaces: %w", clickup.ErrValidation)
+	}
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	started := now()
+
+	tasks, err := p.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]clickup.SingleTask, len(tasks))
+	comments := map[string]map[string]bool{}
+	events := []clickup.WebhookEventMessage{}
+	for _, task := range tasks {
+		current[task.ID] = task
+		if seen, ok := p.comments[task.ID]; ok {
+			comments[task.ID] = seen
+		}
+		if p.tasks == nil {
+			continue
+		}
+
+		before, existed := p.tasks[task.ID]
+		if !existed {
+			events = append(events, p.created(task)...)
+		} else {
+			events = append(events, p.changed(before, task)...)
+		}
+		if (!existed || task.DateUpdated.After(before.DateUpdated.Time)) && p.emits(clickup.EventTaskCommentPosted) {
+			posted, seen, err := p.newComments(ctx, task.ID, comments[task.ID])
+			if err != nil {
+				return nil, err
+			}
+			comments[task.ID] = seen
+			events = append(events, posted...)
+		}
+	}
+
+	p.tasks, p.comments, p.polled = current, comments, started
+	return events, nil
+}
+
+// read returns the watched tasks, each once, in the order they were read.
+func (p *Poller) read(ctx context.Context) ([]clickup.SingleTask, error) {
+	query := clickup.TaskQueryOptions{IncludeClosed: p.IncludeClosed, IncludeSubtasks: p.IncludeSubtasks}
+	var all []clickup.SingleTask
+	for _, listID := range p.ListIDs {
+		tasks, err := p.Client.AllTasksForList(ctx, listID, &query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read list %s: %w", listID, err)
+		}
+		all = append(all, tasks...)
+	}
+	if len(p.SpaceIDs) > 0 {
+		tasks, err := p.Client.AllTasksForWorkspace(ctx, p.Workspace, &clickup.WorkspaceTaskQueryOptions{
+			TaskQueryOptions: query,
+			SpaceIDs:         p.SpaceIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spaces: %w", err)
+		}
+		all = append(all, tasks...)
+	}
+	for _, viewID := range p.ViewIDs {
+		tasks, err := p.Client.AllTasksForView(ctx, viewID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read view %s: %w", viewID, err)
+		}
+		all = append(all, tasks...)
+	}
+
+	seen := map[string]bool{}
+	tasks := all[:0]
+	for _, task := range all {
+		if !seen[task.ID] {
+			seen[task.ID] = true
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (p *Poller) created(task clickup.SingleTask) []clickup.WebhookEventMessage {
+	if !p.emits(clickup.EventTaskCreated) {
+		return nil
+	}
+	item := clickup.WebhookHistoryItem{Field: "task_creation", Date: task.DateCreated, User: historyUser(task.Creator)}
+	return []clickup.WebhookEventMessage{message(clickup.EventTaskCreated, task.ID, item)}
+}
+
+// changed returns the events for the differences between two snapshots of a task.
+func (p *Poller) changed(before, after clickup.SingleTask) []clickup.WebhookEventMessage {
+	var events []clickup.WebhookEventMessage
+	date := after.DateUpdated
+
+	if before.Status.Status != after.Status.Status && p.emits(clickup.EventTaskStatusUpdated) {
+		item := clickup.WebhookHistoryItem{
+			Field:  "status",
+			Date:   date,
+			Before: historyValue(historyStatus(before.Status)),
+			After:  historyValue(historyStatus(after.Status)),
+		}
+		item.Data.StatusType = after.Status.Type
+		events = append(events, message(clickup.EventTaskStatusUpdated, after.ID, item))
+	}
+
+	if p.emits(clickup.EventTaskAssigneeUpdated) {
+		var items []clickup.WebhookHistoryItem
+		for _, user := range missingUsers(after.Assignees, before.Assignees) {
+			items = append(items, clickup.WebhookHistoryItem{Field: "assignee_add", Date: date, After: historyValue(historyUser(user))})
+		}
+		for _, user := range missingUsers(before.Assignees, after.Assignees) {
+			items = append(items, clickup.WebhookHistoryItem{Field: "assignee_rem", Date: date, Before: historyValue(historyUser(user))})
+		}
+		if len(items) > 0 {
+			events = append(events, message(clickup.EventTaskAssigneeUpdated, after.ID, items...))
+		}
+	}
+
+	if !before.DueDate.Equal(after.DueDate.Time) && p.emits(clickup.EventTaskDueDateUpdated) {
+		item := clickup.WebhookHistoryItem{
+			Field:  "due_date",
+			Date:   date,
+			Before: historyDate(before.DueDate),
+			After:  historyDate(after.DueDate),
+		}
+		events = append(events, message(clickup.EventTaskDueDateUpdated, after.ID, item))
+	}
+	return events
+}
+
+// newComments requests the comments of a task and returns the events for those posted since the
+// previous poll that are not in seen, and the ids of the comments seen so far.
+func (p *Poller) newComments(ctx context.Context, taskID string, seen map[string]bool) ([]clickup.WebhookEventMessage, map[string]bool, error) {
+	var res clickup.CommentsResponse
+	err := clickup.RetryOnRateLimit(ctx, func() error {
+		var err error
+		res, err = p.Client.TaskComments(ctx, clickup.CommentsForTaskQuery{CommentsQuery: clickup.CommentsQuery{TaskID: taskID}})
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read comments of task %s: %w", taskID, err)
+	}
+
+	updated := map[string]bool{}
+	for id := range seen {
+		updated[id] = true
+	}
+	comments := res.Comments
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].Date.Before(comments[j].Date.Time) })
+	var events []clickup.WebhookEventMessage
+	for _, comment := range comments {
+		if updated[comment.ID] {
+			continue
+		}
+		updated[comment.ID] = true
+		if !comment.Date.After(p.polled) {
+			continue
+		}
+		item := clickup.WebhookHistoryItem{ID: comment.ID, Field: "comment", Date: comment.Date}
+		if comment.User != nil {
+			item.User = historyUser(*comment.User)
+		}
+		events = append(events, message(clickup.EventTaskCommentPosted, taskID, item))
+	}
+	return events, updated, nil
+}
+
+// emits reports whether the poller is configured to emit event.
+func (p *Poller) emits(event clickup.WebhookEvent) bool {
+	if len(p.Events) == 0 {
+		return true
+	}
+	for _, e := range p.Events {
+		if e == event || e == clickup.EventAll {
+			return true
+		}
+	}
+	return false
+}
+
+func message(event clickup.WebhookEvent, taskID string, items ...clickup.WebhookHistoryItem) clickup.WebhookEventMessage {
+	return clickup.WebhookEventMessage{Event: event, TaskID: taskID, HistoryItems: items}
+}
+
+// missingUsers returns the users of a that are not in b.
+func missingUsers(a, b []clickup.TeamUser) []clickup.TeamUser {
+	var missing []clickup.TeamUser
+	for _, user := range a {
+		found := false
+		for _, other := range b {
+			if user.ID == other.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, user)
+		}
+	}
+	return missing
+}
+
+func historyUser(user clickup.TeamUser) clickup.WebhookHistoryUser {
+	return clickup.WebhookHistoryUser{
+		ID:             user.ID,
+		Username:       user.Username,
+		Email:          user.Email,
+		Color:          user.Color,
+		Initials:       user.Initials,
+		ProfilePicture: user.ProfilePicture,
+	}
+}
+
+// historyValue encodes v, which is always a value that can be encoded.
+func historyValue(v interface{}) clickup.WebhookHistoryValue {
+	value, _ := clickup.NewWebhookHistoryValue(v)
+	return value
+}
+
+// historyDate encodes t as webhooks send dates, as a string of unix milliseconds or null.
+func historyDate(t clickup.Timestamp) clickup.WebhookHistoryValue {
+	if t.IsZero() {
+		return clickup.WebhookHistoryValue{}
+	}
+	return historyValue(strconv.FormatInt(t.Millis(), 10))
+}
+
+func historyStatus(status clickup.Status) clickup.WebhookHistoryStatus {
+	return clickup.WebhookHistoryStatus{Status: status.Status, Color: status.Color, Type: status.Type}
+}
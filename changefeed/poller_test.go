@@ -0,0 +1,266 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package changefeed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// fakeDoer answers a request with the body of the longest path prefix that matches it, and records
+// the request paths.
+type fakeDoer struct {
+	bodies map[string]string
+	fail   bool
+	paths  []string
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	d.paths = append(d.paths, req.URL.Path)
+	if d.fail {
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: ioutil.NopCloser(strings.NewReader(`{"err": "down"}`)), Request: req}, nil
+	}
+	body, match := `{"tasks": [], "comments": []}`, ""
+	for prefix, b := range d.bodies {
+		if strings.HasPrefix(req.URL.Path, prefix) && len(prefix) > len(match) {
+			body, match = b, prefix
+		}
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body)), Request: req}, nil
+}
+
+func newTestClient(doer *fakeDoer) *clickup.Client {
+	return clickup.NewClient(&clickup.ClientOpts{Doer: doer, Authenticator: &clickup.APITokenAuthenticator{}})
+}
+
+var base = time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+
+func ms(minutes int) int64 {
+	return clickup.NewTimestamp(base.Add(time.Duration(minutes) * time.Minute)).Millis()
+}
+
+func task(id, status string, updated, due int, assignees ...int) string {
+	var users []string
+	for _, id := range assignees {
+		users = append(users, fmt.Sprintf(`{"id": %d, "username": "user%d"}`, id, id))
+	}
+	return fmt.Sprintf(`{"id": %q, "status": {"status": %q, "type": "custom"}, "date_created": "%d", "date_updated": "%d",
+		"due_date": "%d", "assignees": [%s], "creator": {"id": 9}}`, id, status, ms(0), ms(updated), ms(due), strings.Join(users, ","))
+}
+
+func tasks(tasks ...string) string {
+	return `{"tasks": [` + strings.Join(tasks, ",") + `], "last_page": true}`
+}
+
+func comment(id string, minutes int) string {
+	return fmt.Sprintf(`{"id": %q, "comment_text": "hi", "date": "%d", "user": {"id": 3, "username": "ana"}}`, id, ms(minutes))
+}
+
+// summarize describes events as "event task field[:detail]...".
+func summarize(events []clickup.WebhookEventMessage) []string {
+	var got []string
+	for _, e := range events {
+		s := fmt.Sprintf("%s %s", e.Event, e.TaskID)
+		for _, item := range e.HistoryItems {
+			s += " " + item.Field
+			switch item.Field {
+			case "status":
+				s += ":" + item.Before.Status + ">" + item.After.Status
+			case "assignee_add":
+				assignee, _ := item.After.User()
+				s += fmt.Sprintf(":%d%s", assignee.ID, encode(item.Before))
+			case "assignee_rem":
+				assignee, _ := item.Before.User()
+				s += fmt.Sprintf(":%d%s", assignee.ID, encode(item.After))
+			case "due_date":
+				s += fmt.Sprintf(":%s>%s", encode(item.Before), encode(item.After))
+			case "task_creation", "comment":
+				s += fmt.Sprintf(":%s:%d", item.ID, item.User.ID)
+			}
+		}
+		got = append(got, s)
+	}
+	return got
+}
+
+func encode(v clickup.WebhookHistoryValue) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func TestPoller_Poll(t *testing.T) {
+	doer := &fakeDoer{bodies: map[string]string{
+		"/api/v2/list/l1/": tasks(task("t1", "open", 10, 60, 1)),
+		"/api/v2/view/v1/": tasks(task("t1", "open", 10, 60, 1)),
+	}}
+	now := base.Add(20 * time.Minute)
+	p := &Poller{
+		Client:  newTestClient(doer),
+		ListIDs: []string{"l1"},
+		ViewIDs: []string{"v1"},
+		now:     func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	steps := []struct {
+		name      string
+		bodies    map[string]string
+		want      []string
+		wantPaths []string
+	}{
+		{
+			name:      "First poll records the tasks",
+			wantPaths: []string{"/api/v2/list/l1/task/", "/api/v2/view/v1/task/"},
+		},
+		{
+			name: "Changes and new tasks",
+			bodies: map[string]string{
+				"/api/v2/list/l1/":         tasks(task("t1", "in progress", 30, 90, 2), task("t2", "open", 25, 0)),
+				"/api/v2/task/t1/comment/": `{"comments": [` + comment("c2", 45) + "," + comment("c1", 5) + `]}`,
+			},
+			want: []string{
+				"taskStatusUpdated t1 status:open>in progress",
+				"taskAssigneeUpdated t1 assignee_add:2null assignee_rem:1null",
+				fmt.Sprintf(`taskDueDateUpdated t1 due_date:"%d">"%d"`, ms(60), ms(90)),
+				"taskCommentPosted t1 comment:c2:3",
+				"taskCreated t2 task_creation::9",
+			},
+			wantPaths: []string{"/api/v2/list/l1/task/", "/api/v2/view/v1/task/", "/api/v2/task/t1/comment/", "/api/v2/task/t2/comment/"},
+		},
+		{
+			name: "Comments already emitted are skipped",
+			bodies: map[string]string{
+				"/api/v2/list/l1/":         tasks(task("t1", "in progress", 50, 90, 2), task("t2", "open", 25, 0)),
+				"/api/v2/task/t1/comment/": `{"comments": [` + comment("c3", 55) + "," + comment("c2", 45) + `]}`,
+			},
+			want:      []string{"taskCommentPosted t1 comment:c3:3"},
+			wantPaths: []string{"/api/v2/list/l1/task/", "/api/v2/view/v1/task/", "/api/v2/task/t1/comment/"},
+		},
+		{
+			name:      "Nothing changed",
+			wantPaths: []string{"/api/v2/list/l1/task/", "/api/v2/view/v1/task/"},
+		},
+	}
+	for _, step := range steps {
+		for prefix, body := range step.bodies {
+			doer.bodies[prefix] = body
+		}
+		doer.paths = nil
+		events, err := p.Poll(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if got := summarize(events); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("%s: events = %q, want %q", step.name, got, step.want)
+		}
+		if !reflect.DeepEqual(doer.paths, step.wantPaths) {
+			t.Errorf("%s: requests = %v, want %v", step.name, doer.paths, step.wantPaths)
+		}
+		now = now.Add(20 * time.Minute)
+	}
+}
+
+func TestPoller_Events(t *testing.T) {
+	doer := &fakeDoer{bodies: map[string]string{"/api/v2/team/w1/task": tasks(task("t1", "open", 10, 60, 1))}}
+	p := &Poller{
+		Client:    newTestClient(doer),
+		Workspace: "w1",
+		SpaceIDs:  []string{"s1"},
+		Events:    []clickup.WebhookEvent{clickup.EventTaskStatusUpdated},
+	}
+	ctx := context.Background()
+	if _, err := p.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	doer.bodies["/api/v2/team/w1/task"] = tasks(task("t1", "done", 30, 90), task("t2", "open", 30, 0))
+	doer.paths = nil
+	events, err := p.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := summarize(events), []string{"taskStatusUpdated t1 status:open>done"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+	if want := []string{"/api/v2/team/w1/task"}; !reflect.DeepEqual(doer.paths, want) {
+		t.Errorf("requests = %v, want %v without comments", doer.paths, want)
+	}
+}
+
+func TestPoller_errors(t *testing.T) {
+	ctx := context.Background()
+	if _, err := (&Poller{}).Poll(ctx); !errors.Is(err, clickup.ErrValidation) {
+		t.Errorf("Poll() without a client error = %v, want ErrValidation", err)
+	}
+	doer := &fakeDoer{bodies: map[string]string{"/api/v2/list/l1/": tasks(task("t1", "open", 10, 60))}}
+	if _, err := (&Poller{Client: newTestClient(doer), SpaceIDs: []string{"s1"}}).Poll(ctx); !errors.Is(err, clickup.ErrValidation) {
+		t.Errorf("Poll() of a space without a workspace error = %v, want ErrValidation", err)
+	}
+
+	p := &Poller{Client: newTestClient(doer), ListIDs: []string{"l1"}}
+	if _, err := p.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	doer.bodies["/api/v2/list/l1/"] = tasks(task("t1", "done", 20, 60))
+	doer.fail = true
+	if _, err := p.Poll(ctx); err == nil {
+		t.Fatal("Poll() did not fail")
+	}
+
+	// The change is emitted by the next poll that succeeds.
+	doer.fail = false
+	events, err := p.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := summarize(events), []string{"taskStatusUpdated t1 status:open>done"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+func TestPoller_Run(t *testing.T) {
+	doer := &fakeDoer{bodies: map[string]string{"/api/v2/list/l1/": tasks(task("t1", "open", 10, 60))}}
+	p := &Poller{Client: newTestClient(doer), ListIDs: []string{"l1"}, Interval: time.Millisecond, Events: []clickup.WebhookEvent{clickup.EventAll}}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Without changes, Run polls until ctx is done.
+	if err := p.Run(ctx, func(ctx context.Context, event clickup.WebhookEventMessage) error {
+		t.Errorf("unexpected event %s", event.Event)
+		return nil
+	}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if len(doer.paths) < 2 {
+		t.Errorf("polled %d times, want several", len(doer.paths))
+	}
+
+	// An error of fn stops Run.
+	doer.bodies["/api/v2/list/l1/"] = tasks(task("t1", "done", 20, 60))
+	stop := errors.New("stop")
+	var got []clickup.WebhookEvent
+	err := p.Run(context.Background(), func(ctx context.Context, event clickup.WebhookEventMessage) error {
+		got = append(got, event.Event)
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Run() error = %v, want %v", err, stop)
+	}
+	if want := []clickup.WebhookEvent{clickup.EventTaskStatusUpdated}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
@@ -12,21 +12,21 @@ import (
 
 type ChecklistResponse struct {
 	Checklist struct {
-		ID          string `json:"id"`
-		TaskID      string `json:"task_id"`
-		Name        string `json:"name"`
-		DateCreated string `json:"date_created"`
-		Orderindex  int    `json:"-"`
-		Creator     int    `json:"creator"`
-		Resolved    int    `json:"resolved"`
-		Unresolved  int    `json:"unresolved"`
+		ID          string    `json:"id"`
+		TaskID      string    `json:"task_id"`
+		Name        string    `json:"name"`
+		DateCreated Timestamp `json:"date_created"`
+		Orderindex  int       `json:"-"`
+		Creator     int       `json:"creator"`
+		Resolved    int       `json:"resolved"`
+		Unresolved  int       `json:"unresolved"`
 		Items       []struct {
-			ID          string   `json:"id"`
-			Name        string   `json:"name"`
-			Orderindex  int      `json:"-"`
-			Assignee    TeamUser `json:"assignee"`
-			Resolved    bool     `json:"resolved"`
-			DateCreated string   `json:"date_created"`
+			ID          string    `json:"id"`
+			Name        string    `json:"name"`
+			Orderindex  int       `json:"-"`
+			Assignee    TeamUser  `json:"assignee"`
+			Resolved    bool      `json:"resolved"`
+			DateCreated Timestamp `json:"date_created"`
 		} `json:"items"`
 	} `json:"checklist"`
 }
@@ -38,10 +38,15 @@ type CreateChecklistRequest struct {
 	Name             string `json:"name"`
 }
 
+// UpdateChecklistRequest uses patch semantics.  Only explicitly set fields are sent to Clickup.
 type UpdateChecklistRequest struct {
-	ChecklistID string `json:"-"`
-	Name        string `json:"name,omitempty"`
-	Position    int    `json:"position,omitempty"`
+	ChecklistID string         `json:"-"`
+	Name        OptionalString `json:"name"`
+	Position    OptionalInt    `json:"position"`
+}
+
+func (u UpdateChecklistRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
 }
 
 type CreateChecklistItemRequest struct {
@@ -49,12 +54,19 @@ type CreateChecklistItemRequest struct {
 	Name        string `json:"name"`
 }
 
+// UpdateChecklistItemRequest uses patch semantics.  Only explicitly set fields are sent to Clickup,
+// so an item can be un-resolved with OptBool(false) or unassigned with NullInt().
 type UpdateChecklistItemRequest struct {
-	ChecklistID     string     `json:"checklist_id"`
-	ChecklistItemID string     `json:"checklist_item_id"`
-	Name            string     `json:"name"`
-	Assignee        TeamMember `json:"assignee"`
-	Resolved        bool       `json:"resolved"`
+	ChecklistID     string         `json:"-"`
+	ChecklistItemID string         `json:"-"`
+	Name            OptionalString `json:"name"`
+	Assignee        OptionalInt    `json:"assignee"` // user id
+	Resolved        OptionalBool   `json:"resolved"`
+	Parent          OptionalString `json:"parent"` // checklist item id to nest under
+}
+
+func (u UpdateChecklistItemRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
 }
 
 // CreateChecklist adds a new checklist to the specified task id in request.
@@ -133,7 +145,7 @@ func (c *Client) CreateChecklistItem(ctx context.Context, request *CreateCheckli
 	}
 	buf := bytes.NewBuffer(b)
 
-	endpoint := fmt.Sprintf("/checklist/%s", request.ChecklistID)
+	endpoint := fmt.Sprintf("/checklist/%s/checklist_item", request.ChecklistID)
 
 	var checklist ChecklistResponse
 
@@ -119,8 +119,8 @@ func TestClient_UpdateChecklist(t *testing.T) {
 			args: args{
 				request: &UpdateChecklistRequest{
 					ChecklistID: "test-checklist-id",
-					Position:    0,
-					Name:        "test name",
+					Position:    OptInt(0),
+					Name:        OptString("test name"),
 				},
 			},
 			wantErr: false,
@@ -229,6 +229,9 @@ func TestClient_CreateChecklistItem(t *testing.T) {
 			name: "TestSuccessful create checklist item",
 			fields: fields{
 				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPost || req.URL.Path != "/checklist/test-checklist-id/checklist_item" {
+						t.Errorf("request = %s %s, want POST /checklist/test-checklist-id/checklist_item", req.Method, req.URL.Path)
+					}
 					body := `{"checklist":{"id":"test-id","name": "test name", "task_id": "test-task-id"}}`
 					return &http.Response{
 						StatusCode: http.StatusOK,
@@ -306,8 +309,8 @@ func TestClient_UpdateChecklistItem(t *testing.T) {
 				request: &UpdateChecklistItemRequest{
 					ChecklistID:     "test-checklist-id",
 					ChecklistItemID: "test-checklist-item-id",
-					Resolved:        false,
-					Name:            "test name",
+					Resolved:        OptBool(false),
+					Name:            OptString("test name"),
 				},
 			},
 			wantErr: false,
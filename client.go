@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -127,3 +128,74 @@ func (c *Client) call(ctx context.Context, method, uri string, data *bytes.Buffe
 
 	return nil
 }
+
+const (
+	rateLimitRetries      = 3
+	defaultRateLimitPause = time.Minute
+	defaultConcurrency    = 4 // in flight requests for helpers that make many requests
+)
+
+// retryOnRateLimit calls fn and, if Clickup responds that the rate limit has been exceeded,
+// waits until the limit resets before trying again.  Any other error is returned immediately.
+func retryOnRateLimit(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+
+		var rateLimitErr *RateLimitError
+		if !errors.As(err, &rateLimitErr) || attempt == rateLimitRetries {
+			return err
+		}
+
+		pause := defaultRateLimitPause
+		if resetAt, ok := rateLimitErr.ResetAt(); ok {
+			pause = time.Until(resetAt)
+		}
+
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RetryOnRateLimit calls fn and, if it fails with a *RateLimitError, waits until the limit resets
+// and calls it again, up to 3 attempts.  It is useful for jobs that make many requests in a row.
+func RetryOnRateLimit(ctx context.Context, fn func() error) error {
+	return retryOnRateLimit(ctx, fn)
+}
+
+// runConcurrently calls fn for every index in [0, n) with at most limit calls in flight.
+// The first error cancels the context passed to the remaining calls and is returned.
+func runConcurrently(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, limit)
+	errs := make(chan error, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if ctx.Err() != nil {
+				return
+			}
+			if err := fn(ctx, i); err != nil {
+				errs <- err
+				cancel()
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
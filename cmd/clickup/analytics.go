@@ -0,0 +1,129 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+	"github.com/Guitarbum722/clickup-client-go/analytics"
+)
+
+func reportTimeInStatus(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("report")
+	listID := fs.String("list", "", "report on the tasks of a list instead of the given task ids")
+	closed := fs.Bool("closed", true, "include closed tasks of the list")
+	customID := fs.Bool("custom-id", false, "the task ids are custom task ids")
+	var stages stringList
+	fs.Var(&stages, "stage", "workflow stage as name=status,status or name=type:open (repeatable, in order)")
+	start := fs.String("start", "", "stage at which cycle time starts")
+	done := fs.String("done", "", "stage at which tasks are complete")
+	since := fs.String("since", "", "leave out tasks completed before this date")
+	tz := fs.String("tz", "UTC", "time zone in which weeks and days start")
+	table := fs.String("csv", "", "write a table as CSV: tasks, summary, throughput or flow")
+	taskIDs, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if (*listID == "") == (len(taskIDs) == 0) {
+		return fmt.Errorf("report on one -list or on task ids: %w", errUsage)
+	}
+	writeCSV, err := reportTable(*table)
+	if err != nil {
+		return err
+	}
+	workspace, err := a.taskWorkspace(*customID)
+	if err != nil {
+		return err
+	}
+
+	opts := &analytics.Options{
+		Fetch: &clickup.TimeInStatusOptions{WorkspaceID: workspace, UseCustomTaskIDs: *customID},
+	}
+	if len(stages) > 0 {
+		opts.Workflow = &analytics.Workflow{Start: *start, Done: *done}
+		for _, s := range stages {
+			stage, err := parseStage(s)
+			if err != nil {
+				return err
+			}
+			opts.Workflow.Stages = append(opts.Workflow.Stages, stage)
+		}
+	} else if *start != "" || *done != "" {
+		opts.Workflow = analytics.DefaultWorkflow()
+		opts.Workflow.Start, opts.Workflow.Done = *start, *done
+	}
+	if opts.Since, err = parseDate(*since); err != nil {
+		return fmt.Errorf("-since: %v: %w", err, errUsage)
+	}
+	if opts.Location, err = time.LoadLocation(*tz); err != nil {
+		return fmt.Errorf("-tz: %v: %w", err, errUsage)
+	}
+
+	if *listID != "" {
+		tasks, err := a.client.AllTasksForList(ctx, *listID, &clickup.TaskQueryOptions{IncludeSubtasks: true, IncludeClosed: *closed})
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			taskIDs = append(taskIDs, task.ID)
+		}
+		// Listed tasks are always looked up by their Clickup id.
+		opts.Fetch.UseCustomTaskIDs = false
+	}
+
+	report, err := analytics.ForTasks(ctx, a.client, taskIDs, opts)
+	if err != nil {
+		return err
+	}
+	for _, status := range report.Unmapped {
+		fmt.Fprintf(a.stderr, "status %q is in no stage\n", status)
+	}
+	if writeCSV != nil {
+		return writeCSV(report, a.stdout)
+	}
+	return a.print(report)
+}
+
+// reportTable returns the CSV writer of a report table, or nil for the empty name.
+func reportTable(name string) (func(*analytics.Report, io.Writer) error, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "tasks":
+		return (*analytics.Report).WriteTasksCSV, nil
+	case "summary":
+		return (*analytics.Report).WriteSummaryCSV, nil
+	case "throughput":
+		return (*analytics.Report).WriteThroughputCSV, nil
+	case "flow":
+		return (*analytics.Report).WriteCumulativeFlowCSV, nil
+	}
+	return nil, fmt.Errorf("unknown -csv table %q: %w", name, errUsage)
+}
+
+// parseStage parses a -stage flag such as "Doing=in progress,review" or "Done=type:closed".
+func parseStage(s string) (analytics.Stage, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return analytics.Stage{}, fmt.Errorf("-stage %q is not name=status,status: %w", s, errUsage)
+	}
+	stage := analytics.Stage{Name: parts[0]}
+	for _, status := range strings.Split(parts[1], ",") {
+		status = strings.TrimSpace(status)
+		if strings.HasPrefix(status, "type:") {
+			stage.Types = append(stage.Types, strings.TrimPrefix(status, "type:"))
+		} else if status != "" {
+			stage.Statuses = append(stage.Statuses, status)
+		}
+	}
+	return stage, nil
+}
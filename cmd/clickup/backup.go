@@ -0,0 +1,115 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Guitarbum722/clickup-client-go/backup"
+)
+
+func backupWorkspace(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("backup")
+	archived := fs.Bool("archived", false, "include archived spaces, folders, lists and tasks")
+	skipComments := fs.Bool("skip-comments", false, "do not archive comments")
+	skipAttachments := fs.Bool("skip-attachments", false, "do not archive attachments")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	workspace, err := a.requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(positional[0])
+	if err != nil {
+		return err
+	}
+	manifest, err := backup.Backup(ctx, a.client, workspace, f, &backup.Options{
+		IncludeArchived: *archived,
+		SkipComments:    *skipComments,
+		SkipAttachments: *skipAttachments,
+		Log:             a.stderr,
+	})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// A partial archive cannot be restored.
+		os.Remove(positional[0])
+		return err
+	}
+	return a.print(manifest)
+}
+
+func restoreWorkspace(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("restore")
+	var users stringList
+	fs.Var(&users, "user", "old=new user id mapping; when given, unmapped users are left out (repeatable)")
+	skipComments := fs.Bool("skip-comments", false, "do not restore comments")
+	skipAttachments := fs.Bool("skip-attachments", false, "do not restore attachments")
+	skipGoals := fs.Bool("skip-goals", false, "do not restore goals")
+	skipViews := fs.Bool("skip-views", false, "do not restore views")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	workspace, err := a.requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	opts := &backup.RestoreOptions{
+		SkipComments:    *skipComments,
+		SkipAttachments: *skipAttachments,
+		SkipGoals:       *skipGoals,
+		SkipViews:       *skipViews,
+		Log:             a.stderr,
+	}
+	for _, u := range users {
+		parts := strings.Split(u, "=")
+		if len(parts) != 2 {
+			return fmt.Errorf("-user %q is not old=new user ids: %w", u, errUsage)
+		}
+		from, err1 := strconv.Atoi(parts[0])
+		to, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("-user %q is not old=new user ids: %w", u, errUsage)
+		}
+		if opts.Users == nil {
+			opts.Users = make(map[int]int)
+		}
+		opts.Users[from] = to
+	}
+
+	f, err := os.Open(positional[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	archive, err := backup.Open(f, info.Size())
+	if err != nil {
+		return fmt.Errorf("%s: %w", positional[0], err)
+	}
+
+	result, err := backup.Restore(ctx, a.client, archive, workspace, opts)
+	if err != nil {
+		// The ids of what was created are printed so that a failed restore can be cleaned up.
+		a.print(result.IDs)
+		return err
+	}
+	return a.print(result.IDs)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+	"github.com/Guitarbum722/clickup-client-go/ical"
+)
+
+func calendarResource() resource {
+	return resource{
+		name:    "calendar",
+		summary: "iCalendar files and feeds of task start and due dates",
+		commands: []command{
+			{
+				name:  "export",
+				usage: "export (-list list-id | -assignee user-id) [-todo] [-closed] [-tz zone] > tasks.ics",
+				run:   exportCalendar,
+			},
+			{name: "serve", usage: "serve [-addr host:port] [-ttl duration] [-tz zone] (-list id | -assignee id)...", run: serveCalendar},
+		},
+	}
+}
+
+func exportCalendar(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("export")
+	listID := fs.String("list", "", "list id")
+	assignee := fs.Int("assignee", 0, "user id of the assignee, in the workspace")
+	todo := fs.Bool("todo", false, "write to-dos instead of events")
+	closed := fs.Bool("closed", false, "include closed tasks")
+	tz := fs.String("tz", "UTC", "time zone in which dates at midnight are whole days")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	set := setFlags(fs)
+	if set["list"] == set["assignee"] {
+		return fmt.Errorf("export one -list or one -assignee: %w", errUsage)
+	}
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		return fmt.Errorf("-tz: %v: %w", err, errUsage)
+	}
+
+	query := clickup.TaskQueryOptions{IncludeSubtasks: true, IncludeClosed: *closed}
+	var tasks []clickup.SingleTask
+	var name string
+	if *listID != "" {
+		name = "Clickup list " + *listID
+		tasks, err = a.client.AllTasksForList(ctx, *listID, &query)
+	} else {
+		workspace, werr := a.requireWorkspace()
+		if werr != nil {
+			return werr
+		}
+		name = fmt.Sprintf("Clickup tasks for %d", *assignee)
+		query.Assignees = []string{fmt.Sprint(*assignee)}
+		tasks, err = a.client.AllTasksForWorkspace(ctx, workspace, &clickup.WorkspaceTaskQueryOptions{TaskQueryOptions: query})
+	}
+	if err != nil {
+		return err
+	}
+	if *listID != "" && len(tasks) > 0 && tasks[0].List.Name != "" {
+		name = tasks[0].List.Name
+	}
+	for _, task := range tasks {
+		for _, user := range task.Assignees {
+			if *listID == "" && user.ID == *assignee && user.Username != "" {
+				name = "Clickup tasks for " + user.Username
+			}
+		}
+	}
+
+	opts := &ical.Options{Name: name, Component: ical.Event, Location: loc}
+	if *todo {
+		opts.Component = ical.Todo
+	}
+	return ical.Write(a.stdout, tasks, opts)
+}
+
+func serveCalendar(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("serve")
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	ttl := fs.Duration("ttl", ical.DefaultTTL, "how long a feed is served before its tasks are read again")
+	tz := fs.String("tz", "UTC", "time zone in which dates at midnight are whole days")
+	var lists, assignees stringList
+	fs.Var(&lists, "list", "list id whose feed is served (repeatable)")
+	fs.Var(&assignees, "assignee", "user id whose feed is served (repeatable)")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if len(lists) == 0 && len(assignees) == 0 {
+		return fmt.Errorf("-list or -assignee is required: %w", errUsage)
+	}
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		return fmt.Errorf("-tz: %v: %w", err, errUsage)
+	}
+
+	srv := &http.Server{
+		Addr: *addr,
+		Handler: &ical.Handler{
+			Client:      a.client,
+			Workspace:   a.workspace,
+			ListIDs:     lists,
+			AssigneeIDs: assignees,
+			TTL:         *ttl,
+			Location:    loc,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(a.stderr, "serving /list/<list-id>.ics")
+	if a.workspace != "" {
+		fmt.Fprintf(a.stderr, " and /assignee/<user-id>.ics")
+	}
+	fmt.Fprintf(a.stderr, " on %s\n", *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Guitarbum722/clickup-client-go"
+	"github.com/Guitarbum722/clickup-client-go/changefeed"
+)
+
+// pollWebhooks prints the events of a changefeed.Poller as JSON lines until ctx is done.
+func pollWebhooks(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("poll")
+	var lists, spaces, views, events stringList
+	fs.Var(&lists, "list", "list id to watch (repeatable)")
+	fs.Var(&spaces, "space", "space id to watch, in the workspace (repeatable)")
+	fs.Var(&views, "view", "view id to watch (repeatable)")
+	fs.Var(&events, "event", "event to emit (repeatable, defaults to every event)")
+	interval := fs.Duration("interval", changefeed.DefaultInterval, "time between polls")
+	closed := fs.Bool("closed", false, "include closed tasks")
+	subtasks := fs.Bool("subtasks", false, "include subtasks")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if len(lists)+len(spaces)+len(views) == 0 {
+		return fmt.Errorf("watch at least one -list, -space or -view: %w", errUsage)
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("-interval must be positive: %w", errUsage)
+	}
+	poller := &changefeed.Poller{
+		Client:          a.client,
+		ListIDs:         lists,
+		SpaceIDs:        spaces,
+		ViewIDs:         views,
+		IncludeClosed:   *closed,
+		IncludeSubtasks: *subtasks,
+		Events:          webhookEvents(events),
+		Interval:        *interval,
+	}
+	if len(spaces) > 0 {
+		workspace, err := a.requireWorkspace()
+		if err != nil {
+			return err
+		}
+		poller.Workspace = workspace
+	}
+
+	enc := json.NewEncoder(a.stdout)
+	err := poller.Run(ctx, func(ctx context.Context, event clickup.WebhookEventMessage) error {
+		return enc.Encode(event)
+	})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func checklistsResource() resource {
+	return resource{
+		name:    "checklists",
+		summary: "checklists on a task and their items",
+		commands: []command{
+			{name: "create", usage: "create -task task-id [-custom-id] -name name", run: createChecklist},
+			{name: "update", usage: "update <checklist-id> [-name name] [-position n]", run: updateChecklist},
+			{name: "delete", usage: "delete <checklist-id>", run: deleteChecklist},
+			{name: "add-item", usage: "add-item <checklist-id> -name name", run: addChecklistItem},
+			{
+				name:  "update-item",
+				usage: "update-item <checklist-id> <item-id> [-name name] [-resolved] [-assignee user-id] [-parent item-id]",
+				run:   updateChecklistItem,
+			},
+			{name: "delete-item", usage: "delete-item <checklist-id> <item-id>", run: deleteChecklistItem},
+		},
+	}
+}
+
+func createChecklist(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("create")
+	task := fs.String("task", "", "task id")
+	customID := fs.Bool("custom-id", false, "the task id is a custom task id")
+	name := fs.String("name", "", "checklist name")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "task", "name"); err != nil {
+		return err
+	}
+	workspace, err := a.requireWorkspace()
+	if err != nil {
+		return err
+	}
+	checklist, err := a.client.CreateChecklist(ctx, &clickup.CreateChecklistRequest{
+		TaskID:           *task,
+		WorkspaceID:      workspace,
+		UseCustomTaskIDs: *customID,
+		Name:             *name,
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(checklist)
+}
+
+func updateChecklist(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("update")
+	name := fs.String("name", "", "checklist name")
+	position := fs.Int("position", 0, "position of the checklist on the task")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	request := &clickup.UpdateChecklistRequest{ChecklistID: positional[0]}
+	set := setFlags(fs)
+	if set["name"] {
+		request.Name = clickup.OptString(*name)
+	}
+	if set["position"] {
+		request.Position = clickup.OptInt(*position)
+	}
+
+	checklist, err := a.client.UpdateChecklist(ctx, request)
+	if err != nil {
+		return err
+	}
+	return a.print(checklist)
+}
+
+func deleteChecklist(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("delete"), args, 1)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteChecklist(ctx, positional[0])
+}
+
+func addChecklistItem(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("add-item")
+	name := fs.String("name", "", "item name")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := required(fs, "name"); err != nil {
+		return err
+	}
+	checklist, err := a.client.CreateChecklistItem(ctx, &clickup.CreateChecklistItemRequest{
+		ChecklistID: positional[0],
+		Name:        *name,
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(checklist)
+}
+
+func updateChecklistItem(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("update-item")
+	name := fs.String("name", "", "item name")
+	resolved := fs.Bool("resolved", false, "mark the item resolved")
+	assignee := fs.Int("assignee", 0, "assign the item to this user id, or 0 to unassign it")
+	parent := fs.String("parent", "", "nest the item under this item id")
+	positional, err := exactArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	request := &clickup.UpdateChecklistItemRequest{ChecklistID: positional[0], ChecklistItemID: positional[1]}
+	set := setFlags(fs)
+	if set["name"] {
+		request.Name = clickup.OptString(*name)
+	}
+	if set["resolved"] {
+		request.Resolved = clickup.OptBool(*resolved)
+	}
+	if set["assignee"] {
+		request.Assignee = clickup.OptInt(*assignee)
+		if *assignee == 0 {
+			request.Assignee = clickup.NullInt()
+		}
+	}
+	if set["parent"] {
+		request.Parent = clickup.OptString(*parent)
+	}
+
+	checklist, err := a.client.UpdateChecklistItem(ctx, request)
+	if err != nil {
+		return err
+	}
+	return a.print(checklist)
+}
+
+func deleteChecklistItem(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("delete-item"), args, 2)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteChecklistItem(ctx, positional[0], positional[1])
+}
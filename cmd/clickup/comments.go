@@ -0,0 +1,187 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func commentsResource() resource {
+	return resource{
+		name:    "comments",
+		summary: "comments on a task, list or chat view",
+		commands: []command{
+			{name: "list", usage: "list (-task task-id [-custom-id] | -list list-id | -view view-id)", run: listComments},
+			{
+				name:  "create",
+				usage: "create (-task task-id [-custom-id] | -list list-id | -view view-id) -text text [-markdown] [-assignee user-id] [-notify-all]",
+				run:   createComment,
+			},
+			{name: "update", usage: "update <comment-id> [-text text] [-markdown] [-assignee user-id] [-resolved]", run: updateComment},
+			{name: "delete", usage: "delete <comment-id>", run: deleteComment},
+		},
+	}
+}
+
+// commentTarget is the -task, -list or -view a comment command applies to.
+type commentTarget struct {
+	task, list, view string
+	customID         bool
+}
+
+func (t *commentTarget) register(fs *flag.FlagSet) {
+	fs.StringVar(&t.task, "task", "", "task id")
+	fs.BoolVar(&t.customID, "custom-id", false, "the task id is a custom task id")
+	fs.StringVar(&t.list, "list", "", "list id")
+	fs.StringVar(&t.view, "view", "", "chat view id")
+}
+
+func (t *commentTarget) validate() error {
+	n := 0
+	for _, id := range []string{t.task, t.list, t.view} {
+		if id != "" {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("exactly one of -task, -list or -view is required: %w", errUsage)
+	}
+	return nil
+}
+
+func listComments(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("list")
+	var target commentTarget
+	target.register(fs)
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := target.validate(); err != nil {
+		return err
+	}
+
+	var comments clickup.CommentsResponse
+	var err error
+	switch {
+	case target.task != "":
+		workspace, werr := a.taskWorkspace(target.customID)
+		if werr != nil {
+			return werr
+		}
+		comments, err = a.client.TaskComments(ctx, clickup.CommentsForTaskQuery{CommentsQuery: clickup.CommentsQuery{
+			TaskID:           target.task,
+			UseCustomTaskIDs: target.customID,
+			WorkspaceID:      workspace,
+		}})
+	case target.list != "":
+		comments, err = a.client.ListComments(ctx, clickup.CommentsForListQuery{CommentsQuery: clickup.CommentsQuery{ListID: target.list}})
+	default:
+		comments, err = a.client.ChatViewComments(ctx, clickup.CommentsForTaskViewQuery{CommentsQuery: clickup.CommentsQuery{ViewID: target.view}})
+	}
+	if err != nil {
+		return err
+	}
+	return a.print(comments.Comments)
+}
+
+func createComment(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("create")
+	var target commentTarget
+	target.register(fs)
+	text := fs.String("text", "", "comment text")
+	markdown := fs.Bool("markdown", false, "format -text as markdown")
+	assignee := fs.Int("assignee", 0, "assign the comment to this user id")
+	notifyAll := fs.Bool("notify-all", false, "notify everyone on the task")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := target.validate(); err != nil {
+		return err
+	}
+	if err := required(fs, "text"); err != nil {
+		return err
+	}
+
+	comment := clickup.CreateCommentRequest{Assignee: *assignee, NotifyAll: *notifyAll}
+	if *markdown {
+		comment.AppendMarkdown(*text, nil)
+	} else {
+		comment.CommentText = *text
+	}
+
+	switch {
+	case target.task != "":
+		workspace, err := a.taskWorkspace(target.customID)
+		if err != nil {
+			return err
+		}
+		request := clickup.NewCreateTaskCommentRequest(target.task, target.customID, workspace)
+		request.CreateCommentRequest = comment
+		response, err := a.client.CreateTaskComment(ctx, *request)
+		if err != nil {
+			return err
+		}
+		return a.print(response)
+	case target.list != "":
+		request := clickup.NewCreateListCommentRequest(target.list)
+		request.CreateCommentRequest = comment
+		response, err := a.client.CreateListComment(ctx, *request)
+		if err != nil {
+			return err
+		}
+		return a.print(response)
+	default:
+		request := clickup.NewCreateChatViewCommentRequest(target.view)
+		request.CreateCommentRequest = comment
+		response, err := a.client.CreateChatViewComment(ctx, *request)
+		if err != nil {
+			return err
+		}
+		return a.print(response)
+	}
+}
+
+func updateComment(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("update")
+	text := fs.String("text", "", "comment text")
+	markdown := fs.Bool("markdown", false, "format -text as markdown")
+	assignee := fs.Int("assignee", 0, "assign the comment to this user id")
+	resolved := fs.Bool("resolved", false, "mark the comment resolved")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	request := clickup.UpdateCommentRequest{CommentID: positional[0]}
+	set := setFlags(fs)
+	if set["text"] {
+		if *markdown {
+			request.Comment = clickup.MarkdownToComment(*text, nil)
+		} else {
+			request.CommentText = clickup.OptString(*text)
+		}
+	}
+	if set["assignee"] {
+		request.Assignee = clickup.OptInt(*assignee)
+	}
+	if set["resolved"] {
+		request.Resolved = clickup.OptBool(*resolved)
+	}
+	return a.client.UpdateComment(ctx, request)
+}
+
+func deleteComment(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("delete"), args, 1)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteComment(ctx, positional[0])
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// config holds the settings that can be read from the config file and environment.
+type config struct {
+	Token     string `json:"token"`
+	Workspace string `json:"workspace"`
+}
+
+// loadConfig reads the config file at path, or the default config file if path is empty, and
+// applies the environment on top of it.  A missing default config file is not an error.
+func loadConfig(path string, getenv func(string) string) (config, error) {
+	var cfg config
+
+	explicit := path != ""
+	if !explicit {
+		path = getenv("CLICKUP_CONFIG")
+		explicit = path != ""
+	}
+	if !explicit {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "clickup", "config.json")
+		}
+	}
+
+	if path != "" {
+		b, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(b, &cfg); err != nil {
+				return config{}, fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+		case explicit || !errors.Is(err, fs.ErrNotExist):
+			return config{}, fmt.Errorf("unable to read config file: %w", err)
+		}
+	}
+
+	if token := getenv("CLICKUP_API_KEY"); token != "" {
+		cfg.Token = token
+	}
+	if workspace := getenv("CLICKUP_WORKSPACE_ID"); workspace != "" {
+		cfg.Workspace = workspace
+	}
+
+	return cfg, nil
+}
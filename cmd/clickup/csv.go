@@ -0,0 +1,176 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+	"github.com/Guitarbum722/clickup-client-go/taskcsv"
+)
+
+func exportTasks(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("export")
+	var lists, spaces, folders, statuses stringList
+	fs.Var(&lists, "list", "list id to export, or with -search a list to search (repeatable)")
+	view := fs.String("view", "", "view id to export")
+	search := fs.Bool("search", false, "export a search of the whole workspace")
+	fs.Var(&spaces, "space", "with -search, a space to search (repeatable)")
+	fs.Var(&folders, "folder", "with -search, a folder to search (repeatable)")
+	fs.Var(&statuses, "status", "only tasks with this status (repeatable)")
+	closed := fs.Bool("closed", false, "include closed tasks")
+	archived := fs.Bool("archived", false, "include archived tasks")
+	subtasks := fs.Bool("subtasks", false, "include subtasks")
+	timeFormat := fs.String("time-format", "", "Go time layout for dates, or unix_ms")
+	tz := fs.String("tz", "", "time zone for dates, such as America/Denver (default UTC)")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	opts := &taskcsv.ExportOptions{Columns: a.output.columns, TimeFormat: *timeFormat}
+	var err error
+	if opts.Location, err = loadLocation(*tz); err != nil {
+		return err
+	}
+	query := clickup.TaskQueryOptions{
+		IncludeArchived: *archived,
+		IncludeSubtasks: *subtasks,
+		IncludeClosed:   *closed,
+		Statuses:        statuses,
+	}
+
+	switch {
+	case *search:
+		if *view != "" {
+			return fmt.Errorf("-search and -view cannot be combined: %w", errUsage)
+		}
+		workspace, err := a.requireWorkspace()
+		if err != nil {
+			return err
+		}
+		return taskcsv.ExportWorkspace(ctx, a.client, a.stdout, workspace, &clickup.WorkspaceTaskQueryOptions{
+			TaskQueryOptions: query,
+			SpaceIDs:         spaces,
+			FolderIDs:        folders,
+			ListIDs:          lists,
+		}, opts)
+	case len(spaces) > 0 || len(folders) > 0:
+		return fmt.Errorf("-space and -folder require -search: %w", errUsage)
+	case *view != "" && len(lists) == 0:
+		return taskcsv.ExportView(ctx, a.client, a.stdout, *view, opts)
+	case *view == "" && len(lists) == 1:
+		return taskcsv.ExportList(ctx, a.client, a.stdout, lists[0], &query, opts)
+	}
+	return fmt.Errorf("export one -list, one -view or a -search: %w", errUsage)
+}
+
+func importTasks(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("import")
+	list := fs.String("list", "", "list id to create the tasks in")
+	dryRun := fs.Bool("dry-run", false, "check every row without creating tasks")
+	progress := fs.String("progress", "", "file that records created rows; an import with the same file resumes")
+	var mappings stringList
+	fs.Var(&mappings, "map", "header=column, such as Title=name or Sprint=cf:Sprint (repeatable)")
+	timeFormat := fs.String("time-format", "", "Go time layout of dates, or unix_ms")
+	tz := fs.String("tz", "", "time zone of dates without one (default UTC)")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := required(fs, "list"); err != nil {
+		return err
+	}
+
+	im := &taskcsv.Importer{
+		Client:     a.client,
+		ListID:     *list,
+		Columns:    make(map[string]string),
+		TimeFormat: *timeFormat,
+		Workspace:  a.workspace,
+		DryRun:     *dryRun,
+	}
+	if im.Location, err = loadLocation(*tz); err != nil {
+		return err
+	}
+	for _, m := range mappings {
+		i := strings.LastIndex(m, "=")
+		if i < 0 {
+			return fmt.Errorf("-map %q is not header=column: %w", m, errUsage)
+		}
+		im.Columns[m[:i]] = m[i+1:]
+	}
+
+	in := a.stdin
+	if positional[0] != "-" {
+		f, err := os.Open(positional[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	if *progress != "" && !*dryRun {
+		f, err := openProgress(*progress, im)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		im.Progress = f
+	}
+
+	report, err := im.Import(ctx, in)
+	for _, row := range report.Failures() {
+		fmt.Fprintf(a.stderr, "row %d: %v\n", row.Row, row.Err)
+	}
+	verb := "created"
+	if *dryRun {
+		verb = "would create"
+	}
+	fmt.Fprintf(a.stdout, "%s %d, skipped %d, failed %d\n", verb, report.Created, report.Skipped, report.Failed)
+	if err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d row(s) failed", report.Failed)
+	}
+	return nil
+}
+
+// openProgress reads the rows an earlier import recorded in path into im.Completed and opens path to
+// record more.
+func openProgress(path string, im *taskcsv.Importer) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if im.Completed, err = taskcsv.ReadProgress(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, err := f.Seek(0, io.SeekEnd); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("-tz: unknown time zone %q", name)
+	}
+	return loc, nil
+}
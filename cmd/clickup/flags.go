@@ -0,0 +1,144 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// flagSet returns an empty flag set for a command that reports errors instead of exiting.
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// parseArgs parses args with fs, allowing flags and positional arguments in any order, and returns
+// the positional arguments.  Everything after "--" is positional.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// exactArgs parses args with fs and requires exactly n positional arguments.
+func exactArgs(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != n {
+		return nil, fmt.Errorf("expected %d argument(s), got %d: %w", n, len(positional), errUsage)
+	}
+	return positional, nil
+}
+
+// setFlags returns the names of the flags that were given on the command line.
+func setFlags(fs *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
+
+// required returns an error naming the first of names that was not given.
+func required(fs *flag.FlagSet, names ...string) error {
+	set := setFlags(fs)
+	for _, name := range names {
+		if !set[name] {
+			return fmt.Errorf("-%s is required: %w", name, errUsage)
+		}
+	}
+	return nil
+}
+
+// stringList is a flag that can be repeated.
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, ",") }
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// intList is a flag of ids that can be repeated.
+type intList []int
+
+func (l *intList) String() string {
+	parts := make([]string, 0, len(*l))
+	for _, v := range *l {
+		parts = append(parts, strconv.Itoa(v))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (l *intList) Set(v string) error {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", v)
+	}
+	*l = append(*l, n)
+	return nil
+}
+
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"}
+
+// parseDate parses a date flag.  Dates are RFC 3339, YYYY-MM-DD, YYYY-MM-DDTHH:MM in local time,
+// or unix milliseconds.  The empty string is the zero time.
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return clickup.TimestampFromMillis(ms).Time, nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD, RFC 3339 or unix milliseconds", s)
+}
+
+// optDate parses a date flag for an update.  "none" clears the date.
+func optDate(s string) (clickup.OptionalTimestamp, error) {
+	if s == "none" {
+		return clickup.NullTimestamp(), nil
+	}
+	t, err := parseDate(s)
+	if err != nil {
+		return clickup.OptionalTimestamp{}, err
+	}
+	return clickup.OptTimestamp(t), nil
+}
+
+// timestampPtr parses a date flag for a create request, returning nil for no date.
+func timestampPtr(s string) (*clickup.Timestamp, error) {
+	t, err := parseDate(s)
+	if err != nil || t.IsZero() {
+		return nil, err
+	}
+	return clickup.NewTimestamp(t).Ptr(), nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func goalsResource() resource {
+	return resource{
+		name:    "goals",
+		summary: "goals in the workspace",
+		commands: []command{
+			{name: "list", usage: "list [-completed]", run: listGoals},
+			{name: "get", usage: "get <goal-id>", run: getGoal},
+			{
+				name:  "create",
+				usage: "create -name name [-description text] [-due date] [-color color] [-owner user-id]... [-multiple-owners]",
+				run:   createGoal,
+			},
+			{
+				name: "update",
+				usage: "update <goal-id> [-name name] [-description text] [-due date|none] [-color color] " +
+					"[-add-owner user-id]... [-remove-owner user-id]... [-folder folder-id|none]",
+				run: updateGoal,
+			},
+			{name: "delete", usage: "delete <goal-id>", run: deleteGoal},
+		},
+	}
+}
+
+func listGoals(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("list")
+	completed := fs.Bool("completed", false, "include completed goals")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	workspace, err := a.requireWorkspace()
+	if err != nil {
+		return err
+	}
+	goals, err := a.client.GoalsForWorkspace(ctx, workspace, *completed)
+	if err != nil {
+		return err
+	}
+	return a.print(goals)
+}
+
+func getGoal(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	goal, err := a.client.GoalForWorkSpace(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return a.print(goal)
+}
+
+func createGoal(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("create")
+	name := fs.String("name", "", "goal name")
+	description := fs.String("description", "", "goal description")
+	due := fs.String("due", "", "due date")
+	color := fs.String("color", "", "goal color")
+	var owners intList
+	fs.Var(&owners, "owner", "owner user id (repeatable)")
+	multipleOwners := fs.Bool("multiple-owners", false, "allow multiple owners")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "name"); err != nil {
+		return err
+	}
+	workspace, err := a.requireWorkspace()
+	if err != nil {
+		return err
+	}
+	dueDate, err := parseDate(*due)
+	if err != nil {
+		return fmt.Errorf("-due: %w", err)
+	}
+
+	goal, err := a.client.CreateGoal(ctx, clickup.CreateGoalRequest{
+		WorkspaceID:    workspace,
+		Name:           *name,
+		DueDate:        clickup.NewTimestamp(dueDate),
+		Description:    *description,
+		MultipleOwners: *multipleOwners,
+		Owners:         owners,
+		Color:          *color,
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(goal)
+}
+
+func updateGoal(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("update")
+	name := fs.String("name", "", "goal name")
+	description := fs.String("description", "", "goal description")
+	due := fs.String("due", "", "due date, or none to clear it")
+	color := fs.String("color", "", "goal color")
+	var addOwners, removeOwners intList
+	fs.Var(&addOwners, "add-owner", "add an owner user id (repeatable)")
+	fs.Var(&removeOwners, "remove-owner", "remove an owner user id (repeatable)")
+	folder := fs.String("folder", "", "move the goal to this goal folder, or none to remove it from its folder")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	request := clickup.UpdateGoalRequest{ID: positional[0], AddOwners: addOwners, RemoveOwners: removeOwners}
+	set := setFlags(fs)
+	if set["name"] {
+		request.Name = clickup.OptString(*name)
+	}
+	if set["description"] {
+		request.Description = clickup.OptString(*description)
+	}
+	if set["due"] {
+		if request.DueDate, err = optDate(*due); err != nil {
+			return fmt.Errorf("-due: %w", err)
+		}
+	}
+	if set["color"] {
+		request.Color = clickup.OptString(*color)
+	}
+	if set["folder"] {
+		request.FolderID = clickup.OptString(*folder)
+		if *folder == "none" {
+			request.FolderID = clickup.NullString()
+		}
+	}
+
+	goal, err := a.client.UpdateGoal(ctx, request)
+	if err != nil {
+		return err
+	}
+	return a.print(goal)
+}
+
+func deleteGoal(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("delete"), args, 1)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteGoal(ctx, positional[0])
+}
@@ -0,0 +1,398 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func teamsResource() resource {
+	return resource{
+		name:    "teams",
+		summary: "workspaces the token can access",
+		commands: []command{
+			{name: "list", usage: "list", run: listTeams},
+			{name: "get", usage: "get <workspace-id>", run: getTeam},
+			{
+				name:  "backup",
+				usage: "backup [-archived] [-skip-comments] [-skip-attachments] <file.zip>",
+				run:   backupWorkspace,
+			},
+			{
+				name: "restore",
+				usage: "restore [-user old=new]... [-skip-comments] [-skip-attachments] [-skip-goals] [-skip-views] " +
+					"<file.zip>",
+				run: restoreWorkspace,
+			},
+		},
+	}
+}
+
+func listTeams(ctx context.Context, a *app, args []string) error {
+	if _, err := exactArgs(a.flagSet("list"), args, 0); err != nil {
+		return err
+	}
+	teams, err := a.client.Teams(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(teams.Teams)
+}
+
+func getTeam(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	teams, err := a.client.Teams(ctx)
+	if err != nil {
+		return err
+	}
+	for _, team := range teams.Teams {
+		if team.ID == positional[0] {
+			return a.print(team)
+		}
+	}
+	return fmt.Errorf("workspace %s not found", positional[0])
+}
+
+func spacesResource() resource {
+	return resource{
+		name:    "spaces",
+		summary: "spaces in the workspace",
+		commands: []command{
+			{name: "list", usage: "list [-archived]", run: listSpaces},
+			{name: "get", usage: "get <space-id>", run: getSpace},
+			{name: "create", usage: "create -name name [-multiple-assignees]", run: createSpace},
+			{name: "update", usage: "update <space-id> [-name name] [-color color] [-private] [-multiple-assignees]", run: updateSpace},
+			{name: "delete", usage: "delete <space-id>", run: deleteSpace},
+		},
+	}
+}
+
+func listSpaces(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("list")
+	archived := fs.Bool("archived", false, "include archived spaces")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	workspace, err := a.requireWorkspace()
+	if err != nil {
+		return err
+	}
+	spaces, err := a.client.SpacesForWorkspace(ctx, workspace, *archived)
+	if err != nil {
+		return err
+	}
+	return a.print(spaces.Spaces)
+}
+
+func getSpace(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	space, err := a.client.SpaceByID(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return a.print(space)
+}
+
+func createSpace(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("create")
+	name := fs.String("name", "", "space name")
+	multipleAssignees := fs.Bool("multiple-assignees", false, "allow multiple assignees on tasks")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "name"); err != nil {
+		return err
+	}
+	workspace, err := a.requireWorkspace()
+	if err != nil {
+		return err
+	}
+	space, err := a.client.CreateSpaceForWorkspace(ctx, clickup.CreateSpaceRequest{
+		WorkspaceID:       workspace,
+		Name:              *name,
+		MultipleAssignees: *multipleAssignees,
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(space)
+}
+
+func updateSpace(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("update")
+	name := fs.String("name", "", "space name")
+	color := fs.String("color", "", "space color")
+	private := fs.Bool("private", false, "make the space private")
+	multipleAssignees := fs.Bool("multiple-assignees", false, "allow multiple assignees on tasks")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	request := clickup.UpdateSpaceRequest{ID: positional[0]}
+	set := setFlags(fs)
+	if set["name"] {
+		request.Name = clickup.OptString(*name)
+	}
+	if set["color"] {
+		request.Color = clickup.OptString(*color)
+	}
+	if set["private"] {
+		request.Private = clickup.OptBool(*private)
+	}
+	if set["multiple-assignees"] {
+		request.MultipleAssignees = clickup.OptBool(*multipleAssignees)
+	}
+
+	space, err := a.client.UpdateSpaceForWorkspace(ctx, request)
+	if err != nil {
+		return err
+	}
+	return a.print(space)
+}
+
+func deleteSpace(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("delete"), args, 1)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteSpace(ctx, positional[0])
+}
+
+func foldersResource() resource {
+	return resource{
+		name:    "folders",
+		summary: "folders in a space",
+		commands: []command{
+			{name: "list", usage: "list -space space-id [-archived]", run: listFolders},
+			{name: "get", usage: "get <folder-id>", run: getFolder},
+			{name: "create", usage: "create -space space-id -name name", run: createFolder},
+			{name: "update", usage: "update <folder-id> -name name", run: updateFolder},
+			{name: "delete", usage: "delete <folder-id>", run: deleteFolder},
+		},
+	}
+}
+
+func listFolders(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("list")
+	space := fs.String("space", "", "space id")
+	archived := fs.Bool("archived", false, "include archived folders")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "space"); err != nil {
+		return err
+	}
+	folders, err := a.client.FoldersForSpace(ctx, *space, *archived)
+	if err != nil {
+		return err
+	}
+	return a.print(folders.Folders)
+}
+
+func getFolder(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	folder, err := a.client.FolderByID(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return a.print(folder)
+}
+
+func createFolder(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("create")
+	space := fs.String("space", "", "space id")
+	name := fs.String("name", "", "folder name")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "space", "name"); err != nil {
+		return err
+	}
+	folder, err := a.client.CreateFolder(ctx, clickup.CreateFolderRequest{SpaceID: *space, Name: *name})
+	if err != nil {
+		return err
+	}
+	return a.print(folder)
+}
+
+func updateFolder(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("update")
+	name := fs.String("name", "", "folder name")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := required(fs, "name"); err != nil {
+		return err
+	}
+	folder, err := a.client.UpdateFolder(ctx, clickup.UpdateFolderRequest{ID: positional[0], Name: clickup.OptString(*name)})
+	if err != nil {
+		return err
+	}
+	return a.print(folder)
+}
+
+func deleteFolder(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("delete"), args, 1)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteFolder(ctx, positional[0])
+}
+
+func listsResource() resource {
+	return resource{
+		name:    "lists",
+		summary: "lists in a folder or space and sprint reports",
+		commands: []command{
+			{name: "list", usage: "list (-folder folder-id | -space space-id) [-archived]", run: listLists},
+			{name: "get", usage: "get <list-id>", run: getList},
+			{name: "create", usage: "create (-folder folder-id | -space space-id) -name name [-content text] [-due date]", run: createList},
+			{
+				name:  "update",
+				usage: "update <list-id> [-name name] [-content text] [-due date|none] [-priority priority|none]",
+				run:   updateList,
+			},
+			{name: "delete", usage: "delete <list-id>", run: deleteList},
+			{
+				name:  "sprint",
+				usage: "sprint <list-id> -start date -end date [-unit tasks|points|hours] [-svg burndown|burnup] [-tz zone] [-subtasks]",
+				run:   reportSprint,
+			},
+		},
+	}
+}
+
+func listLists(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("list")
+	folder := fs.String("folder", "", "folder id")
+	space := fs.String("space", "", "space id, to list the lists that are not in a folder")
+	archived := fs.Bool("archived", false, "include archived lists")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	set := setFlags(fs)
+	if set["folder"] == set["space"] {
+		return fmt.Errorf("list the lists of one -folder or one -space: %w", errUsage)
+	}
+
+	var lists *clickup.ListsResponse
+	var err error
+	if set["space"] {
+		lists, err = a.client.FolderlessLists(ctx, *space, *archived)
+	} else {
+		lists, err = a.client.ListsForFolder(ctx, *folder, *archived)
+	}
+	if err != nil {
+		return err
+	}
+	return a.print(lists.Lists)
+}
+
+func getList(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	list, err := a.client.ListByID(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return a.print(list)
+}
+
+func createList(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("create")
+	folder := fs.String("folder", "", "folder id")
+	space := fs.String("space", "", "space id, to create the list outside of any folder")
+	name := fs.String("name", "", "list name")
+	content := fs.String("content", "", "list description")
+	due := fs.String("due", "", "due date")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	set := setFlags(fs)
+	if set["folder"] == set["space"] {
+		return fmt.Errorf("create the list in one -folder or one -space: %w", errUsage)
+	}
+	if err := required(fs, "name"); err != nil {
+		return err
+	}
+
+	request := clickup.CreateListRequest{FolderID: *folder, SpaceID: *space, Name: *name, Content: *content}
+	if set["due"] {
+		date, err := parseDate(*due)
+		if err != nil {
+			return fmt.Errorf("-due: %w", err)
+		}
+		request.DueDate = clickup.NewTimestamp(date).Ptr()
+	}
+
+	list, err := a.client.CreateList(ctx, request)
+	if err != nil {
+		return err
+	}
+	return a.print(list)
+}
+
+func updateList(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("update")
+	name := fs.String("name", "", "list name")
+	content := fs.String("content", "", "list description")
+	due := fs.String("due", "", "due date, or none to clear it")
+	priority := fs.String("priority", "", "urgent, high, normal, low or none")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	request := clickup.UpdateListRequest{ID: positional[0]}
+	set := setFlags(fs)
+	if set["name"] {
+		request.Name = clickup.OptString(*name)
+	}
+	if set["content"] {
+		request.Content = clickup.OptString(*content)
+	}
+	if set["due"] {
+		if request.DueDate, err = optDate(*due); err != nil {
+			return fmt.Errorf("-due: %w", err)
+		}
+	}
+	if set["priority"] {
+		if request.Priority, err = parsePriority(*priority); err != nil {
+			return err
+		}
+	}
+
+	list, err := a.client.UpdateList(ctx, request)
+	if err != nil {
+		return err
+	}
+	return a.print(list)
+}
+
+func deleteList(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("delete"), args, 1)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteList(ctx, positional[0])
+}
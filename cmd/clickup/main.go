@@ -0,0 +1,239 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Command clickup manages a Clickup workspace from the terminal.
+//
+// Usage:
+//
+//	clickup [-token token] [-workspace id] [-config file] [-output format] [-columns list] [-template text]
+//		<resource> <command> [flags] [args]
+//
+// The API token is read from -token, the CLICKUP_API_KEY environment variable or the "token" key of the
+// config file, in that order.  The workspace is read the same way from -workspace, CLICKUP_WORKSPACE_ID
+// or the "workspace" key.  The config file is JSON and defaults to clickup/config.json in the user
+// config directory, or CLICKUP_CONFIG if set.
+//
+// Task commands accept -custom-id to look tasks up by their custom task id, which requires a workspace.
+// Results are written to stdout as JSON.  Tasks, lists, folders, spaces, goals and webhooks can instead be
+// flattened with -output json, yaml, csv or table, narrowed with -columns id,name,cf:Sprint or written
+// through a Go template with -template '{{.id}} {{.name}}'.  Run "clickup help" for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/Guitarbum722/clickup-client-go"
+	"github.com/Guitarbum722/clickup-client-go/format"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Getenv, os.Stdin, os.Stdout, os.Stderr, nil))
+}
+
+// app is the state shared by every command.
+type app struct {
+	client    *clickup.Client
+	workspace string
+	output    output
+	stdin     io.Reader
+	stdout    io.Writer
+	stderr    io.Writer
+}
+
+// command is a single operation on a resource, such as "tasks get".
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+// resource groups the commands for one kind of Clickup object.
+type resource struct {
+	name     string
+	summary  string
+	commands []command
+}
+
+func resources() []resource {
+	return []resource{
+		teamsResource(),
+		spacesResource(),
+		foldersResource(),
+		listsResource(),
+		tasksResource(),
+		commentsResource(),
+		checklistsResource(),
+		goalsResource(),
+		viewsResource(),
+		webhooksResource(),
+		templatesResource(),
+		timeInStatusResource(),
+		calendarResource(),
+	}
+}
+
+// errUsage is returned by commands that were called with the wrong arguments.  The usage of the
+// command is printed with the error.
+var errUsage = errors.New("invalid usage")
+
+// run executes the command line args and returns the exit code.  doer is used for requests to Clickup
+// and may be nil to use the client default.
+func run(ctx context.Context, args []string, getenv func(string) string, stdin io.Reader, stdout, stderr io.Writer, doer clickup.ClientDoer) int {
+	fs := flag.NewFlagSet("clickup", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	token := fs.String("token", "", "Clickup API token")
+	workspace := fs.String("workspace", "", "workspace (team) id")
+	configPath := fs.String("config", "", "path to the config file")
+	outputFormat := fs.String("output", "", "flatten results as json, yaml, csv or table")
+	columns := fs.String("columns", "", "comma separated columns to output")
+	tmpl := fs.String("template", "", "Go template to output each result with")
+	fs.Usage = func() { printUsage(stderr, fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if fs.NArg() == 0 || fs.Arg(0) == "help" {
+		printUsage(stdout, fs)
+		return 0
+	}
+	if fs.NArg() < 2 {
+		fmt.Fprintf(stderr, "clickup: missing command for %q\n", fs.Arg(0))
+		printResourceUsage(stderr, fs.Arg(0))
+		return 2
+	}
+
+	out := output{template: *tmpl}
+	if *outputFormat != "" {
+		f, err := format.ParseFormat(*outputFormat)
+		if err != nil {
+			fmt.Fprintf(stderr, "clickup: %v\n", err)
+			return 2
+		}
+		out.format = f
+	}
+	if *columns != "" {
+		out.columns = strings.Split(*columns, ",")
+	}
+
+	res, cmd, ok := findCommand(fs.Arg(0), fs.Arg(1))
+	if !ok {
+		fmt.Fprintf(stderr, "clickup: unknown command %q\n", strings.Join(fs.Args()[:2], " "))
+		printResourceUsage(stderr, fs.Arg(0))
+		return 2
+	}
+
+	cfg, err := loadConfig(*configPath, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "clickup: %v\n", err)
+		return 1
+	}
+	if *token != "" {
+		cfg.Token = *token
+	}
+	if *workspace != "" {
+		cfg.Workspace = *workspace
+	}
+	if cfg.Token == "" {
+		fmt.Fprintln(stderr, "clickup: no API token: use -token, CLICKUP_API_KEY or the config file")
+		return 1
+	}
+
+	a := &app{
+		client: clickup.NewClient(&clickup.ClientOpts{
+			Doer:          doer,
+			Authenticator: &clickup.APITokenAuthenticator{APIToken: cfg.Token},
+		}),
+		workspace: cfg.Workspace,
+		output:    out,
+		stdin:     stdin,
+		stdout:    stdout,
+		stderr:    stderr,
+	}
+
+	if err := cmd.run(ctx, a, fs.Args()[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(stderr, "clickup %s %s: %v\n", res.name, cmd.name, err)
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(stderr, "usage: clickup %s %s\n", res.name, cmd.usage)
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+func findCommand(resourceName, commandName string) (resource, command, bool) {
+	for _, res := range resources() {
+		if res.name != resourceName {
+			continue
+		}
+		for _, cmd := range res.commands {
+			if cmd.name == commandName {
+				return res, cmd, true
+			}
+		}
+	}
+	return resource{}, command{}, false
+}
+
+func printUsage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintln(w, "usage: clickup [flags] <resource> <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "flags:")
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "resources:")
+	for _, res := range resources() {
+		fmt.Fprintf(w, "  %-16s %s\n", res.name, res.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "clickup <resource>" for the commands of a resource.`)
+}
+
+func printResourceUsage(w io.Writer, name string) {
+	for _, res := range resources() {
+		if res.name != name {
+			continue
+		}
+		fmt.Fprintf(w, "%s commands:\n", res.name)
+		for _, cmd := range res.commands {
+			fmt.Fprintf(w, "  clickup %s %s\n", res.name, cmd.usage)
+		}
+	}
+}
+
+// requireWorkspace returns the configured workspace id, or an error if there is none.
+func (a *app) requireWorkspace() (string, error) {
+	if a.workspace == "" {
+		return "", fmt.Errorf("a workspace is required: use -workspace, CLICKUP_WORKSPACE_ID or the config file")
+	}
+	return a.workspace, nil
+}
+
+// taskWorkspace returns the workspace to send with a task id.  Custom task ids require one.
+func (a *app) taskWorkspace(customID bool) (string, error) {
+	if customID {
+		return a.requireWorkspace()
+	}
+	return a.workspace, nil
+}
@@ -0,0 +1,470 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeDoer struct {
+	requests []*http.Request
+	bodies   []string
+	response string
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	var body string
+	if req.Body != nil {
+		b, _ := ioutil.ReadAll(req.Body)
+		body = string(b)
+	}
+	d.requests = append(d.requests, req)
+	d.bodies = append(d.bodies, body)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(d.response)),
+		Request:    req,
+	}, nil
+}
+
+// env returns a getenv for vars.  Unless vars sets CLICKUP_CONFIG, an empty config file is used so that
+// the tests do not read the config of the user running them.
+func env(t *testing.T, vars map[string]string) func(string) string {
+	t.Helper()
+	empty := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(empty, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return func(key string) string {
+		if key == "CLICKUP_CONFIG" && vars[key] == "" {
+			return empty
+		}
+		return vars[key]
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		env        map[string]string
+		stdin      string
+		response   string
+		wantCode   int
+		wantURI    string
+		wantMethod string
+		wantBody   string
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "Get task by custom id",
+			args:       []string{"-workspace", "444", "tasks", "get", "ABC-1", "-custom-id"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"id":"abc","custom_id":"ABC-1","name":"Fix it"}`,
+			wantMethod: http.MethodGet,
+			wantURI:    "/api/v2/task/ABC-1/?custom_task_ids=true&include_subtasks=false&team_id=444",
+			wantStdout: `"name": "Fix it"`,
+		},
+		{
+			name:       "Custom id requires a workspace",
+			args:       []string{"tasks", "get", "-custom-id", "ABC-1"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   1,
+			wantStderr: "a workspace is required",
+		},
+		{
+			name:       "Update only sends the given flags",
+			args:       []string{"tasks", "update", "abc", "-name", "Renamed", "-due", "none", "-priority", "high"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"id":"abc"}`,
+			wantMethod: http.MethodPut,
+			wantURI:    "/api/v2/task/abc/?custom_task_ids=false&team_id=",
+			wantBody:   `{"name":"Renamed","priority":2,"due_date":null}`,
+		},
+		{
+			name:       "Create comment from markdown",
+			args:       []string{"comments", "create", "-list", "l1", "-markdown", "-text", "**hi**"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"id":1}`,
+			wantMethod: http.MethodPost,
+			wantURI:    "/api/v2/list/l1/comment",
+			wantBody:   `{"comment":[{"text":"hi","attributes":{"bold":true,"italic":false,"code":false,"list":null}},{"text":"\n"}]}`,
+		},
+		{
+			name:       "Workspace from environment",
+			args:       []string{"goals", "list"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1", "CLICKUP_WORKSPACE_ID": "555"},
+			response:   `{"goals":[]}`,
+			wantMethod: http.MethodGet,
+			wantURI:    "/api/v2/team/555/goal/?include_completed=false",
+		},
+		{
+			name:       "Tasks as CSV",
+			args:       []string{"-output", "csv", "-columns", "id,name,assignees", "tasks", "list", "-list", "l1"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"tasks":[{"id":"abc","name":"Fix it","assignees":[{"username":"ana"},{"username":"bo"}]}]}`,
+			wantMethod: http.MethodGet,
+			wantURI:    "/api/v2/list/l1/task/?page=0",
+			wantStdout: "id,name,assignees\nabc,Fix it,\"ana,bo\"\n",
+		},
+		{
+			name:       "Task through a template",
+			args:       []string{"-template", "{{.id}} {{.status}}", "tasks", "get", "abc"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"id":"abc","status":{"status":"open"}}`,
+			wantMethod: http.MethodGet,
+			wantURI:    "/api/v2/task/abc/?custom_task_ids=false&include_subtasks=false&team_id=",
+			wantStdout: "abc open\n",
+		},
+		{
+			name:       "Output without a converter",
+			args:       []string{"-output", "table", "teams", "list"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"teams":[]}`,
+			wantMethod: http.MethodGet,
+			wantURI:    "/api/v2/team",
+			wantCode:   1,
+			wantStderr: "only supports JSON output",
+		},
+		{
+			name:       "Unknown output format",
+			args:       []string{"-output", "xml", "teams", "list"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: `unknown output format "xml"`,
+		},
+		{
+			name:       "Export list to CSV",
+			args:       []string{"-columns", "id,name,due_date", "tasks", "export", "-list", "l1", "-time-format", "2006-01-02"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"tasks":[{"id":"abc","name":"Fix it","due_date":"1656633600000"}]}`,
+			wantMethod: http.MethodGet,
+			wantURI:    "/api/v2/list/l1/task/?page=0",
+			wantStdout: "id,name,due_date\nabc,Fix it,2022-07-01\n",
+		},
+		{
+			name:       "Import dry run from stdin",
+			args:       []string{"tasks", "import", "-list", "l1", "-dry-run", "-map", "Title=name", "-"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			stdin:      "Title,priority\nOne,high\nTwo,someday\n",
+			wantCode:   1,
+			wantStdout: "would create 1, skipped 0, failed 1",
+			wantStderr: `row 3: priority: invalid priority "someday"`,
+		},
+		{
+			name:       "Export needs a source",
+			args:       []string{"tasks", "export"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: "export one -list, one -view or a -search",
+		},
+		{
+			name:       "Missing required flag",
+			args:       []string{"folders", "list"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: "usage: clickup folders list -space space-id",
+		},
+		{
+			name:       "Unknown command",
+			args:       []string{"folders", "archive"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: `unknown command "folders archive"`,
+		},
+		{
+			name:       "Rename folder",
+			args:       []string{"folders", "update", "f1", "-name", "Roadmap"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"id":"f1","name":"Roadmap"}`,
+			wantMethod: http.MethodPut,
+			wantURI:    "/api/v2/folder/f1",
+			wantBody:   `{"name":"Roadmap"}`,
+		},
+		{
+			name:       "Delete folder",
+			args:       []string{"folders", "delete", "f1"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{}`,
+			wantMethod: http.MethodDelete,
+			wantURI:    "/api/v2/folder/f1",
+		},
+		{
+			name:       "Folderless lists",
+			args:       []string{"lists", "list", "-space", "s1"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"lists":[{"id":"l1","name":"Inbox"}]}`,
+			wantMethod: http.MethodGet,
+			wantURI:    "/api/v2/space/s1/list/?archived=false",
+			wantStdout: `"name": "Inbox"`,
+		},
+		{
+			name:       "Lists need a folder or a space",
+			args:       []string{"lists", "list", "-folder", "f1", "-space", "s1"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: "list the lists of one -folder or one -space",
+		},
+		{
+			name:       "Create folderless list",
+			args:       []string{"lists", "create", "-space", "s1", "-name", "Inbox"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"id":"l1","name":"Inbox"}`,
+			wantMethod: http.MethodPost,
+			wantURI:    "/api/v2/space/s1/list",
+			wantBody:   `{"name":"Inbox"}`,
+		},
+		{
+			name:       "Update list only sends the given flags",
+			args:       []string{"lists", "update", "l1", "-content", "Triage", "-due", "none"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"id":"l1"}`,
+			wantMethod: http.MethodPut,
+			wantURI:    "/api/v2/list/l1",
+			wantBody:   `{"content":"Triage","due_date":null}`,
+		},
+		{
+			name:       "Delete list",
+			args:       []string{"lists", "delete", "l1"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{}`,
+			wantMethod: http.MethodDelete,
+			wantURI:    "/api/v2/list/l1",
+		},
+		{
+			name:       "Backup needs a workspace",
+			args:       []string{"teams", "backup", "workspace.zip"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   1,
+			wantStderr: "a workspace is required",
+		},
+		{
+			name:       "Restore user mapping",
+			args:       []string{"-workspace", "444", "teams", "restore", "-user", "7", "workspace.zip"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: `-user "7" is not old=new user ids`,
+		},
+		{
+			name:     "Restore missing archive",
+			args:     []string{"-workspace", "444", "teams", "restore", "missing.zip"},
+			env:      map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode: 1,
+		},
+		{
+			name:       "Export assignee calendar",
+			args:       []string{"-workspace", "444", "calendar", "export", "-assignee", "7", "-todo"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"tasks":[{"id":"abc","name":"Fix it","due_date":"1656633600000","assignees":[{"id":7,"username":"ana"}]}]}`,
+			wantMethod: http.MethodGet,
+			wantURI:    "/api/v2/team/444/task?assignees%5B%5D=7&page=0&subtasks=true",
+			wantStdout: "X-WR-CALNAME:Clickup tasks for ana\r\nBEGIN:VTODO\r\n",
+		},
+		{
+			name:       "Calendar needs a source",
+			args:       []string{"calendar", "export", "-list", "l1", "-assignee", "7"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: "export one -list or one -assignee",
+		},
+		{
+			name: "Time in status report as CSV",
+			args: []string{"time-in-status", "report", "a", "b", "-csv", "summary"},
+			env:  map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response: `{"a":{"current_status":{"status":"closed","total_time":{"by_minute":5,"since":"1656633600000"}},
+				"status_history":[{"status":"open","type":"open","total_time":{"by_minute":60,"since":"1656630000000"}},
+				{"status":"closed","type":"closed","total_time":{"by_minute":5,"since":"1656633600000"}}]}}`,
+			wantMethod: http.MethodGet,
+			wantURI:    "/api/v2/task/bulk_time_in_status/task_ids/?custom_task_ids=false&task_ids=a&task_ids=b&team_id=",
+			wantStdout: "lead_time,1,60,60,60,60,60,60,60\n",
+		},
+		{
+			name:       "Unknown report table",
+			args:       []string{"time-in-status", "report", "-list", "l1", "-csv", "chart"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: `unknown -csv table "chart"`,
+		},
+		{
+			name:       "Sprint burnup chart",
+			args:       []string{"lists", "sprint", "l1", "-start", "2022-07-01", "-end", "2022-07-08", "-svg", "burnup"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			response:   `{"tasks":[{"id":"abc","name":"Fix it","status":{"status":"open","type":"open"},"date_created":"1656633600000","list":{"id":"l1","name":"Sprint 12"}}]}`,
+			wantMethod: http.MethodGet,
+			wantURI:    "/api/v2/list/l1/task/?include_closed=true&page=0",
+			wantStdout: "<title>Sprint 12 burnup</title>",
+		},
+		{
+			name:       "Calendar server needs allowed feeds",
+			args:       []string{"calendar", "serve", "-addr", "localhost:0"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: "-list or -assignee is required",
+		},
+		{
+			name:       "Sprint needs dates",
+			args:       []string{"lists", "sprint", "l1", "-start", "2022-07-01"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: "-end is required",
+		},
+		{
+			name:       "Sync needs a state file",
+			args:       []string{"tasks", "sync", "-list", "l1"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: "-state is required",
+		},
+		{
+			name:       "Poll needs something to watch",
+			args:       []string{"webhooks", "poll", "-event", "taskCreated"},
+			env:        map[string]string{"CLICKUP_API_KEY": "pk_1"},
+			wantCode:   2,
+			wantStderr: "watch at least one -list, -space or -view",
+		},
+		{
+			name:       "Missing token",
+			args:       []string{"teams", "list"},
+			wantCode:   1,
+			wantStderr: "no API token",
+		},
+		{
+			name:       "Help",
+			args:       []string{"help"},
+			wantStdout: "time-in-status",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doer := &fakeDoer{response: tt.response}
+			var stdout, stderr bytes.Buffer
+
+			code := run(context.Background(), tt.args, env(t, tt.env), strings.NewReader(tt.stdin), &stdout, &stderr, doer)
+			if code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d; stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("stdout = %q, want it to contain %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+			if tt.wantURI == "" {
+				if len(doer.requests) != 0 {
+					t.Errorf("unexpected request to %s", doer.requests[0].URL)
+				}
+				return
+			}
+			if len(doer.requests) != 1 {
+				t.Fatalf("made %d requests, want 1", len(doer.requests))
+			}
+			req := doer.requests[0]
+			if req.Method != tt.wantMethod || req.URL.RequestURI() != tt.wantURI {
+				t.Errorf("request = %s %s, want %s %s", req.Method, req.URL.RequestURI(), tt.wantMethod, tt.wantURI)
+			}
+			if got := req.Header.Get("Authorization"); got != "pk_1" {
+				t.Errorf("Authorization = %q, want pk_1", got)
+			}
+			if tt.wantBody != "" && doer.bodies[0] != tt.wantBody {
+				t.Errorf("body = %s, want %s", doer.bodies[0], tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRun_syncTasks(t *testing.T) {
+	state := filepath.Join(t.TempDir(), "sync.json")
+	args := []string{"tasks", "sync", "-list", "l1", "-state", state}
+	vars := map[string]string{"CLICKUP_API_KEY": "pk_1"}
+	task := `{"tasks":[{"id":"abc","name":"Fix it","date_updated":"1656633600000","list":{"id":"l1"}}]}`
+
+	for i, want := range []string{`"type": "created"`, "[]"} {
+		doer := &fakeDoer{response: task}
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), args, env(t, vars), strings.NewReader(""), &stdout, &stderr, doer); code != 0 {
+			t.Fatalf("sync %d exited with %d: %s", i, code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("sync %d printed %q, want it to contain %q", i, stdout.String(), want)
+		}
+		if i == 1 && !strings.Contains(doer.requests[0].URL.RawQuery, "date_updated_gt=1656633540000") {
+			t.Errorf("second sync requested %s, want updates since the watermark", doer.requests[0].URL)
+		}
+	}
+}
+
+func TestRun_pollWebhooks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	doer := &fakeDoer{response: `{"tasks":[{"id":"abc","name":"Fix it"}]}`}
+	var stdout, stderr bytes.Buffer
+	args := []string{"webhooks", "poll", "-list", "l1", "-interval", "10ms"}
+	if code := run(ctx, args, env(t, map[string]string{"CLICKUP_API_KEY": "pk_1"}), strings.NewReader(""), &stdout, &stderr, doer); code != 0 {
+		t.Fatalf("poll exited with %d: %s", code, stderr.String())
+	}
+	if len(doer.requests) < 2 || doer.requests[0].URL.Path != "/api/v2/list/l1/task/" {
+		t.Errorf("made %d requests, want the list polled several times", len(doer.requests))
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want no events for unchanged tasks", stdout.String())
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"token":"pk_file","workspace":"111"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig(path, env(t, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (config{Token: "pk_file", Workspace: "111"}); cfg != want {
+		t.Errorf("loadConfig() = %+v, want %+v", cfg, want)
+	}
+
+	cfg, err = loadConfig("", env(t, map[string]string{"CLICKUP_CONFIG": path, "CLICKUP_API_KEY": "pk_env"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (config{Token: "pk_env", Workspace: "111"}); cfg != want {
+		t.Errorf("loadConfig() with environment = %+v, want %+v", cfg, want)
+	}
+
+	if _, err := loadConfig(filepath.Join(t.TempDir(), "missing.json"), env(t, nil)); err == nil {
+		t.Error("loadConfig() with a missing explicit file should fail")
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	name := fs.String("name", "", "")
+	custom := fs.Bool("custom-id", false, "")
+
+	got, err := parseArgs(fs, []string{"a", "-name", "x", "b", "-custom-id", "--", "-c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "-c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseArgs() = %v, want %v", got, want)
+	}
+	if *name != "x" || !*custom {
+		t.Errorf("flags = %q, %v, want x, true", *name, *custom)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Guitarbum722/clickup-client-go"
+	"github.com/Guitarbum722/clickup-client-go/format"
+)
+
+// output is how results are written to stdout.  The zero value writes the API response as JSON.
+type output struct {
+	format   format.Format
+	template string
+	columns  []string
+}
+
+func (o output) flattened() bool {
+	return o.format != "" || o.template != "" || len(o.columns) > 0
+}
+
+// print writes v to stdout.  Tasks, lists, folders, spaces, goals and webhooks are flattened when an
+// output format, template or columns were chosen, anything else is written as indented JSON.
+func (a *app) print(v interface{}) error {
+	if !a.output.flattened() {
+		return a.printJSON(v)
+	}
+
+	table, ok := tableFor(v)
+	if !ok {
+		if a.output.format == format.JSON && a.output.template == "" && len(a.output.columns) == 0 {
+			return a.printJSON(v)
+		}
+		return fmt.Errorf("this command only supports JSON output")
+	}
+	table, err := table.Select(a.output.columns...)
+	if err != nil {
+		return err
+	}
+	if a.output.template != "" {
+		return table.WriteTemplate(a.stdout, a.output.template)
+	}
+	f := a.output.format
+	if f == "" {
+		f = format.Text
+	}
+	return table.Write(a.stdout, f)
+}
+
+func (a *app) printJSON(v interface{}) error {
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// tableFor flattens the results of the commands that have a format converter.
+func tableFor(v interface{}) (format.Table, bool) {
+	switch v := v.(type) {
+	case []clickup.SingleTask:
+		return format.Tasks(v, nil), true
+	case *clickup.SingleTask:
+		return format.Tasks([]clickup.SingleTask{*v}, nil), true
+	case *clickup.TasksForViewResponse:
+		return format.Tasks(v.Tasks, nil), true
+	case []clickup.SingleList:
+		return format.Lists(v, nil), true
+	case *clickup.SingleList:
+		return format.Lists([]clickup.SingleList{*v}, nil), true
+	case []clickup.SingleFolder:
+		return format.Folders(v, nil), true
+	case *clickup.SingleFolder:
+		return format.Folders([]clickup.SingleFolder{*v}, nil), true
+	case []clickup.SingleSpace:
+		return format.Spaces(v, nil), true
+	case *clickup.SingleSpace:
+		return format.Spaces([]clickup.SingleSpace{*v}, nil), true
+	case *clickup.GetGoalsResponse:
+		return format.Goals(v.Goals, nil), true
+	case *clickup.GoalResponse:
+		return format.Goals([]clickup.GoalResponse{*v}, nil), true
+	case []clickup.Webhook:
+		return format.Webhooks(v, nil), true
+	case *clickup.CreateWebhookResponse:
+		return format.Webhooks([]clickup.Webhook{clickup.Webhook(v.Webhook)}, nil), true
+	case *clickup.UpdateWebhookResponse:
+		return format.Webhooks([]clickup.Webhook{clickup.Webhook(v.Webhook)}, nil), true
+	}
+	return format.Table{}, false
+}
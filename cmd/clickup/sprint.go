@@ -0,0 +1,65 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go/sprint"
+)
+
+func reportSprint(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("sprint")
+	start := fs.String("start", "", "first day of the sprint")
+	end := fs.String("end", "", "last day of the sprint")
+	unit := fs.String("unit", "tasks", "measure scope in tasks, points or hours")
+	chart := fs.String("svg", "", "write a burndown or burnup chart as SVG instead of JSON")
+	tz := fs.String("tz", "UTC", "time zone in which days start")
+	subtasks := fs.Bool("subtasks", false, "include subtasks")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := required(fs, "start", "end"); err != nil {
+		return err
+	}
+	if c := sprint.Chart(*chart); c != "" && c != sprint.Burndown && c != sprint.Burnup {
+		return fmt.Errorf("unknown -svg chart %q: %w", *chart, errUsage)
+	}
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		return fmt.Errorf("-tz: %v: %w", err, errUsage)
+	}
+	opts := &sprint.Options{Unit: sprint.Unit(*unit), Location: loc, IncludeSubtasks: *subtasks}
+	if opts.Start, err = sprintDay(*start, loc); err != nil {
+		return fmt.Errorf("-start: %v: %w", err, errUsage)
+	}
+	if opts.End, err = sprintDay(*end, loc); err != nil {
+		return fmt.Errorf("-end: %v: %w", err, errUsage)
+	}
+
+	report, err := sprint.ForList(ctx, a.client, positional[0], opts)
+	if err != nil {
+		return err
+	}
+	if *chart != "" {
+		return report.WriteSVG(a.stdout, sprint.Chart(*chart))
+	}
+	return a.print(report)
+}
+
+// sprintDay parses a date flag as a day in loc rather than in the local time zone.
+func sprintDay(s string, loc *time.Location) (time.Time, error) {
+	t, err := parseDate(s)
+	if err != nil {
+		return t, err
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc), nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Guitarbum722/clickup-client-go/tasksync"
+)
+
+func syncTasks(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("sync")
+	var lists stringList
+	fs.Var(&lists, "list", "list id (repeatable)")
+	state := fs.String("state", "", "file that holds the synced tasks and watermarks between runs")
+	reconcile := fs.Bool("reconcile", false, "read every task to find deleted tasks")
+	reconcileEvery := fs.Duration("reconcile-every", 0, "read every task of a list this often to find deleted tasks")
+	subtasks := fs.Bool("subtasks", false, "include subtasks")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "list", "state"); err != nil {
+		return err
+	}
+
+	store, err := readSyncState(*state)
+	if err != nil {
+		return err
+	}
+	syncer := &tasksync.Syncer{
+		Client:          a.client,
+		Store:           store,
+		ListIDs:         lists,
+		IncludeSubtasks: *subtasks,
+		ReconcileEvery:  *reconcileEvery,
+	}
+	sync := syncer.Sync
+	if *reconcile {
+		sync = syncer.Reconcile
+	}
+	result, err := sync(ctx)
+	// The changes stored before a failure are saved and printed, so they are not reported again.
+	if serr := writeSyncState(*state, store); serr != nil && err == nil {
+		err = serr
+	}
+	if perr := a.print(result.Changes); perr != nil && err == nil {
+		err = perr
+	}
+	return err
+}
+
+// readSyncState reads the store saved at path, or returns an empty store if there is no file yet.
+func readSyncState(path string) (*tasksync.MemoryStore, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return &tasksync.MemoryStore{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	store, err := tasksync.ReadMemoryStore(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return store, nil
+}
+
+// writeSyncState saves store to path through a temporary file, so a failed write keeps the old state.
+func writeSyncState(path string, store *tasksync.MemoryStore) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := store.WriteJSON(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func tasksResource() resource {
+	return resource{
+		name:    "tasks",
+		summary: "tasks in a list",
+		commands: []command{
+			{
+				name: "list",
+				usage: "list -list list-id [-all | -page n] [-status status]... [-closed] [-archived] [-subtasks] " +
+					"[-order-by id|created|updated|due_date] [-reverse] [-due-after date] [-due-before date] " +
+					"[-created-after date] [-created-before date] [-updated-after date] [-updated-before date]",
+				run: listTasks,
+			},
+			{name: "get", usage: "get <task-id> [-custom-id] [-subtasks]", run: getTask},
+			{
+				name:  "create",
+				usage: "create -list list-id -name name [-description text] [-status status] [-tag tag]... [-due date] [-start date]",
+				run:   createTask,
+			},
+			{
+				name: "update",
+				usage: "update <task-id> [-custom-id] [-name name] [-description text] [-status status] " +
+					"[-priority urgent|high|normal|low|none] [-due date|none] [-start date|none] [-archived]",
+				run: updateTask,
+			},
+			{name: "delete", usage: "delete <task-id> [-custom-id]", run: deleteTask},
+			{
+				name: "export",
+				usage: "export (-list list-id | -view view-id | -search [-space id]... [-folder id]... [-list id]...) " +
+					"[-status status]... [-closed] [-archived] [-subtasks] [-time-format layout] [-tz zone]",
+				run: exportTasks,
+			},
+			{
+				name: "import",
+				usage: "import -list list-id [-dry-run] [-progress file] [-map header=column]... " +
+					"[-time-format layout] [-tz zone] <file.csv | ->",
+				run: importTasks,
+			},
+			{
+				name:  "sync",
+				usage: "sync -list list-id... -state file [-reconcile] [-reconcile-every duration] [-subtasks]",
+				run:   syncTasks,
+			},
+		},
+	}
+}
+
+func listTasks(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("list")
+	list := fs.String("list", "", "list id")
+	all := fs.Bool("all", false, "fetch every page")
+	page := fs.Int("page", 0, "page to fetch")
+	var statuses stringList
+	fs.Var(&statuses, "status", "only tasks with this status (repeatable)")
+	closed := fs.Bool("closed", false, "include closed tasks")
+	archived := fs.Bool("archived", false, "include archived tasks")
+	subtasks := fs.Bool("subtasks", false, "include subtasks")
+	orderBy := fs.String("order-by", "", "order by id, created, updated or due_date")
+	reverse := fs.Bool("reverse", false, "reverse the order")
+	dueAfter := fs.String("due-after", "", "only tasks due after date")
+	dueBefore := fs.String("due-before", "", "only tasks due before date")
+	createdAfter := fs.String("created-after", "", "only tasks created after date")
+	createdBefore := fs.String("created-before", "", "only tasks created before date")
+	updatedAfter := fs.String("updated-after", "", "only tasks updated after date")
+	updatedBefore := fs.String("updated-before", "", "only tasks updated before date")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "list"); err != nil {
+		return err
+	}
+
+	opts := &clickup.TaskQueryOptions{
+		Page:            *page,
+		OrderBy:         clickup.OrderByVal(*orderBy),
+		Reverse:         *reverse,
+		IncludeSubtasks: *subtasks,
+		Statuses:        statuses,
+		IncludeClosed:   *closed,
+		IncludeArchived: *archived,
+	}
+	for _, date := range []struct {
+		name  string
+		value string
+		dst   *time.Time
+	}{
+		{"due-after", *dueAfter, &opts.DueDateGreaterThan},
+		{"due-before", *dueBefore, &opts.DueDateLessThan},
+		{"created-after", *createdAfter, &opts.DateCreatedGreaterThan},
+		{"created-before", *createdBefore, &opts.DateCreatedLessThan},
+		{"updated-after", *updatedAfter, &opts.DateUpdatedGreaterThan},
+		{"updated-before", *updatedBefore, &opts.DateUpdatedLessThan},
+	} {
+		t, err := parseDate(date.value)
+		if err != nil {
+			return fmt.Errorf("-%s: %w", date.name, err)
+		}
+		*date.dst = t
+	}
+
+	if *all {
+		tasks, err := a.client.AllTasksForList(ctx, *list, opts)
+		if err != nil {
+			return err
+		}
+		return a.print(tasks)
+	}
+	tasks, err := a.client.TasksForList(ctx, *list, opts)
+	if err != nil {
+		return err
+	}
+	return a.print(tasks.Tasks)
+}
+
+func getTask(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("get")
+	customID := fs.Bool("custom-id", false, "the task id is a custom task id")
+	subtasks := fs.Bool("subtasks", false, "include subtasks")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	workspace, err := a.taskWorkspace(*customID)
+	if err != nil {
+		return err
+	}
+	task, err := a.client.TaskByID(ctx, positional[0], workspace, *customID, *subtasks)
+	if err != nil {
+		return err
+	}
+	return a.print(task)
+}
+
+func createTask(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("create")
+	list := fs.String("list", "", "list id")
+	name := fs.String("name", "", "task name")
+	description := fs.String("description", "", "task description")
+	status := fs.String("status", "", "task status")
+	var tags stringList
+	fs.Var(&tags, "tag", "tag name (repeatable)")
+	due := fs.String("due", "", "due date")
+	start := fs.String("start", "", "start date")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "list", "name"); err != nil {
+		return err
+	}
+
+	request := clickup.TaskRequest{
+		Name:        *name,
+		Description: *description,
+		Status:      *status,
+		Tags:        tags,
+	}
+	var err error
+	if request.DueDate, err = timestampPtr(*due); err != nil {
+		return fmt.Errorf("-due: %w", err)
+	}
+	if request.StartDate, err = timestampPtr(*start); err != nil {
+		return fmt.Errorf("-start: %w", err)
+	}
+
+	task, err := a.client.CreateTask(ctx, *list, request)
+	if err != nil {
+		return err
+	}
+	return a.print(task)
+}
+
+func updateTask(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("update")
+	customID := fs.Bool("custom-id", false, "the task id is a custom task id")
+	name := fs.String("name", "", "task name")
+	description := fs.String("description", "", "task description")
+	status := fs.String("status", "", "task status")
+	priority := fs.String("priority", "", "urgent, high, normal, low or none")
+	due := fs.String("due", "", "due date, or none to clear it")
+	start := fs.String("start", "", "start date, or none to clear it")
+	archived := fs.Bool("archived", false, "archive the task")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	workspace, err := a.taskWorkspace(*customID)
+	if err != nil {
+		return err
+	}
+
+	request := &clickup.TaskUpdateRequest{ID: positional[0]}
+	set := setFlags(fs)
+	if set["name"] {
+		request.Name = clickup.OptString(*name)
+	}
+	if set["description"] {
+		request.Description = clickup.OptString(*description)
+	}
+	if set["status"] {
+		request.Status = clickup.OptString(*status)
+	}
+	if set["priority"] {
+		if request.Priority, err = parsePriority(*priority); err != nil {
+			return err
+		}
+	}
+	if set["due"] {
+		if request.DueDate, err = optDate(*due); err != nil {
+			return fmt.Errorf("-due: %w", err)
+		}
+	}
+	if set["start"] {
+		if request.StartDate, err = optDate(*start); err != nil {
+			return fmt.Errorf("-start: %w", err)
+		}
+	}
+	if set["archived"] {
+		request.Archived = clickup.OptBool(*archived)
+	}
+
+	task, err := a.client.UpdateTask(ctx, request, workspace, *customID)
+	if err != nil {
+		return err
+	}
+	return a.print(task)
+}
+
+var priorities = map[string]int{"urgent": 1, "high": 2, "normal": 3, "low": 4}
+
+func parsePriority(s string) (clickup.OptionalInt, error) {
+	if s == "none" {
+		return clickup.NullInt(), nil
+	}
+	if p, ok := priorities[strings.ToLower(s)]; ok {
+		return clickup.OptInt(p), nil
+	}
+	if p, err := strconv.Atoi(s); err == nil && p >= 1 && p <= 4 {
+		return clickup.OptInt(p), nil
+	}
+	return clickup.OptionalInt{}, fmt.Errorf("invalid priority %q: use urgent, high, normal, low or none", s)
+}
+
+func deleteTask(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("delete")
+	customID := fs.Bool("custom-id", false, "the task id is a custom task id")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	workspace, err := a.taskWorkspace(*customID)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteTask(ctx, positional[0], workspace, *customID)
+}
+
+func timeInStatusResource() resource {
+	return resource{
+		name:    "time-in-status",
+		summary: "how long tasks spent in each status, and cycle and lead time reports",
+		commands: []command{
+			{name: "get", usage: "get <task-id>... [-custom-id]", run: getTimeInStatus},
+			{
+				name: "report",
+				usage: "report (-list list-id [-closed=false] | <task-id>... [-custom-id]) [-stage name=status,type:custom]... " +
+					"[-start stage] [-done stage] [-since date] [-tz zone] [-csv tasks|summary|throughput|flow]",
+				run: reportTimeInStatus,
+			},
+		},
+	}
+}
+
+func getTimeInStatus(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("get")
+	customID := fs.Bool("custom-id", false, "the task ids are custom task ids")
+	taskIDs, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(taskIDs) == 0 {
+		return fmt.Errorf("expected at least one task id: %w", errUsage)
+	}
+	workspace, err := a.taskWorkspace(*customID)
+	if err != nil {
+		return err
+	}
+
+	if len(taskIDs) == 1 {
+		status, err := a.client.TaskTimeInStatus(ctx, taskIDs[0], workspace, *customID)
+		if err != nil {
+			return err
+		}
+		return a.print(status)
+	}
+	statuses, err := a.client.AllTaskTimeInStatus(ctx, taskIDs, &clickup.TimeInStatusOptions{
+		WorkspaceID:      workspace,
+		UseCustomTaskIDs: *customID,
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(statuses)
+}
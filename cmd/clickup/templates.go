@@ -0,0 +1,63 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func templatesResource() resource {
+	return resource{
+		name:    "templates",
+		summary: "task templates in the workspace",
+		commands: []command{
+			{name: "list", usage: "list [-page n]", run: listTemplates},
+			{name: "create", usage: "create -template template-id -list list-id -name name", run: createFromTemplate},
+		},
+	}
+}
+
+func listTemplates(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("list")
+	page := fs.Int("page", 0, "page to fetch")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	workspace, err := a.requireWorkspace()
+	if err != nil {
+		return err
+	}
+	templates, err := a.client.TemplatesForWorkspace(ctx, workspace, *page)
+	if err != nil {
+		return err
+	}
+	return a.print(templates.Templates)
+}
+
+func createFromTemplate(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("create")
+	template := fs.String("template", "", "template id")
+	list := fs.String("list", "", "list id to create the task in")
+	name := fs.String("name", "", "task name")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "template", "list", "name"); err != nil {
+		return err
+	}
+	task, err := a.client.CreateTaskFromTemplate(ctx, clickup.TaskFromTemplateRequest{
+		ListID:     *list,
+		TemplateID: *template,
+		Name:       *name,
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(task)
+}
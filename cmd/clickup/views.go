@@ -0,0 +1,162 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func viewsResource() resource {
+	return resource{
+		name:    "views",
+		summary: "views of the workspace, a space, a folder or a list",
+		commands: []command{
+			{name: "list", usage: "list [-space space-id | -folder folder-id | -list list-id]", run: listViews},
+			{name: "get", usage: "get <view-id>", run: getView},
+			{name: "create", usage: "create [-space space-id | -folder folder-id | -list list-id] -name name -type type", run: createView},
+			{name: "update", usage: "update <view-id> [-name name]", run: updateView},
+			{name: "delete", usage: "delete <view-id>", run: deleteView},
+			{name: "tasks", usage: "tasks <view-id> [-page n]", run: viewTasks},
+		},
+	}
+}
+
+// viewParent is the -space, -folder or -list a view command applies to.  Without one, the view belongs
+// to the workspace.
+type viewParent struct {
+	space, folder, list string
+}
+
+func (p *viewParent) register(fs *flag.FlagSet) {
+	fs.StringVar(&p.space, "space", "", "space id")
+	fs.StringVar(&p.folder, "folder", "", "folder id")
+	fs.StringVar(&p.list, "list", "", "list id")
+}
+
+func (p *viewParent) resolve(a *app) (clickup.ViewListType, string, error) {
+	var parents []string
+	typ, id := clickup.TypeTeam, ""
+	for _, parent := range []struct {
+		typ clickup.ViewListType
+		id  string
+	}{{clickup.TypeSpace, p.space}, {clickup.TypeFolder, p.folder}, {clickup.TypeList, p.list}} {
+		if parent.id != "" {
+			parents = append(parents, parent.id)
+			typ, id = parent.typ, parent.id
+		}
+	}
+	if len(parents) > 1 {
+		return 0, "", fmt.Errorf("only one of -space, -folder or -list can be used: %w", errUsage)
+	}
+	if id == "" {
+		workspace, err := a.requireWorkspace()
+		return clickup.TypeTeam, workspace, err
+	}
+	return typ, id, nil
+}
+
+func listViews(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("list")
+	var parent viewParent
+	parent.register(fs)
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	typ, id, err := parent.resolve(a)
+	if err != nil {
+		return err
+	}
+	views, err := a.client.ViewsFor(ctx, typ, id)
+	if err != nil {
+		return err
+	}
+	return a.print(views.Views)
+}
+
+func getView(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	view, err := a.client.ViewByID(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return a.print(view)
+}
+
+func createView(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("create")
+	var parent viewParent
+	parent.register(fs)
+	name := fs.String("name", "", "view name")
+	viewType := fs.String("type", "", "list, board, calendar, gantt, table, timeline, workload, activity, map or conversation")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "name", "type"); err != nil {
+		return err
+	}
+	typ, id, err := parent.resolve(a)
+	if err != nil {
+		return err
+	}
+	view, err := a.client.CreateView(ctx, clickup.CreateViewRequest{
+		ParentType: typ,
+		ParentID:   id,
+		Name:       *name,
+		Type:       clickup.ViewType(*viewType),
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(view)
+}
+
+func updateView(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("update")
+	name := fs.String("name", "", "view name")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	request := clickup.UpdateViewRequest{ID: positional[0]}
+	if setFlags(fs)["name"] {
+		request.Name = clickup.OptString(*name)
+	}
+	view, err := a.client.UpdateView(ctx, request)
+	if err != nil {
+		return err
+	}
+	return a.print(view)
+}
+
+func deleteView(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("delete"), args, 1)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteView(ctx, positional[0])
+}
+
+func viewTasks(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("tasks")
+	page := fs.Int("page", 0, "page to fetch")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	tasks, err := a.client.TasksForView(ctx, positional[0], *page)
+	if err != nil {
+		return err
+	}
+	return a.print(tasks)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func webhooksResource() resource {
+	return resource{
+		name:    "webhooks",
+		summary: "webhooks in the workspace, or the same events by polling",
+		commands: []command{
+			{name: "list", usage: "list", run: listWebhooks},
+			{
+				name:  "create",
+				usage: "create -endpoint url [-event event]... [-task task-id | -list list-id | -folder folder-id]",
+				run:   createWebhook,
+			},
+			{name: "update", usage: "update <webhook-id> [-endpoint url] [-event event]... [-status active|inactive]", run: updateWebhook},
+			{name: "delete", usage: "delete <webhook-id>", run: deleteWebhook},
+			{
+				name: "poll",
+				usage: "poll (-list list-id | -space space-id | -view view-id)... [-event event]... " +
+					"[-interval duration] [-closed] [-subtasks]",
+				run: pollWebhooks,
+			},
+		},
+	}
+}
+
+func listWebhooks(ctx context.Context, a *app, args []string) error {
+	if _, err := exactArgs(a.flagSet("list"), args, 0); err != nil {
+		return err
+	}
+	workspace, err := a.requireWorkspace()
+	if err != nil {
+		return err
+	}
+	webhooks, err := a.client.WebhooksFor(ctx, workspace)
+	if err != nil {
+		return err
+	}
+	return a.print(webhooks.Webhooks)
+}
+
+func webhookEvents(events stringList) []clickup.WebhookEvent {
+	if len(events) == 0 {
+		return nil
+	}
+	converted := make([]clickup.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		converted = append(converted, clickup.WebhookEvent(event))
+	}
+	return converted
+}
+
+func createWebhook(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("create")
+	endpoint := fs.String("endpoint", "", "url to deliver events to")
+	var events stringList
+	fs.Var(&events, "event", "event to subscribe to, such as taskCreated (repeatable, default all)")
+	task := fs.String("task", "", "only events for this task id")
+	list := fs.String("list", "", "only events for this list id")
+	folder := fs.String("folder", "", "only events for this folder id")
+	if _, err := exactArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(fs, "endpoint"); err != nil {
+		return err
+	}
+	workspace, err := a.requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	request := &clickup.CreateWebhookRequest{
+		Endpoint: *endpoint,
+		Events:   webhookEvents(events),
+		TaskID:   *task,
+		ListID:   *list,
+		FolderID: *folder,
+	}
+	if len(request.Events) == 0 {
+		request.Events = []clickup.WebhookEvent{clickup.EventAll}
+	}
+
+	webhook, err := a.client.CreateWebhook(ctx, workspace, request)
+	if err != nil {
+		return err
+	}
+	return a.print(webhook)
+}
+
+func updateWebhook(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("update")
+	endpoint := fs.String("endpoint", "", "url to deliver events to")
+	var events stringList
+	fs.Var(&events, "event", "event to subscribe to (repeatable, replaces the current events)")
+	status := fs.String("status", "", "active or inactive")
+	positional, err := exactArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	request := &clickup.UpdateWebhookRequest{ID: positional[0], Events: webhookEvents(events)}
+	set := setFlags(fs)
+	if set["endpoint"] {
+		request.Endpoint = clickup.OptString(*endpoint)
+	}
+	if set["status"] {
+		request.Status = clickup.OptString(*status)
+	}
+
+	webhook, err := a.client.UpdateWebhook(ctx, request)
+	if err != nil {
+		return err
+	}
+	return a.print(webhook)
+}
+
+func deleteWebhook(ctx context.Context, a *app, args []string) error {
+	positional, err := exactArgs(a.flagSet("delete"), args, 1)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteWebhook(ctx, positional[0])
+}
@@ -24,23 +24,40 @@ type CodeBlock struct {
 	CodeBlock string `json:"code-block"`
 }
 
+// Attributes format a block of a comment.  Bold, Italic, Code, Strike, Underline, Link and Color
+// style the text of the block they are set on.  CodeBlock, List, Blockquote and Header format a whole
+// line and are set on the "\n" block that ends the line.
 type Attributes struct {
-	Bold      bool       `json:"bold"`
-	Italic    bool       `json:"italic"`
-	Code      bool       `json:"code"`
-	CodeBlock *CodeBlock `json:"code-block,omitempty"`
-	List      *List      `json:"list"`
+	Bold       bool       `json:"bold"`
+	Italic     bool       `json:"italic"`
+	Code       bool       `json:"code"`
+	Strike     bool       `json:"strike,omitempty"`
+	Underline  bool       `json:"underline,omitempty"`
+	Color      string     `json:"color,omitempty"` // such as #7b68ee
+	CodeBlock  *CodeBlock `json:"code-block,omitempty"`
+	List       *List      `json:"list"`
+	Link       string     `json:"link,omitempty"`
+	Blockquote bool       `json:"blockquote,omitempty"`
+	Header     int        `json:"header,omitempty"` // heading level, 1 through 3
 }
 
 type Emoticon struct {
 	Code string `json:"code"`
 }
 
+// CommentMention is the user tagged by a comment block of type "tag".
+type CommentMention struct {
+	ID       int    `json:"id"`
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
+}
+
 type ComplexComment struct {
-	Text       string      `json:"text"`
-	Type       string      `json:"type,omitempty"`
-	Attributes *Attributes `json:"attributes,omitempty"`
-	Emoticon   *Emoticon   `json:"emoticon,omitempty"`
+	Text       string          `json:"text"`
+	Type       string          `json:"type,omitempty"`
+	Attributes *Attributes     `json:"attributes,omitempty"`
+	Emoticon   *Emoticon       `json:"emoticon,omitempty"`
+	User       *CommentMention `json:"user,omitempty"`
 }
 
 type CreateCommentRequest struct {
@@ -137,9 +154,9 @@ func NewCreateTaskCommentRequest(taskID string, useCustomTaskIDs bool, workspace
 }
 
 type CreateCommentResponse struct {
-	ID        int    `json:"id"`
-	HistoryID string `json:"hist_id"`
-	Date      int    `json:"date"`
+	ID        int       `json:"id"`
+	HistoryID string    `json:"hist_id"`
+	Date      Timestamp `json:"date"`
 }
 
 type CreateTaskCommentResponse struct {
@@ -265,11 +282,11 @@ type CommentsResponse struct {
 		Assignee    *TeamUser        `json:"assignee"`
 		AssignedBy  *TeamUser        `json:"assigned_by"`
 		Reactions   []struct {
-			Reaction string   `json:"reaction"`
-			Date     string   `json:"date"`
-			User     TeamUser `json:"user"`
+			Reaction string    `json:"reaction"`
+			Date     Timestamp `json:"date"`
+			User     TeamUser  `json:"user"`
 		} `json:"reactions"`
-		Date string `json:"date"`
+		Date Timestamp `json:"date"`
 	} `json:"comments"`
 }
 
@@ -285,33 +302,73 @@ type CommentsForTaskQuery struct {
 	CommentsQuery
 }
 
+// TaskComments returns the comments on the task specified in query.
 func (c *Client) TaskComments(ctx context.Context, query CommentsForTaskQuery) (CommentsResponse, error) {
-	panic("TODO")
+	if query.TaskID == "" {
+		return CommentsResponse{}, fmt.Errorf("must provide a task id to query task comments: %w", ErrValidation)
+	}
+	if query.UseCustomTaskIDs && query.WorkspaceID == "" {
+		return CommentsResponse{}, fmt.Errorf("must provide a workspace id if querying by custom task id: %w", ErrValidation)
+	}
+
+	urlValues := url.Values{}
+	urlValues.Set("custom_task_ids", strconv.FormatBool(query.UseCustomTaskIDs))
+	urlValues.Add("team_id", query.WorkspaceID)
+
+	endpoint := fmt.Sprintf("/task/%s/comment/?%s", query.TaskID, urlValues.Encode())
+
+	return c.comments(ctx, endpoint)
 }
 
 type CommentsForTaskViewQuery struct {
 	CommentsQuery
 }
 
+// ChatViewComments returns the comments on the chat view specified in query.
 func (c *Client) ChatViewComments(ctx context.Context, query CommentsForTaskViewQuery) (CommentsResponse, error) {
-	panic("TODO")
+	if query.ViewID == "" {
+		return CommentsResponse{}, fmt.Errorf("must provide a view id to query view comments: %w", ErrValidation)
+	}
+
+	return c.comments(ctx, fmt.Sprintf("/view/%s/comment", query.ViewID))
 }
 
 type CommentsForListQuery struct {
 	CommentsQuery
 }
 
+// ListComments returns the comments on the list specified in query.
 func (c *Client) ListComments(ctx context.Context, query CommentsForListQuery) (CommentsResponse, error) {
-	panic("TODO")
+	if query.ListID == "" {
+		return CommentsResponse{}, fmt.Errorf("must provide a list id to query list comments: %w", ErrValidation)
+	}
+
+	return c.comments(ctx, fmt.Sprintf("/list/%s/comment", query.ListID))
+}
+
+func (c *Client) comments(ctx context.Context, endpoint string) (CommentsResponse, error) {
+	var comments CommentsResponse
+
+	if err := c.call(ctx, http.MethodGet, endpoint, nil, &comments); err != nil {
+		return CommentsResponse{}, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return comments, nil
 }
 
+// UpdateCommentRequest uses patch semantics.  Only explicitly set fields are sent to Clickup,
+// so a comment can be un-resolved with OptBool(false).
 type UpdateCommentRequest struct {
 	CommentID   string           `json:"-"`
-	CommentText string           `json:"comment_text"` // plain text
-	Comment     []ComplexComment `json:"comment,omitempty"`
-	Assignee    int              `json:"assignee,omitempty"`
-	NotifyAll   bool             `json:"notify_all,omitempty"`
-	Resolved    bool             `json:"resolved,omitempty"`
+	CommentText OptionalString   `json:"comment_text"` // plain text
+	Comment     []ComplexComment `json:"comment"`
+	Assignee    OptionalInt      `json:"assignee"`
+	NotifyAll   OptionalBool     `json:"notify_all"`
+	Resolved    OptionalBool     `json:"resolved"`
+}
+
+func (u UpdateCommentRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
 }
 
 // UpdateComment changes an existing comment based on comment.CommentID.
@@ -0,0 +1,208 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import "strings"
+
+const maxHeaderLevel = 3
+
+// CommentBuilder builds the blocks of a rich comment one piece at a time.  The result of Blocks can
+// be used as the Comment of a CreateTaskCommentRequest, CreateListCommentRequest,
+// CreateChatViewCommentRequest or UpdateCommentRequest.
+//
+//	comment := clickup.NewComment().
+//		Text("Deployed ").Bold("v2.1").Text(" cc ").Mention(userID).Line().
+//		BulletItem("faster sync").
+//		CodeBlock("sh", "make deploy")
+//	req := clickup.NewCreateTaskCommentRequest(taskID, false, "")
+//	req.Comment = comment.Blocks()
+type CommentBuilder struct {
+	blocks []ComplexComment
+}
+
+// NewComment starts an empty comment.
+func NewComment() *CommentBuilder {
+	return &CommentBuilder{}
+}
+
+// Blocks returns the blocks built so far.
+func (b *CommentBuilder) Blocks() []ComplexComment {
+	blocks := make([]ComplexComment, len(b.blocks))
+	copy(blocks, b.blocks)
+	return blocks
+}
+
+// Styled appends text formatted with the text level fields of attributes.  Line level fields such
+// as List are ignored; use the line methods like BulletItem instead.
+func (b *CommentBuilder) Styled(text string, attributes Attributes) *CommentBuilder {
+	if text == "" {
+		return b
+	}
+	attributes.CodeBlock = nil
+	attributes.List = nil
+	attributes.Blockquote = false
+	attributes.Header = 0
+
+	var attrs *Attributes
+	if attributes != (Attributes{}) {
+		attrs = &attributes
+	}
+	b.blocks = append(b.blocks, ComplexComment{Text: text, Attributes: attrs})
+	return b
+}
+
+// Text appends unformatted text.  Newlines in text end the current line.
+func (b *CommentBuilder) Text(text string) *CommentBuilder {
+	return b.Styled(text, Attributes{})
+}
+
+// Bold appends bold text.
+func (b *CommentBuilder) Bold(text string) *CommentBuilder {
+	return b.Styled(text, Attributes{Bold: true})
+}
+
+// Italic appends italic text.
+func (b *CommentBuilder) Italic(text string) *CommentBuilder {
+	return b.Styled(text, Attributes{Italic: true})
+}
+
+// Strike appends struck through text.
+func (b *CommentBuilder) Strike(text string) *CommentBuilder {
+	return b.Styled(text, Attributes{Strike: true})
+}
+
+// Underline appends underlined text.
+func (b *CommentBuilder) Underline(text string) *CommentBuilder {
+	return b.Styled(text, Attributes{Underline: true})
+}
+
+// Code appends inline code.
+func (b *CommentBuilder) Code(text string) *CommentBuilder {
+	return b.Styled(text, Attributes{Code: true})
+}
+
+// Color appends text in color, such as "#7b68ee".
+func (b *CommentBuilder) Color(text, color string) *CommentBuilder {
+	return b.Styled(text, Attributes{Color: color})
+}
+
+// Link appends text linking to url.  The url itself is used as the text if text is empty.
+func (b *CommentBuilder) Link(text, url string) *CommentBuilder {
+	if text == "" {
+		text = url
+	}
+	return b.Styled(text, Attributes{Link: url})
+}
+
+// Mention appends a tag of the user with userID, which notifies them.
+func (b *CommentBuilder) Mention(userID int) *CommentBuilder {
+	b.blocks = append(b.blocks, ComplexComment{
+		Type: commentTypeMention,
+		User: &CommentMention{ID: userID},
+	})
+	return b
+}
+
+// Emoticon appends an emoticon given either its shortcode, such as "tada", or its code point in
+// hex, such as "1f389".  Unknown shortcodes are appended as :shortcode: text.
+func (b *CommentBuilder) Emoticon(emoticon string) *CommentBuilder {
+	code := strings.Trim(emoticon, ":")
+	if known, ok := emoticonCodes[code]; ok {
+		code = known
+	}
+	text := emoticonText(code)
+	if text == "" {
+		return b.Text(":" + strings.Trim(emoticon, ":") + ":")
+	}
+	b.blocks = append(b.blocks, ComplexComment{
+		Type:     commentTypeEmoticon,
+		Text:     text,
+		Emoticon: &Emoticon{Code: code},
+	})
+	return b
+}
+
+// Markdown appends markdown converted with MarkdownToComment.
+func (b *CommentBuilder) Markdown(markdown string, opts *MarkdownOptions) *CommentBuilder {
+	b.endLine()
+	b.blocks = append(b.blocks, MarkdownToComment(markdown, opts)...)
+	return b
+}
+
+// Line ends the current line.
+func (b *CommentBuilder) Line() *CommentBuilder {
+	return b.Text("\n")
+}
+
+// BulletItem appends text as an item of a bulleted list.
+func (b *CommentBuilder) BulletItem(text string) *CommentBuilder {
+	return b.line(text, Attributes{List: &List{List: "bullet"}})
+}
+
+// NumberedItem appends text as an item of a numbered list.
+func (b *CommentBuilder) NumberedItem(text string) *CommentBuilder {
+	return b.line(text, Attributes{List: &List{List: "ordered"}})
+}
+
+// ChecklistItem appends text as a checklist item, checked or not.
+func (b *CommentBuilder) ChecklistItem(text string, checked bool) *CommentBuilder {
+	list := "unchecked"
+	if checked {
+		list = "checked"
+	}
+	return b.line(text, Attributes{List: &List{List: list}})
+}
+
+// Heading appends text as a heading.  level is clamped to 1 through 3.
+func (b *CommentBuilder) Heading(level int, text string) *CommentBuilder {
+	if level < 1 {
+		level = 1
+	}
+	if level > maxHeaderLevel {
+		level = maxHeaderLevel
+	}
+	return b.line(text, Attributes{Header: level})
+}
+
+// Blockquote appends each line of text as a quote.
+func (b *CommentBuilder) Blockquote(text string) *CommentBuilder {
+	for _, line := range strings.Split(text, "\n") {
+		b.line(line, Attributes{Blockquote: true})
+	}
+	return b
+}
+
+// CodeBlock appends source as a code block highlighted as language.  An empty language is plain text.
+func (b *CommentBuilder) CodeBlock(language, source string) *CommentBuilder {
+	if language == "" {
+		language = defaultCodeBlockLanguage
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(source, "\n"), "\n") {
+		b.line(line, Attributes{CodeBlock: &CodeBlock{CodeBlock: language}})
+	}
+	return b
+}
+
+// line appends text as a line of its own, formatted by the line attributes on its newline.
+func (b *CommentBuilder) line(text string, format Attributes) *CommentBuilder {
+	b.endLine()
+	b.Text(text)
+	b.blocks = append(b.blocks, ComplexComment{Text: "\n", Attributes: &format})
+	return b
+}
+
+// endLine ends the current line if any text has been added to it, so line formatting does not
+// apply to text that came before.
+func (b *CommentBuilder) endLine() {
+	if len(b.blocks) == 0 {
+		return
+	}
+	last := b.blocks[len(b.blocks)-1]
+	if last.Type != "" || !strings.HasSuffix(last.Text, "\n") {
+		b.Line()
+	}
+}
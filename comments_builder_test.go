@@ -0,0 +1,87 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommentBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *CommentBuilder
+		want    []ComplexComment
+	}{
+		{
+			name:    "Inline pieces",
+			builder: NewComment().Text("Hi ").Bold("there").Mention(7).Link("", "https://clickup.com").Emoticon(":tada:").Emoticon("1f44d").Emoticon("nope"),
+			want: []ComplexComment{
+				{Text: "Hi "},
+				{Text: "there", Attributes: &Attributes{Bold: true}},
+				{Type: commentTypeMention, User: &CommentMention{ID: 7}},
+				{Text: "https://clickup.com", Attributes: &Attributes{Link: "https://clickup.com"}},
+				{Type: commentTypeEmoticon, Text: "🎉", Emoticon: &Emoticon{Code: "1f389"}},
+				{Type: commentTypeEmoticon, Text: "👍", Emoticon: &Emoticon{Code: "1f44d"}},
+				{Text: ":nope:"},
+			},
+		},
+		{
+			name:    "Line items end the current line first",
+			builder: NewComment().Text("intro").BulletItem("one").ChecklistItem("done", true).Heading(9, "big"),
+			want: []ComplexComment{
+				{Text: "intro"},
+				{Text: "\n"},
+				{Text: "one"},
+				{Text: "\n", Attributes: &Attributes{List: &List{List: "bullet"}}},
+				{Text: "done"},
+				{Text: "\n", Attributes: &Attributes{List: &List{List: "checked"}}},
+				{Text: "big"},
+				{Text: "\n", Attributes: &Attributes{Header: 3}},
+			},
+		},
+		{
+			name:    "Code block lines",
+			builder: NewComment().CodeBlock("", "a\nb\n"),
+			want: []ComplexComment{
+				{Text: "a"},
+				{Text: "\n", Attributes: &Attributes{CodeBlock: &CodeBlock{CodeBlock: "plain"}}},
+				{Text: "b"},
+				{Text: "\n", Attributes: &Attributes{CodeBlock: &CodeBlock{CodeBlock: "plain"}}},
+			},
+		},
+		{
+			name:    "Styled drops line formats",
+			builder: NewComment().Styled("x", Attributes{Italic: true, Header: 1, List: &List{List: "bullet"}}),
+			want:    []ComplexComment{{Text: "x", Attributes: &Attributes{Italic: true}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.builder.Blocks(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommentBuilder.Blocks() = %s, want %s", mustMarshal(t, got), mustMarshal(t, tt.want))
+			}
+		})
+	}
+}
+
+func TestCommentBuilder_requests(t *testing.T) {
+	comment := NewComment().Bold("done").Blocks()
+
+	task := NewCreateTaskCommentRequest("task-id", false, "")
+	task.Comment = comment
+	list := NewCreateListCommentRequest("list-id")
+	list.Comment = comment
+	update := UpdateCommentRequest{CommentID: "1", Comment: comment}
+
+	want := `"comment":[{"text":"done","attributes":{"bold":true,"italic":false,"code":false,"list":null}}]`
+	for _, got := range []string{mustMarshal(t, task), mustMarshal(t, list), mustMarshal(t, update)} {
+		if got != "{"+want+"}" {
+			t.Errorf("marshaled request = %s, want {%s}", got, want)
+		}
+	}
+}
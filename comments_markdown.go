@@ -0,0 +1,486 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	commentTypeMention  = "tag"
+	commentTypeEmoticon = "emoticon"
+
+	defaultCodeBlockLanguage = "plain"
+)
+
+// emoticonCodes maps emoji shortcodes to the hex code points Clickup uses to identify an emoticon.
+var emoticonCodes = map[string]string{
+	"+1":                       "1f44d",
+	"-1":                       "1f44e",
+	"100":                      "1f4af",
+	"arrow_right":              "27a1",
+	"beers":                    "1f37b",
+	"boom":                     "1f4a5",
+	"bug":                      "1f41b",
+	"bulb":                     "1f4a1",
+	"calendar":                 "1f4c5",
+	"chart_with_upwards_trend": "1f4c8",
+	"clap":                     "1f44f",
+	"coffee":                   "2615",
+	"construction":             "1f6a7",
+	"cry":                      "1f622",
+	"exclamation":              "2757",
+	"eyes":                     "1f440",
+	"fire":                     "1f525",
+	"grinning":                 "1f600",
+	"hammer":                   "1f528",
+	"heart":                    "2764",
+	"heavy_check_mark":         "2714",
+	"joy":                      "1f602",
+	"laughing":                 "1f606",
+	"lock":                     "1f512",
+	"memo":                     "1f4dd",
+	"muscle":                   "1f4aa",
+	"ok_hand":                  "1f44c",
+	"package":                  "1f4e6",
+	"partying_face":            "1f973",
+	"pray":                     "1f64f",
+	"question":                 "2753",
+	"recycle":                  "267b",
+	"rocket":                   "1f680",
+	"slightly_smiling_face":    "1f642",
+	"smile":                    "1f604",
+	"sparkles":                 "2728",
+	"star":                     "2b50",
+	"tada":                     "1f389",
+	"thinking":                 "1f914",
+	"thumbsdown":               "1f44e",
+	"thumbsup":                 "1f44d",
+	"warning":                  "26a0",
+	"wave":                     "1f44b",
+	"white_check_mark":         "2705",
+	"wink":                     "1f609",
+	"wrench":                   "1f527",
+	"x":                        "274c",
+	"zap":                      "26a1",
+}
+
+// emoticonText returns the characters for an emoticon code such as "1f44d" or "1f1fa-1f1f8".
+func emoticonText(code string) string {
+	var b strings.Builder
+	for _, point := range strings.Split(code, "-") {
+		r, err := strconv.ParseUint(point, 16, 32)
+		if err != nil {
+			return ""
+		}
+		b.WriteRune(rune(r))
+	}
+	return b.String()
+}
+
+// MarkdownOptions configures MarkdownToComment.
+type MarkdownOptions struct {
+	// Mentions maps @usernames to the TeamUser ID that they tag.  A mention can
+	// always be written with an ID directly as <@123>.
+	Mentions map[string]int
+}
+
+// MarkdownToComment converts a subset of CommonMark into the blocks of a rich comment.
+// Supported are paragraphs, **bold**, *italic*, `inline code`, fenced code blocks, bullet,
+// numbered and checklist (- [ ] / - [x]) list items, [links](https://clickup.com), mentions
+// written as <@123> or as an @username found in opts.Mentions, and :emoticon: shortcodes.
+// Also supported are headings, > block quotes and ~~strikethrough~~.  Anything else is left as text.
+// opts may be nil.
+func MarkdownToComment(markdown string, opts *MarkdownOptions) []ComplexComment {
+	if opts == nil {
+		opts = &MarkdownOptions{}
+	}
+	p := &markdownParser{opts: opts}
+	p.parse(markdown)
+	return p.blocks
+}
+
+// AppendMarkdown converts markdown with MarkdownToComment and appends the result to c.
+func (c *CreateCommentRequest) AppendMarkdown(markdown string, opts *MarkdownOptions) {
+	c.Comment = append(c.Comment, MarkdownToComment(markdown, opts)...)
+}
+
+var (
+	markdownFence       = regexp.MustCompile("^(```+|~~~+)\\s*([^`\\s]*)")
+	markdownHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	markdownBullet      = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	markdownChecklist   = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	markdownOrdered     = regexp.MustCompile(`^\d{1,9}[.)]\s+(.*)$`)
+	markdownBreak       = regexp.MustCompile(`^([-*_])(\s*[-*_]){2,}$`)
+	markdownShortcode   = regexp.MustCompile(`^:([a-z0-9_+\-]+):`)
+	markdownMentionID   = regexp.MustCompile(`^<@(\d+)>`)
+	markdownMentionName = regexp.MustCompile(`^@([A-Za-z0-9_.\-]*[A-Za-z0-9_])`)
+)
+
+type markdownParser struct {
+	opts    *MarkdownOptions
+	blocks  []ComplexComment
+	started bool
+	blank   bool // a blank line has been seen since the last block
+}
+
+func (p *markdownParser) parse(markdown string) {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		p.startBlock()
+		p.inline(strings.Join(paragraph, " "), inlineStyle{})
+		p.newline(nil)
+		paragraph = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+
+		if m := markdownFence.FindStringSubmatch(line); m != nil {
+			flush()
+			p.startBlock()
+			language := m[2]
+			if language == "" {
+				language = defaultCodeBlockLanguage
+			}
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]); i++ {
+				if lines[i] != "" {
+					p.text(lines[i], nil)
+				}
+				p.newline(&Attributes{CodeBlock: &CodeBlock{CodeBlock: language}})
+			}
+			continue
+		}
+
+		switch {
+		case line == "":
+			flush()
+			p.blank = p.started
+		case markdownBreak.MatchString(line):
+			flush()
+			p.blank = p.started
+		case markdownHeading.MatchString(line):
+			flush()
+			p.startBlock()
+			m := markdownHeading.FindStringSubmatch(line)
+			level := len(m[1])
+			if level > maxHeaderLevel {
+				level = maxHeaderLevel
+			}
+			p.inline(m[2], inlineStyle{})
+			p.newline(&Attributes{Header: level})
+		case markdownBullet.MatchString(line):
+			flush()
+			p.startBlock()
+			item := markdownBullet.FindStringSubmatch(line)[1]
+			listType := "bullet"
+			if m := markdownChecklist.FindStringSubmatch(item); m != nil {
+				listType = "unchecked"
+				if m[1] != " " {
+					listType = "checked"
+				}
+				item = m[2]
+			}
+			p.inline(item, inlineStyle{})
+			p.newline(&Attributes{List: &List{List: listType}})
+		case markdownOrdered.MatchString(line):
+			flush()
+			p.startBlock()
+			p.inline(markdownOrdered.FindStringSubmatch(line)[1], inlineStyle{})
+			p.newline(&Attributes{List: &List{List: "ordered"}})
+		case strings.HasPrefix(line, ">"):
+			flush()
+			p.startBlock()
+			p.inline(strings.TrimSpace(strings.TrimPrefix(line, ">")), inlineStyle{})
+			p.newline(&Attributes{Blockquote: true})
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+}
+
+// startBlock separates a new block from the previous one with an empty line if
+// they were separated by blank lines in the markdown.
+func (p *markdownParser) startBlock() {
+	if p.blank {
+		p.newline(nil)
+	}
+	p.blank = false
+	p.started = true
+}
+
+func (p *markdownParser) newline(attributes *Attributes) {
+	p.text("\n", attributes)
+}
+
+// text appends a text block, merging it into the previous block when the two are styled the same.
+func (p *markdownParser) text(text string, attributes *Attributes) {
+	if n := len(p.blocks); n > 0 {
+		last := &p.blocks[n-1]
+		if last.Type == "" && mergeableAttributes(last.Attributes) && mergeableAttributes(attributes) &&
+			reflect.DeepEqual(last.Attributes, attributes) {
+			last.Text += text
+			return
+		}
+	}
+	p.blocks = append(p.blocks, ComplexComment{Text: text, Attributes: attributes})
+}
+
+func mergeableAttributes(attributes *Attributes) bool {
+	return attributes == nil ||
+		(attributes.List == nil && attributes.CodeBlock == nil && !attributes.Blockquote && attributes.Header == 0)
+}
+
+type inlineStyle struct {
+	bold   bool
+	italic bool
+	strike bool
+	code   bool
+	link   string
+}
+
+func (s inlineStyle) attributes() *Attributes {
+	if s == (inlineStyle{}) {
+		return nil
+	}
+	return &Attributes{
+		Bold:   s.bold,
+		Italic: s.italic,
+		Strike: s.strike,
+		Code:   s.code,
+		Link:   s.link,
+	}
+}
+
+func (p *markdownParser) inline(s string, style inlineStyle) {
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			p.text(text.String(), style.attributes())
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch c {
+		case '\\':
+			if i+1 < len(s) && strings.IndexByte("\\`*_{}[]()#+-.!<>@:~|", s[i+1]) != -1 {
+				text.WriteByte(s[i+1])
+				i += 2
+				continue
+			}
+		case '`':
+			n := runLength(s, i, '`')
+			delim := strings.Repeat("`", n)
+			if end := strings.Index(s[i+n:], delim); end != -1 {
+				flush()
+				code := s[i+n : i+n+end]
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				codeStyle := style
+				codeStyle.code = true
+				p.text(code, codeStyle.attributes())
+				i += n + end + n
+				continue
+			}
+		case '*', '_':
+			n := 1
+			if runLength(s, i, c) >= 2 {
+				n = 2
+			}
+			if end := closingDelimiter(s, i, c, n); end != -1 {
+				flush()
+				inner := style
+				if n == 2 {
+					inner.bold = true
+				} else {
+					inner.italic = true
+				}
+				p.inline(s[i+n:end], inner)
+				i = end + n
+				continue
+			}
+		case '~':
+			if runLength(s, i, c) == 2 {
+				if end := closingDelimiter(s, i, c, 2); end != -1 {
+					flush()
+					inner := style
+					inner.strike = true
+					p.inline(s[i+2:end], inner)
+					i = end + 2
+					continue
+				}
+			}
+		case '[':
+			if label, url, end, ok := markdownLink(s, i); ok {
+				flush()
+				linkStyle := style
+				linkStyle.link = url
+				p.inline(label, linkStyle)
+				i = end
+				continue
+			}
+		case '<':
+			if m := markdownMentionID.FindStringSubmatch(s[i:]); m != nil {
+				id, _ := strconv.Atoi(m[1])
+				flush()
+				p.mention(CommentMention{ID: id})
+				i += len(m[0])
+				continue
+			}
+		case '@':
+			if i == 0 || !isWordByte(s[i-1]) {
+				if m := markdownMentionName.FindStringSubmatch(s[i:]); m != nil {
+					if id, ok := p.opts.Mentions[m[1]]; ok {
+						flush()
+						p.mention(CommentMention{ID: id, Username: m[1]})
+						i += len(m[0])
+						continue
+					}
+				}
+			}
+		case ':':
+			if m := markdownShortcode.FindStringSubmatch(s[i:]); m != nil {
+				if code, ok := emoticonCodes[m[1]]; ok {
+					flush()
+					p.blocks = append(p.blocks, ComplexComment{
+						Type:     commentTypeEmoticon,
+						Text:     emoticonText(code),
+						Emoticon: &Emoticon{Code: code},
+					})
+					i += len(m[0])
+					continue
+				}
+			}
+		}
+
+		text.WriteByte(c)
+		i++
+	}
+	flush()
+}
+
+func (p *markdownParser) mention(user CommentMention) {
+	text := ""
+	if user.Username != "" {
+		text = "@" + user.Username
+	}
+	p.blocks = append(p.blocks, ComplexComment{
+		Type: commentTypeMention,
+		Text: text,
+		User: &user,
+	})
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// closingDelimiter finds the emphasis delimiter that closes the run of n c's at open.  -1 is
+// returned if the run cannot open emphasis or there is no closing delimiter.
+func closingDelimiter(s string, open int, c byte, n int) int {
+	start := open + n
+	if start >= len(s) || isSpaceByte(s[start]) {
+		return -1
+	}
+	// underscores inside of words (snake_case) are not emphasis
+	if c == '_' && open > 0 && isWordByte(s[open-1]) {
+		return -1
+	}
+
+	for j := start; j < len(s); {
+		switch s[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			// code spans bind tighter than emphasis
+			run := runLength(s, j, '`')
+			if end := strings.Index(s[j+run:], strings.Repeat("`", run)); end != -1 {
+				j += run + end + run
+				continue
+			}
+		case c:
+			run := runLength(s, j, c)
+			closes := run == n || run >= 3
+			if closes && j > start && !isSpaceByte(s[j-1]) {
+				end := j + run - n
+				if c != '_' || end+n >= len(s) || !isWordByte(s[end+n]) {
+					return end
+				}
+			}
+			j += run
+			continue
+		}
+		j++
+	}
+	return -1
+}
+
+// markdownLink parses [label](url "optional title") starting at open.
+func markdownLink(s string, open int) (label, url string, end int, ok bool) {
+	depth := 0
+	closeBracket := -1
+	for j := open; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeBracket = j
+			}
+		}
+		if closeBracket != -1 {
+			break
+		}
+	}
+	if closeBracket == -1 || closeBracket+1 >= len(s) || s[closeBracket+1] != '(' {
+		return "", "", 0, false
+	}
+
+	closeParen := strings.IndexByte(s[closeBracket+2:], ')')
+	if closeParen == -1 {
+		return "", "", 0, false
+	}
+	destination := strings.TrimSpace(s[closeBracket+2 : closeBracket+2+closeParen])
+	if space := strings.IndexAny(destination, " \t"); space != -1 {
+		destination = destination[:space] // drop the title
+	}
+	destination = strings.TrimSuffix(strings.TrimPrefix(destination, "<"), ">")
+	if destination == "" {
+		return "", "", 0, false
+	}
+
+	return s[open+1 : closeBracket], destination, closeBracket + 2 + closeParen + 1, true
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMarkdownToComment(t *testing.T) {
+	bullet := &Attributes{List: &List{List: "bullet"}}
+	tests := []struct {
+		name     string
+		markdown string
+		opts     *MarkdownOptions
+		want     []ComplexComment
+	}{
+		{
+			name:     "Paragraph lines are joined",
+			markdown: "first line\nsecond line",
+			want:     []ComplexComment{{Text: "first line second line\n"}},
+		},
+		{
+			name:     "Blank lines separate paragraphs",
+			markdown: "one\n\n\ntwo",
+			want:     []ComplexComment{{Text: "one\n\ntwo\n"}},
+		},
+		{
+			name:     "Inline styles",
+			markdown: "a **bold** and *italic* `code` ***both*** snake_case_name",
+			want: []ComplexComment{
+				{Text: "a "},
+				{Text: "bold", Attributes: &Attributes{Bold: true}},
+				{Text: " and "},
+				{Text: "italic", Attributes: &Attributes{Italic: true}},
+				{Text: " "},
+				{Text: "code", Attributes: &Attributes{Code: true}},
+				{Text: " "},
+				{Text: "both", Attributes: &Attributes{Bold: true, Italic: true}},
+				{Text: " snake_case_name\n"},
+			},
+		},
+		{
+			name:     "Unclosed and escaped delimiters stay as text",
+			markdown: `2 * 3 \*not italic\* **open`,
+			want:     []ComplexComment{{Text: "2 * 3 *not italic* **open\n"}},
+		},
+		{
+			name:     "Link",
+			markdown: `see [the **docs**](https://clickup.com/api "API")`,
+			want: []ComplexComment{
+				{Text: "see "},
+				{Text: "the ", Attributes: &Attributes{Link: "https://clickup.com/api"}},
+				{Text: "docs", Attributes: &Attributes{Bold: true, Link: "https://clickup.com/api"}},
+				{Text: "\n"},
+			},
+		},
+		{
+			name:     "Lists",
+			markdown: "- one\n* two\n\n1. first\n- [ ] todo\n- [x] done",
+			want: []ComplexComment{
+				{Text: "one"}, {Text: "\n", Attributes: bullet},
+				{Text: "two"}, {Text: "\n", Attributes: bullet},
+				{Text: "\nfirst"}, {Text: "\n", Attributes: &Attributes{List: &List{List: "ordered"}}},
+				{Text: "todo"}, {Text: "\n", Attributes: &Attributes{List: &List{List: "unchecked"}}},
+				{Text: "done"}, {Text: "\n", Attributes: &Attributes{List: &List{List: "checked"}}},
+			},
+		},
+		{
+			name:     "Fenced code block",
+			markdown: "before\n```go\nfunc main() {\n\n}\n```",
+			want: []ComplexComment{
+				{Text: "before\nfunc main() {"},
+				{Text: "\n", Attributes: &Attributes{CodeBlock: &CodeBlock{CodeBlock: "go"}}},
+				{Text: "\n", Attributes: &Attributes{CodeBlock: &CodeBlock{CodeBlock: "go"}}},
+				{Text: "}"},
+				{Text: "\n", Attributes: &Attributes{CodeBlock: &CodeBlock{CodeBlock: "go"}}},
+			},
+		},
+		{
+			name:     "Headings, quotes and strikethrough",
+			markdown: "## Release notes ##\n#### deep\n> quoted ~~old~~",
+			want: []ComplexComment{
+				{Text: "Release notes"},
+				{Text: "\n", Attributes: &Attributes{Header: 2}},
+				{Text: "deep"},
+				{Text: "\n", Attributes: &Attributes{Header: 3}},
+				{Text: "quoted "},
+				{Text: "old", Attributes: &Attributes{Strike: true}},
+				{Text: "\n", Attributes: &Attributes{Blockquote: true}},
+			},
+		},
+		{
+			name:     "Mentions and emoticons",
+			markdown: "thanks <@123> and @jane.doe, not @nobody or me@jane.doe :tada: :unknown:",
+			opts:     &MarkdownOptions{Mentions: map[string]int{"jane.doe": 456}},
+			want: []ComplexComment{
+				{Text: "thanks "},
+				{Type: commentTypeMention, User: &CommentMention{ID: 123}},
+				{Text: " and "},
+				{Type: commentTypeMention, Text: "@jane.doe", User: &CommentMention{ID: 456, Username: "jane.doe"}},
+				{Text: ", not @nobody or me@jane.doe "},
+				{Type: commentTypeEmoticon, Text: "🎉", Emoticon: &Emoticon{Code: "1f389"}},
+				{Text: " :unknown:\n"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MarkdownToComment(tt.markdown, tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MarkdownToComment() = %s, want %s", mustMarshal(t, got), mustMarshal(t, tt.want))
+			}
+		})
+	}
+}
+
+func TestCreateCommentRequest_AppendMarkdown(t *testing.T) {
+	req := NewCreateTaskCommentRequest("task-id", false, "")
+	req.AppendMarkdown("**hi** <@1>", nil)
+
+	got := mustMarshal(t, req)
+	want := `{"comment":[{"text":"hi","attributes":{"bold":true,"italic":false,"code":false,"list":null}},{"text":" "},{"text":"","type":"tag","user":{"id":1}},{"text":"\n"}]}`
+	if got != want {
+		t.Errorf("CreateCommentRequest.AppendMarkdown() = %s, want %s", got, want)
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return string(b)
+}
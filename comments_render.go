@@ -0,0 +1,573 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CommentMarkdown renders the blocks of a comment, such as those in a CommentsResponse, as CommonMark.
+// It is the inverse of MarkdownToComment.  Lines of a comment are kept as separate lines and
+// paragraphs, lists and code blocks are separated by a blank line.
+func CommentMarkdown(blocks []ComplexComment) string {
+	var b strings.Builder
+	for i, group := range groupCommentLines(commentLines(blocks)) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		switch group.kind {
+		case commentGroupCode:
+			code := commentCodeText(group.lines)
+			fence := strings.Repeat("`", maxInt(3, longestRun(code, '`')+1))
+			b.WriteString(fence)
+			if group.language != defaultCodeBlockLanguage {
+				b.WriteString(group.language)
+			}
+			b.WriteString("\n")
+			b.WriteString(code)
+			b.WriteString(fence + "\n")
+		default:
+			for n, line := range group.lines {
+				switch group.kind {
+				case commentGroupHeader:
+					b.WriteString(strings.Repeat("#", commentHeaderLevel(line)) + " ")
+				case commentGroupBlockquote:
+					b.WriteString("> ")
+				}
+				b.WriteString(commentListMarker(line, n, "- ", "- [ ] ", "- [x] "))
+				b.WriteString(escapeMarkdownLineStart(renderCommentInline(line.inline, markdownMarkup)))
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// CommentHTML renders the blocks of a comment as HTML that is safe to embed in a page or email.
+// All text is escaped and only http, https and mailto links and plain colors are kept.
+func CommentHTML(blocks []ComplexComment) string {
+	var b strings.Builder
+	for _, group := range groupCommentLines(commentLines(blocks)) {
+		switch group.kind {
+		case commentGroupCode:
+			b.WriteString("<pre><code")
+			if group.language != defaultCodeBlockLanguage {
+				b.WriteString(` class="language-` + html.EscapeString(group.language) + `"`)
+			}
+			b.WriteString(">")
+			b.WriteString(html.EscapeString(commentCodeText(group.lines)))
+			b.WriteString("</code></pre>\n")
+		case commentGroupOrdered, commentGroupBullet:
+			tag := "ul"
+			if group.kind == commentGroupOrdered {
+				tag = "ol"
+			}
+			b.WriteString("<" + tag + ">\n")
+			for n, line := range group.lines {
+				b.WriteString("<li>")
+				if group.kind == commentGroupBullet {
+					b.WriteString(commentListMarker(line, n, "", "☐ ", "☑ "))
+				}
+				b.WriteString(renderCommentInline(line.inline, htmlMarkup))
+				b.WriteString("</li>\n")
+			}
+			b.WriteString("</" + tag + ">\n")
+		case commentGroupHeader:
+			tag := "h" + strconv.Itoa(commentHeaderLevel(group.lines[0]))
+			b.WriteString("<" + tag + ">" + renderCommentInline(group.lines[0].inline, htmlMarkup) + "</" + tag + ">\n")
+		case commentGroupBlockquote:
+			b.WriteString("<blockquote><p>")
+			for n, line := range group.lines {
+				if n > 0 {
+					b.WriteString("<br>")
+				}
+				b.WriteString(renderCommentInline(line.inline, htmlMarkup))
+			}
+			b.WriteString("</p></blockquote>\n")
+		default:
+			b.WriteString("<p>")
+			for n, line := range group.lines {
+				if n > 0 {
+					b.WriteString("<br>")
+				}
+				b.WriteString(renderCommentInline(line.inline, htmlMarkup))
+			}
+			b.WriteString("</p>\n")
+		}
+	}
+	return b.String()
+}
+
+// CommentPlainText renders the blocks of a comment as plain text.  Each line of the comment is kept,
+// list items are prefixed with "- ", "1. ", "[ ] " or "[x] ", quotes with "> " and links are followed
+// by their url.
+func CommentPlainText(blocks []ComplexComment) string {
+	lines := commentLines(blocks)
+	var b strings.Builder
+	ordered := 0
+	for _, line := range lines {
+		if commentLineKind(line) == commentGroupOrdered {
+			ordered++
+		} else {
+			ordered = 0
+		}
+		if commentLineKind(line) == commentGroupBlockquote {
+			b.WriteString("> ")
+		}
+		b.WriteString(commentListMarker(line, ordered-1, "- ", "[ ] ", "[x] "))
+		b.WriteString(renderCommentInline(line.inline, plainTextMarkup))
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// commentLine is a single line of a comment.  format holds the attributes of the newline that ends
+// the line, which is where Clickup keeps line level formatting such as lists and code blocks.
+type commentLine struct {
+	inline []ComplexComment
+	format *Attributes
+}
+
+func commentLines(blocks []ComplexComment) []commentLine {
+	var lines []commentLine
+	var current []ComplexComment
+
+	for _, block := range blocks {
+		if block.Type == commentTypeMention || block.Type == commentTypeEmoticon {
+			current = append(current, block)
+			continue
+		}
+		parts := strings.Split(block.Text, "\n")
+		for i, part := range parts {
+			if part != "" {
+				segment := block
+				segment.Text = part
+				current = append(current, segment)
+			}
+			if i < len(parts)-1 {
+				lines = append(lines, commentLine{inline: current, format: block.Attributes})
+				current = nil
+			}
+		}
+	}
+	if len(current) > 0 {
+		lines = append(lines, commentLine{inline: current})
+	}
+
+	return lines
+}
+
+const (
+	commentGroupParagraph  = "paragraph"
+	commentGroupBullet     = "bullet"
+	commentGroupOrdered    = "ordered"
+	commentGroupCode       = "code"
+	commentGroupHeader     = "header"
+	commentGroupBlockquote = "blockquote"
+)
+
+func commentLineKind(line commentLine) string {
+	switch {
+	case line.format == nil:
+		return commentGroupParagraph
+	case line.format.CodeBlock != nil:
+		return commentGroupCode
+	case line.format.Header > 0:
+		return commentGroupHeader
+	case line.format.Blockquote:
+		return commentGroupBlockquote
+	case line.format.List == nil:
+		return commentGroupParagraph
+	case line.format.List.List == "ordered":
+		return commentGroupOrdered
+	default:
+		return commentGroupBullet
+	}
+}
+
+func commentLineLanguage(line commentLine) string {
+	if line.format == nil || line.format.CodeBlock == nil || line.format.CodeBlock.CodeBlock == "" {
+		return defaultCodeBlockLanguage
+	}
+	return line.format.CodeBlock.CodeBlock
+}
+
+func commentHeaderLevel(line commentLine) int {
+	if line.format.Header > 6 {
+		return 6
+	}
+	return line.format.Header
+}
+
+type commentGroup struct {
+	kind     string
+	language string // code blocks only
+	lines    []commentLine
+}
+
+// groupCommentLines collects consecutive lines into paragraphs, lists, quotes and code blocks.  Each
+// heading is a group of its own.  Empty lines outside of code blocks only separate groups.
+func groupCommentLines(lines []commentLine) []commentGroup {
+	var groups []commentGroup
+	for _, line := range lines {
+		kind := commentLineKind(line)
+		if kind == commentGroupParagraph && len(line.inline) == 0 {
+			groups = append(groups, commentGroup{})
+			continue
+		}
+		if n := len(groups); n > 0 && groups[n-1].kind == kind && kind != commentGroupHeader &&
+			(kind != commentGroupCode || groups[n-1].language == commentLineLanguage(line)) {
+			groups[n-1].lines = append(groups[n-1].lines, line)
+			continue
+		}
+		groups = append(groups, commentGroup{kind: kind, language: commentLineLanguage(line), lines: []commentLine{line}})
+	}
+
+	nonEmpty := groups[:0]
+	for _, group := range groups {
+		if len(group.lines) > 0 {
+			nonEmpty = append(nonEmpty, group)
+		}
+	}
+	return nonEmpty
+}
+
+// commentListMarker returns the marker that starts line as the nth item of its list, if it is a list item.
+func commentListMarker(line commentLine, n int, bullet, unchecked, checked string) string {
+	if line.format == nil || line.format.List == nil || line.format.CodeBlock != nil {
+		return ""
+	}
+	switch line.format.List.List {
+	case "ordered":
+		return strconv.Itoa(n+1) + ". "
+	case "unchecked":
+		return unchecked
+	case "checked":
+		return checked
+	default:
+		return bullet
+	}
+}
+
+func commentCodeText(lines []commentLine) string {
+	var b strings.Builder
+	for _, line := range lines {
+		for _, segment := range line.inline {
+			b.WriteString(commentSegmentText(segment))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// commentSegmentText is the text of a segment without any markup.
+func commentSegmentText(segment ComplexComment) string {
+	switch segment.Type {
+	case commentTypeMention:
+		if segment.Text != "" {
+			return segment.Text
+		}
+		if segment.User != nil && segment.User.Username != "" {
+			return "@" + segment.User.Username
+		}
+		if segment.User != nil {
+			return "@" + strconv.Itoa(segment.User.ID)
+		}
+	case commentTypeEmoticon:
+		if segment.Text == "" && segment.Emoticon != nil {
+			return emoticonText(segment.Emoticon.Code)
+		}
+	}
+	return segment.Text
+}
+
+// commentMarkup describes how to render the inline formatting of a comment in one format.
+type commentMarkup struct {
+	// styles are applied from the outermost to the innermost.
+	styles []commentStyle
+	// text renders a single unstyled segment.
+	text func(segment ComplexComment) string
+}
+
+type commentStyle struct {
+	// value is shared by segments that are styled together.  An empty value is unstyled.
+	value func(attributes *Attributes) string
+	// wrap applies the style to inner, the already rendered segments.
+	wrap func(value string, segments []ComplexComment, inner string) string
+}
+
+func renderCommentInline(segments []ComplexComment, markup commentMarkup) string {
+	return renderCommentStyles(segments, markup, markup.styles)
+}
+
+func renderCommentStyles(segments []ComplexComment, markup commentMarkup, styles []commentStyle) string {
+	var b strings.Builder
+	if len(styles) == 0 {
+		for _, segment := range segments {
+			b.WriteString(markup.text(segment))
+		}
+		return b.String()
+	}
+
+	style := styles[0]
+	for start := 0; start < len(segments); {
+		value := commentStyleValue(style, segments[start])
+		end := start + 1
+		for end < len(segments) && commentStyleValue(style, segments[end]) == value {
+			end++
+		}
+		run := segments[start:end]
+		inner := renderCommentStyles(run, markup, styles[1:])
+		if value != "" {
+			inner = style.wrap(value, run, inner)
+		}
+		b.WriteString(inner)
+		start = end
+	}
+	return b.String()
+}
+
+func commentStyleValue(style commentStyle, segment ComplexComment) string {
+	if segment.Attributes == nil {
+		return ""
+	}
+	return style.value(segment.Attributes)
+}
+
+func flagStyle(set func(attributes *Attributes) bool) func(attributes *Attributes) string {
+	return func(attributes *Attributes) string {
+		if set(attributes) {
+			return "1"
+		}
+		return ""
+	}
+}
+
+func linkValue(attributes *Attributes) string { return attributes.Link }
+
+func boldValue(attributes *Attributes) bool      { return attributes.Bold }
+func italicValue(attributes *Attributes) bool    { return attributes.Italic }
+func strikeValue(attributes *Attributes) bool    { return attributes.Strike }
+func underlineValue(attributes *Attributes) bool { return attributes.Underline }
+func codeValue(attributes *Attributes) bool      { return attributes.Code }
+
+// wrapTrimmed surrounds inner with open and close, keeping surrounding whitespace outside of the
+// markup since Markdown emphasis cannot start or end with a space.
+func wrapTrimmed(inner, open, close string) string {
+	core := strings.TrimSpace(inner)
+	if core == "" {
+		return inner
+	}
+	leading := inner[:strings.Index(inner, core)]
+	trailing := inner[len(leading)+len(core):]
+	return leading + open + core + close + trailing
+}
+
+func rawSegmentsText(segments []ComplexComment) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		b.WriteString(commentSegmentText(segment))
+	}
+	return b.String()
+}
+
+var markdownMarkup = commentMarkup{
+	styles: []commentStyle{
+		{
+			value: linkValue,
+			wrap: func(link string, _ []ComplexComment, inner string) string {
+				if strings.ContainsAny(link, " ()<>") {
+					link = "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(link) + ">"
+				}
+				return "[" + inner + "](" + link + ")"
+			},
+		},
+		{
+			value: flagStyle(boldValue),
+			wrap: func(_ string, _ []ComplexComment, inner string) string {
+				return wrapTrimmed(inner, "**", "**")
+			},
+		},
+		{
+			value: flagStyle(italicValue),
+			wrap: func(_ string, _ []ComplexComment, inner string) string {
+				return wrapTrimmed(inner, "*", "*")
+			},
+		},
+		{
+			value: flagStyle(strikeValue),
+			wrap: func(_ string, _ []ComplexComment, inner string) string {
+				return wrapTrimmed(inner, "~~", "~~")
+			},
+		},
+		{
+			value: flagStyle(codeValue),
+			wrap: func(_ string, segments []ComplexComment, _ string) string {
+				code := rawSegmentsText(segments)
+				fence := strings.Repeat("`", longestRun(code, '`')+1)
+				if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+					code = " " + code + " "
+				}
+				return fence + code + fence
+			},
+		},
+	},
+	text: func(segment ComplexComment) string {
+		switch segment.Type {
+		case commentTypeMention:
+			if segment.Text == "" && segment.User != nil && segment.User.Username == "" {
+				return "<@" + strconv.Itoa(segment.User.ID) + ">"
+			}
+			return escapeMarkdown(commentSegmentText(segment))
+		case commentTypeEmoticon:
+			return commentSegmentText(segment)
+		}
+		return escapeMarkdown(segment.Text)
+	},
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`, `~`, `\~`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var markdownOrderedStart = regexp.MustCompile(`^(\d{1,9})([.)])(\s|$)`)
+
+// escapeMarkdownLineStart escapes the characters that would start a heading or list item when
+// they begin a line of text.
+func escapeMarkdownLineStart(line string) string {
+	if line == "" {
+		return line
+	}
+	switch line[0] {
+	case '#', '-', '+':
+		return `\` + line
+	}
+	return markdownOrderedStart.ReplaceAllString(line, `$1\$2$3`)
+}
+
+var htmlMarkup = commentMarkup{
+	styles: []commentStyle{
+		{
+			value: func(attributes *Attributes) string {
+				if !safeLink(attributes.Link) {
+					return ""
+				}
+				return attributes.Link
+			},
+			wrap: func(link string, _ []ComplexComment, inner string) string {
+				return `<a href="` + html.EscapeString(link) + `">` + inner + "</a>"
+			},
+		},
+		{
+			value: func(attributes *Attributes) string {
+				if !htmlColor.MatchString(attributes.Color) {
+					return ""
+				}
+				return attributes.Color
+			},
+			wrap: func(color string, _ []ComplexComment, inner string) string {
+				return `<span style="color: ` + color + `">` + inner + "</span>"
+			},
+		},
+		{
+			value: flagStyle(boldValue),
+			wrap: func(_ string, _ []ComplexComment, inner string) string {
+				return "<strong>" + inner + "</strong>"
+			},
+		},
+		{
+			value: flagStyle(italicValue),
+			wrap: func(_ string, _ []ComplexComment, inner string) string {
+				return "<em>" + inner + "</em>"
+			},
+		},
+		{
+			value: flagStyle(underlineValue),
+			wrap: func(_ string, _ []ComplexComment, inner string) string {
+				return "<u>" + inner + "</u>"
+			},
+		},
+		{
+			value: flagStyle(strikeValue),
+			wrap: func(_ string, _ []ComplexComment, inner string) string {
+				return "<s>" + inner + "</s>"
+			},
+		},
+		{
+			value: flagStyle(codeValue),
+			wrap: func(_ string, _ []ComplexComment, inner string) string {
+				return "<code>" + inner + "</code>"
+			},
+		},
+	},
+	text: func(segment ComplexComment) string {
+		text := html.EscapeString(commentSegmentText(segment))
+		if segment.Type == commentTypeMention && segment.User != nil {
+			return `<span class="mention" data-user-id="` + strconv.Itoa(segment.User.ID) + `">` + text + "</span>"
+		}
+		return text
+	},
+}
+
+// htmlColor matches the colors that can be used in a style attribute as they are.
+var htmlColor = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]{1,20}|rgba?\([0-9., %]{1,40}\))$`)
+
+// safeLink reports whether link can be used as an href without running script.
+func safeLink(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+var plainTextMarkup = commentMarkup{
+	styles: []commentStyle{
+		{
+			value: linkValue,
+			wrap: func(link string, _ []ComplexComment, inner string) string {
+				if inner == link {
+					return inner
+				}
+				return inner + " (" + link + ")"
+			},
+		},
+	},
+	text: commentSegmentText,
+}
+
+func longestRun(s string, c byte) int {
+	longest := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			if n := runLength(s, i, c); n > longest {
+				longest = n
+			}
+			i += runLength(s, i, c) - 1
+		}
+	}
+	return longest
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
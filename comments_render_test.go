@@ -0,0 +1,119 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import "testing"
+
+func TestCommentRenderers(t *testing.T) {
+	tests := []struct {
+		name          string
+		blocks        []ComplexComment
+		wantMarkdown  string
+		wantHTML      string
+		wantPlainText string
+	}{
+		{
+			name: "Paragraphs with inline styles",
+			blocks: []ComplexComment{
+				{Text: "Hello "},
+				{Text: "bold ", Attributes: &Attributes{Bold: true}},
+				{Text: "both", Attributes: &Attributes{Bold: true, Italic: true}},
+				{Text: " 1 * 2 <b>\n\n"},
+				{Text: "run ", Attributes: &Attributes{Code: true}},
+				{Text: "x`y", Attributes: &Attributes{Code: true}},
+				{Text: "\n# not a heading"},
+			},
+			wantMarkdown:  "Hello **bold *both*** 1 \\* 2 \\<b\\>\n\n``run x`y``\n\\# not a heading\n",
+			wantHTML:      "<p>Hello <strong>bold <em>both</em></strong> 1 * 2 &lt;b&gt;</p>\n<p><code>run x`y</code><br># not a heading</p>\n",
+			wantPlainText: "Hello bold both 1 * 2 <b>\n\nrun x`y\n# not a heading",
+		},
+		{
+			name: "Lists are grouped and numbered",
+			blocks: []ComplexComment{
+				{Text: "intro\n"},
+				{Text: "one"}, {Text: "\n", Attributes: &Attributes{List: &List{List: "ordered"}}},
+				{Text: "two"}, {Text: "\n", Attributes: &Attributes{List: &List{List: "ordered"}}},
+				{Text: "todo"}, {Text: "\n", Attributes: &Attributes{List: &List{List: "unchecked"}}},
+				{Text: "done"}, {Text: "\n", Attributes: &Attributes{List: &List{List: "checked"}}},
+				{Text: "dot"}, {Text: "\n", Attributes: &Attributes{List: &List{List: "bullet"}}},
+			},
+			wantMarkdown:  "intro\n\n1. one\n2. two\n\n- [ ] todo\n- [x] done\n- dot\n",
+			wantHTML:      "<p>intro</p>\n<ol>\n<li>one</li>\n<li>two</li>\n</ol>\n<ul>\n<li>☐ todo</li>\n<li>☑ done</li>\n<li>dot</li>\n</ul>\n",
+			wantPlainText: "intro\n1. one\n2. two\n[ ] todo\n[x] done\n- dot",
+		},
+		{
+			name: "Code block",
+			blocks: []ComplexComment{
+				{Text: "if a < b {"},
+				{Text: "\n", Attributes: &Attributes{CodeBlock: &CodeBlock{CodeBlock: "go"}}},
+				{Text: "}"},
+				{Text: "\n", Attributes: &Attributes{CodeBlock: &CodeBlock{CodeBlock: "go"}}},
+				{Text: "plain"},
+				{Text: "\n", Attributes: &Attributes{CodeBlock: &CodeBlock{CodeBlock: "plain"}}},
+			},
+			wantMarkdown:  "```go\nif a < b {\n}\n```\n\n```\nplain\n```\n",
+			wantHTML:      "<pre><code class=\"language-go\">if a &lt; b {\n}\n</code></pre>\n<pre><code>plain\n</code></pre>\n",
+			wantPlainText: "if a < b {\n}\nplain",
+		},
+		{
+			name: "Links, mentions and emoticons",
+			blocks: []ComplexComment{
+				{Text: "see "},
+				{Text: "docs", Attributes: &Attributes{Link: "https://clickup.com/api"}},
+				{Text: " "},
+				{Text: "bad", Attributes: &Attributes{Link: "javascript:alert(1)"}},
+				{Text: " "},
+				{Type: commentTypeMention, Text: "@Jane", User: &CommentMention{ID: 1}},
+				{Text: " "},
+				{Type: commentTypeMention, User: &CommentMention{ID: 2}},
+				{Text: " "},
+				{Type: commentTypeEmoticon, Emoticon: &Emoticon{Code: "1f44d"}},
+			},
+			wantMarkdown:  "see [docs](https://clickup.com/api) [bad](<javascript:alert(1)>) @Jane <@2> 👍\n",
+			wantHTML:      "<p>see <a href=\"https://clickup.com/api\">docs</a> bad <span class=\"mention\" data-user-id=\"1\">@Jane</span> <span class=\"mention\" data-user-id=\"2\">@2</span> 👍</p>\n",
+			wantPlainText: "see docs (https://clickup.com/api) bad (javascript:alert(1)) @Jane @2 👍",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommentMarkdown(tt.blocks); got != tt.wantMarkdown {
+				t.Errorf("CommentMarkdown() = %q, want %q", got, tt.wantMarkdown)
+			}
+			if got := CommentHTML(tt.blocks); got != tt.wantHTML {
+				t.Errorf("CommentHTML() = %q, want %q", got, tt.wantHTML)
+			}
+			if got := CommentPlainText(tt.blocks); got != tt.wantPlainText {
+				t.Errorf("CommentPlainText() = %q, want %q", got, tt.wantPlainText)
+			}
+		})
+	}
+}
+
+func TestCommentMarkdown_roundTrip(t *testing.T) {
+	markdown := "Release **notes** for *v2* with `code` and [a link](https://clickup.com)\n\n- [ ] check\n- item\n\n1. first\n2. second\n\n```go\nfunc main() {}\n```\n"
+	if got := CommentMarkdown(MarkdownToComment(markdown, nil)); got != markdown {
+		t.Errorf("CommentMarkdown(MarkdownToComment()) = %q, want %q", got, markdown)
+	}
+}
+
+func TestCommentRenderers_lineFormats(t *testing.T) {
+	blocks := NewComment().
+		Heading(2, "Title").
+		Strike("old").Text(" ").Underline("new").Text(" ").Color("red", "#ff0000").Color("x", "red;background:url(x)").
+		Blockquote("quoted\nlines").
+		Blocks()
+
+	if got, want := CommentMarkdown(blocks), "## Title\n\n~~old~~ new redx\n\n> quoted\n> lines\n"; got != want {
+		t.Errorf("CommentMarkdown() = %q, want %q", got, want)
+	}
+	if got, want := CommentHTML(blocks), "<h2>Title</h2>\n<p><s>old</s> <u>new</u> <span style=\"color: #ff0000\">red</span>x</p>\n<blockquote><p>quoted<br>lines</p></blockquote>\n"; got != want {
+		t.Errorf("CommentHTML() = %q, want %q", got, want)
+	}
+	if got, want := CommentPlainText(blocks), "Title\nold new redx\n> quoted\n> lines"; got != want {
+		t.Errorf("CommentPlainText() = %q, want %q", got, want)
+	}
+}
@@ -720,7 +720,7 @@ func TestClient_UpdateComment(t *testing.T) {
 				ctx: context.Background(),
 				comment: UpdateCommentRequest{
 					CommentID:   "123",
-					CommentText: "test comment",
+					CommentText: OptString("test comment"),
 				},
 			},
 			wantErr: false,
@@ -814,3 +814,78 @@ func TestClient_DeleteComment(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Comments(t *testing.T) {
+	var gotPath string
+	doer := newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+		gotPath = req.URL.RequestURI()
+		body := `{"comments":[{"id":"462","comment_text":"hello","user":{"id":1,"username":"jane"},"date":"1568036964079"}]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	c := &Client{doer: doer, authenticator: &APITokenAuthenticator{}}
+
+	tests := []struct {
+		name     string
+		call     func() (CommentsResponse, error)
+		wantPath string
+		wantErr  bool
+	}{
+		{
+			name: "Task comments by custom id",
+			call: func() (CommentsResponse, error) {
+				return c.TaskComments(context.Background(), CommentsForTaskQuery{CommentsQuery{TaskID: "ABC-1", UseCustomTaskIDs: true, WorkspaceID: "444"}})
+			},
+			wantPath: "/task/ABC-1/comment/?custom_task_ids=true&team_id=444",
+		},
+		{
+			name: "Fail custom task id without workspace",
+			call: func() (CommentsResponse, error) {
+				return c.TaskComments(context.Background(), CommentsForTaskQuery{CommentsQuery{TaskID: "ABC-1", UseCustomTaskIDs: true}})
+			},
+			wantErr: true,
+		},
+		{
+			name: "View comments",
+			call: func() (CommentsResponse, error) {
+				return c.ChatViewComments(context.Background(), CommentsForTaskViewQuery{CommentsQuery{ViewID: "v1"}})
+			},
+			wantPath: "/view/v1/comment",
+		},
+		{
+			name: "List comments",
+			call: func() (CommentsResponse, error) {
+				return c.ListComments(context.Background(), CommentsForListQuery{CommentsQuery{ListID: "l1"}})
+			},
+			wantPath: "/list/l1/comment",
+		},
+		{
+			name: "Fail missing list ID",
+			call: func() (CommentsResponse, error) {
+				return c.ListComments(context.Background(), CommentsForListQuery{})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath = ""
+			got, err := tt.call()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("request path = %q, want %q", gotPath, tt.wantPath)
+			}
+			if len(got.Comments) != 1 || got.Comments[0].CommentText != "hello" || got.Comments[0].User.Username != "jane" {
+				t.Errorf("comments = %+v", got.Comments)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// CustomFieldOption is a choice of a drop_down or labels custom field.  Drop down options have a
+// Name and labels options have a Label.
+type CustomFieldOption struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Label      string `json:"label"`
+	Color      string `json:"color"`
+	Orderindex int    `json:"orderindex"`
+}
+
+// UnmarshalJSON decodes an option, whose orderindex Clickup sends as a number or as a string of digits.
+// Tasks refer to a drop down option by its id or by its orderindex.
+func (o *CustomFieldOption) UnmarshalJSON(b []byte) error {
+	type option CustomFieldOption
+	var v struct {
+		option
+		Orderindex json.RawMessage `json:"orderindex"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*o = CustomFieldOption(v.option)
+	o.Orderindex = 0
+
+	raw := v.Orderindex
+	if len(raw) == 0 || bytes.Equal(raw, nullJSON) {
+		return nil
+	}
+	s := string(raw)
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			return nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("invalid orderindex %s: %w", raw, err)
+	}
+	o.Orderindex = n
+	return nil
+}
+
+// ListCustomField is a custom field that can be set on the tasks of a list.
+type ListCustomField struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	TypeConfig struct {
+		Options []CustomFieldOption `json:"options"`
+	} `json:"type_config"`
+	DateCreated    Timestamp `json:"date_created"`
+	HideFromGuests bool      `json:"hide_from_guests"`
+	Required       bool      `json:"required"`
+}
+
+type CustomFieldsResponse struct {
+	Fields []ListCustomField `json:"fields"`
+}
+
+// CustomFieldsForList returns the custom fields that are accessible to the tasks of listID.
+func (c *Client) CustomFieldsForList(ctx context.Context, listID string) (*CustomFieldsResponse, error) {
+	if listID == "" {
+		return nil, fmt.Errorf("must provide a list id to retrieve custom fields: %w", ErrValidation)
+	}
+
+	endpoint := fmt.Sprintf("/list/%s/field", listID)
+
+	var fields CustomFieldsResponse
+
+	if err := c.call(ctx, http.MethodGet, endpoint, nil, &fields); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &fields, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_CustomFieldsForList(t *testing.T) {
+	tests := []struct {
+		name      string
+		listID    string
+		body      string
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:   "TestSuccessful fields returned",
+			listID: "fakeListID",
+			body: `{"fields":[
+				{"id":"f1","name":"Sprint","type":"drop_down","type_config":{"options":[{"id":"o1","name":"Sprint 1","orderindex":0},{"id":"o3","name":"Sprint 2","orderindex":"1"}]}},
+				{"id":"f2","name":"Area","type":"labels","type_config":{"options":[{"id":"o2","label":"Backend"}]}}
+			]}`,
+			wantCount: 2,
+		},
+		{
+			name:    "TestFail Missing List ID",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.URL.Path != "/list/fakeListID/field" {
+						t.Errorf("path = %s, want /list/fakeListID/field", req.URL.Path)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			got, err := c.CustomFieldsForList(context.Background(), tt.listID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.CustomFieldsForList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got.Fields) != tt.wantCount {
+				t.Fatalf("got %d fields, want %d", len(got.Fields), tt.wantCount)
+			}
+			if got := got.Fields[0].TypeConfig.Options[1].Orderindex; got != 1 {
+				t.Errorf("drop down option orderindex = %d, want 1", got)
+			}
+			if got.Fields[1].TypeConfig.Options[0].Label != "Backend" {
+				t.Errorf("labels option = %+v, want label Backend", got.Fields[1].TypeConfig.Options[0])
+			}
+		})
+	}
+}
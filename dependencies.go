@@ -10,14 +10,44 @@ import (
 	"strconv"
 )
 
+// DependencyType describes a dependency from the point of view of the task it was returned with.
+type DependencyType int
+
+const (
+	DependencyWaitingOn DependencyType = iota // the task is waiting on DependsOn
+	DependencyBlocking                        // the task is blocking TaskID
+)
+
+// TaskDependency means that TaskID cannot be completed until DependsOn is.  The same dependency
+// is returned with both tasks, so Type tells which side of it the owning task is on.
+type TaskDependency struct {
+	TaskID      string         `json:"task_id"`
+	DependsOn   string         `json:"depends_on"`
+	Type        DependencyType `json:"type"`
+	DateCreated Timestamp      `json:"date_created"`
+	Userid      string         `json:"userid"`
+	WorkspaceID string         `json:"workspace_id"`
+	ChainID     string         `json:"chain_id"`
+}
+
+// TaskLink is an undirected relationship between TaskID and LinkID.
+type TaskLink struct {
+	TaskID      string    `json:"task_id"`
+	LinkID      string    `json:"link_id"`
+	DateCreated Timestamp `json:"date_created"`
+	Userid      string    `json:"userid"`
+	WorkspaceID string    `json:"workspace_id"`
+}
+
 type AddDependencyRequest struct {
-	TaskID           string
+	TaskID           string `json:"-"`
 	DependsOn        string `json:"depends_on,omitempty"`
 	DependencyOf     string `json:"dependency_of,omitempty"`
-	WorkspaceID      string
-	UseCustomTaskIDs bool
+	WorkspaceID      string `json:"-"`
+	UseCustomTaskIDs bool   `json:"-"`
 }
 
+// AddDependencyForTask makes dependency.TaskID wait on dependency.DependsOn, or block dependency.DependencyOf.
 func (c *Client) AddDependencyForTask(ctx context.Context, dependency AddDependencyRequest) error {
 	if dependency.TaskID == "" {
 		return fmt.Errorf("must provide a task id to create a dependency: %w", ErrValidation)
@@ -58,10 +88,29 @@ type AddTaskLinkRequest struct {
 	UseCustomTaskIDs bool
 }
 
+// TaskLinkResponse holds the task that a link was added to or removed from, with its
+// current LinkedTasks.
 type TaskLinkResponse struct {
 	Task *SingleTask `json:"task"`
 }
 
+// LinkedTaskIDs returns the ids of the tasks currently linked to the task in t.
+func (t *TaskLinkResponse) LinkedTaskIDs() []string {
+	if t.Task == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(t.Task.LinkedTasks))
+	for _, link := range t.Task.LinkedTasks {
+		if link.LinkID == t.Task.ID {
+			ids = append(ids, link.TaskID)
+			continue
+		}
+		ids = append(ids, link.LinkID)
+	}
+	return ids
+}
+
+// AddTaskLinkForTask links link.TaskID with link.LinksToTaskID.
 func (c *Client) AddTaskLinkForTask(ctx context.Context, link AddTaskLinkRequest) (*TaskLinkResponse, error) {
 	if link.TaskID == "" {
 		return nil, fmt.Errorf("must provide a task id to create a task link: %w", ErrValidation)
@@ -87,3 +136,80 @@ func (c *Client) AddTaskLinkForTask(ctx context.Context, link AddTaskLinkRequest
 
 	return &linkedTask, nil
 }
+
+type DeleteDependencyRequest struct {
+	TaskID           string
+	DependsOn        string
+	DependencyOf     string
+	WorkspaceID      string
+	UseCustomTaskIDs bool
+}
+
+// DeleteDependencyForTask removes the dependency between dependency.TaskID and either
+// dependency.DependsOn or dependency.DependencyOf.
+func (c *Client) DeleteDependencyForTask(ctx context.Context, dependency DeleteDependencyRequest) error {
+	if dependency.TaskID == "" {
+		return fmt.Errorf("must provide a task id to delete a dependency: %w", ErrValidation)
+	}
+	if dependency.UseCustomTaskIDs && dependency.WorkspaceID == "" {
+		return fmt.Errorf("workspaceID must be provided if deleting by custom task id: %w", ErrValidation)
+	}
+	if len(dependency.DependsOn) > 0 && len(dependency.DependencyOf) > 0 {
+		return fmt.Errorf("must provide either a depends_on or dependency_of to delete a dependency but not both: %w", ErrValidation)
+	}
+	if dependency.DependsOn == "" && dependency.DependencyOf == "" {
+		return fmt.Errorf("must provide either a depends_on or dependency_of to delete a dependency: %w", ErrValidation)
+	}
+
+	urlValues := url.Values{}
+	urlValues.Set("custom_task_ids", strconv.FormatBool(dependency.UseCustomTaskIDs))
+	urlValues.Add("team_id", dependency.WorkspaceID)
+	if dependency.DependsOn != "" {
+		urlValues.Add("depends_on", dependency.DependsOn)
+	}
+	if dependency.DependencyOf != "" {
+		urlValues.Add("dependency_of", dependency.DependencyOf)
+	}
+
+	endpoint := fmt.Sprintf("/task/%v/dependency/?%s", dependency.TaskID, urlValues.Encode())
+
+	if err := c.call(ctx, http.MethodDelete, endpoint, nil, &struct{}{}); err != nil {
+		return fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return nil
+}
+
+type DeleteTaskLinkRequest struct {
+	TaskID           string
+	LinksToTaskID    string
+	WorkspaceID      string
+	UseCustomTaskIDs bool
+}
+
+// DeleteTaskLinkForTask removes the link between link.TaskID and link.LinksToTaskID.
+func (c *Client) DeleteTaskLinkForTask(ctx context.Context, link DeleteTaskLinkRequest) (*TaskLinkResponse, error) {
+	if link.TaskID == "" {
+		return nil, fmt.Errorf("must provide a task id to delete a task link: %w", ErrValidation)
+	}
+	if link.UseCustomTaskIDs && link.WorkspaceID == "" {
+		return nil, fmt.Errorf("workspaceID must be provided if deleting by custom task id: %w", ErrValidation)
+	}
+	if link.LinksToTaskID == "" {
+		return nil, fmt.Errorf("must provide the linked task id to delete: %w", ErrValidation)
+	}
+
+	urlValues := url.Values{}
+	urlValues.Set("custom_task_ids", strconv.FormatBool(link.UseCustomTaskIDs))
+	urlValues.Add("team_id", link.WorkspaceID)
+
+	endpoint := fmt.Sprintf("/task/%v/link/%v/?%v", link.TaskID, link.LinksToTaskID, urlValues.Encode())
+
+	var linkedTask TaskLinkResponse
+
+	if err := c.call(ctx, http.MethodDelete, endpoint, nil, &linkedTask); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &linkedTask, nil
+}
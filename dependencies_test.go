@@ -193,3 +193,162 @@ func TestClient_AddTaskLink(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_DeleteDependencyForTask(t *testing.T) {
+	type fields struct {
+		doer          ClientDoer
+		authenticator Authenticator
+	}
+	type args struct {
+		ctx        context.Context
+		dependency DeleteDependencyRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "TestSuccessful delete dependency",
+			fields: fields{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodDelete || req.URL.Query().Get("depends_on") != "dependency task" {
+						t.Errorf("unexpected request %s %s", req.Method, req.URL)
+					}
+					body := `{}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			},
+			args: args{
+				ctx: context.Background(),
+				dependency: DeleteDependencyRequest{
+					TaskID:    "test task",
+					DependsOn: "dependency task",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Depends on and dependency of both provided",
+			args: args{
+				ctx: context.Background(),
+				dependency: DeleteDependencyRequest{
+					TaskID:       "test task",
+					DependsOn:    "depends on task",
+					DependencyOf: "dependency of task",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing workspace id with custom task ids",
+			args: args{
+				ctx: context.Background(),
+				dependency: DeleteDependencyRequest{
+					TaskID:           "test task",
+					DependsOn:        "depends on task",
+					UseCustomTaskIDs: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing task id",
+			args: args{
+				ctx: context.Background(),
+				dependency: DeleteDependencyRequest{
+					DependsOn: "depends on task",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer:          tt.fields.doer,
+				authenticator: tt.fields.authenticator,
+			}
+			if err := c.DeleteDependencyForTask(tt.args.ctx, tt.args.dependency); (err != nil) != tt.wantErr {
+				t.Errorf("Client.DeleteDependencyForTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteTaskLinkForTask(t *testing.T) {
+	type fields struct {
+		doer          ClientDoer
+		authenticator Authenticator
+	}
+	type args struct {
+		ctx  context.Context
+		link DeleteTaskLinkRequest
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		wantLinkIDs []string
+		wantErr     bool
+	}{
+		{
+			name: "TestSuccessful delete task link",
+			fields: fields{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					body := `{"task": {"id": "task id", "linked_tasks": [{"task_id": "task id", "link_id": "other id"}, {"task_id": "third id", "link_id": "task id"}]}}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			},
+			args: args{
+				ctx: context.Background(),
+				link: DeleteTaskLinkRequest{
+					TaskID:        "task id",
+					LinksToTaskID: "linked task id",
+				},
+			},
+			wantLinkIDs: []string{"other id", "third id"},
+			wantErr:     false,
+		},
+		{
+			name: "Missing linked task id",
+			args: args{
+				ctx: context.Background(),
+				link: DeleteTaskLinkRequest{
+					TaskID: "task id",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer:          tt.fields.doer,
+				authenticator: tt.fields.authenticator,
+			}
+			got, err := c.DeleteTaskLinkForTask(tt.args.ctx, tt.args.link)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.DeleteTaskLinkForTask() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if ids := got.LinkedTaskIDs(); strings.Join(ids, ",") != strings.Join(tt.wantLinkIDs, ",") {
+				t.Errorf("TaskLinkResponse.LinkedTaskIDs() = %v, want %v", ids, tt.wantLinkIDs)
+			}
+		})
+	}
+}
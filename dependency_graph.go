@@ -0,0 +1,305 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"fmt"
+	"time"
+)
+
+// DependencyGraph is a directed graph of a set of tasks built from their Dependencies, along
+// with the undirected LinkedTasks between them.  Dependencies and links that point at tasks outside
+// of the set are ignored.
+type DependencyGraph struct {
+	tasks      map[string]SingleTask
+	order      []string            // task ids in the order they were provided
+	dependsOn  map[string][]string // task id -> tasks it waits on
+	dependents map[string][]string // task id -> tasks waiting on it
+	links      map[string][]string
+}
+
+// NewDependencyGraph builds a DependencyGraph over tasks.
+func NewDependencyGraph(tasks []SingleTask) *DependencyGraph {
+	g := &DependencyGraph{
+		tasks:      make(map[string]SingleTask, len(tasks)),
+		dependsOn:  make(map[string][]string),
+		dependents: make(map[string][]string),
+		links:      make(map[string][]string),
+	}
+
+	for _, task := range tasks {
+		if _, ok := g.tasks[task.ID]; ok {
+			continue
+		}
+		g.tasks[task.ID] = task
+		g.order = append(g.order, task.ID)
+	}
+
+	edges := make(map[[2]string]bool)
+	linked := make(map[[2]string]bool)
+	for _, id := range g.order {
+		task := g.tasks[id]
+		for _, dep := range task.Dependencies {
+			if !g.has(dep.TaskID) || !g.has(dep.DependsOn) || edges[[2]string{dep.TaskID, dep.DependsOn}] {
+				continue
+			}
+			edges[[2]string{dep.TaskID, dep.DependsOn}] = true
+			g.dependsOn[dep.TaskID] = append(g.dependsOn[dep.TaskID], dep.DependsOn)
+			g.dependents[dep.DependsOn] = append(g.dependents[dep.DependsOn], dep.TaskID)
+		}
+		for _, link := range task.LinkedTasks {
+			a, b := link.TaskID, link.LinkID
+			if b < a {
+				a, b = b, a
+			}
+			if !g.has(a) || !g.has(b) || a == b || linked[[2]string{a, b}] {
+				continue
+			}
+			linked[[2]string{a, b}] = true
+			g.links[a] = append(g.links[a], b)
+			g.links[b] = append(g.links[b], a)
+		}
+	}
+
+	return g
+}
+
+func (g *DependencyGraph) has(taskID string) bool {
+	_, ok := g.tasks[taskID]
+	return ok
+}
+
+// Task returns the task with taskID, if it is part of the graph.
+func (g *DependencyGraph) Task(taskID string) (SingleTask, bool) {
+	task, ok := g.tasks[taskID]
+	return task, ok
+}
+
+// DependsOn returns the ids of the tasks that taskID is waiting on.
+func (g *DependencyGraph) DependsOn(taskID string) []string {
+	return g.dependsOn[taskID]
+}
+
+// Dependents returns the ids of the tasks that are waiting on taskID.
+func (g *DependencyGraph) Dependents(taskID string) []string {
+	return g.dependents[taskID]
+}
+
+// LinkedTo returns the ids of the tasks linked to taskID.
+func (g *DependencyGraph) LinkedTo(taskID string) []string {
+	return g.links[taskID]
+}
+
+// TopologicalSort orders the tasks so that every task comes after all of the tasks it depends on.
+// Tasks that are not constrained by each other keep the order they were provided in.
+// ErrDependencyCycle is returned if no such order exists.  See Cycles.
+func (g *DependencyGraph) TopologicalSort() ([]SingleTask, error) {
+	ids, err := g.topologicalOrder()
+	if err != nil {
+		return nil, err
+	}
+	sorted := make([]SingleTask, 0, len(ids))
+	for _, id := range ids {
+		sorted = append(sorted, g.tasks[id])
+	}
+	return sorted, nil
+}
+
+func (g *DependencyGraph) topologicalOrder() ([]string, error) {
+	remaining := make(map[string]int, len(g.order))
+	for _, id := range g.order {
+		remaining[id] = len(g.dependsOn[id])
+	}
+
+	sorted := make([]string, 0, len(g.order))
+	done := make(map[string]bool, len(g.order))
+	for len(sorted) < len(g.order) {
+		progressed := false
+		for _, id := range g.order {
+			if done[id] || remaining[id] > 0 {
+				continue
+			}
+			done[id] = true
+			progressed = true
+			sorted = append(sorted, id)
+			for _, dependent := range g.dependents[id] {
+				remaining[dependent]--
+			}
+		}
+		if !progressed {
+			return nil, fmt.Errorf("%d tasks could not be ordered: %w", len(g.order)-len(sorted), ErrDependencyCycle)
+		}
+	}
+
+	return sorted, nil
+}
+
+// Cycles returns each group of task ids that depend on each other in a loop.  A nil result
+// means the graph can be sorted.
+func (g *DependencyGraph) Cycles() [][]string {
+	// Tarjan's strongly connected components
+	index := 0
+	indexes := make(map[string]int, len(g.order))
+	lowlinks := make(map[string]int, len(g.order))
+	onStack := make(map[string]bool, len(g.order))
+	var stack []string
+	var cycles [][]string
+
+	var connect func(id string)
+	connect = func(id string) {
+		indexes[id] = index
+		lowlinks[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, next := range g.dependsOn[id] {
+			if _, visited := indexes[next]; !visited {
+				connect(next)
+				if lowlinks[next] < lowlinks[id] {
+					lowlinks[id] = lowlinks[next]
+				}
+			} else if onStack[next] && indexes[next] < lowlinks[id] {
+				lowlinks[id] = indexes[next]
+			}
+		}
+
+		if lowlinks[id] != indexes[id] {
+			return
+		}
+
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == id {
+				break
+			}
+		}
+		if len(component) > 1 || g.dependsOnSelf(id) {
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, id := range g.order {
+		if _, visited := indexes[id]; !visited {
+			connect(id)
+		}
+	}
+
+	return cycles
+}
+
+func (g *DependencyGraph) dependsOnSelf(taskID string) bool {
+	for _, id := range g.dependsOn[taskID] {
+		if id == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskSchedule is the critical path method schedule for a single task.  All times are offsets
+// from the start of the work described by the graph.
+type TaskSchedule struct {
+	Duration       time.Duration
+	EarliestStart  time.Duration
+	EarliestFinish time.Duration
+	LatestStart    time.Duration
+	LatestFinish   time.Duration
+	Slack          time.Duration // how long the task can slip without delaying the whole set
+}
+
+type CriticalPath struct {
+	Tasks     []SingleTask // the chain of tasks with no slack, in dependency order
+	Duration  time.Duration
+	Schedules map[string]TaskSchedule // keyed by task id
+}
+
+// CriticalPath finds the longest chain of dependent work through the graph.
+// A task's duration is its TimeEstimate, or the time between its StartDate and DueDate if it
+// has no estimate.  Tasks with neither count as zero length milestones.
+// ErrDependencyCycle is returned if the graph has a cycle.
+func (g *DependencyGraph) CriticalPath() (*CriticalPath, error) {
+	order, err := g.topologicalOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make(map[string]TaskSchedule, len(order))
+	var total time.Duration
+
+	for _, id := range order {
+		s := TaskSchedule{Duration: taskDuration(g.tasks[id])}
+		for _, dep := range g.dependsOn[id] {
+			if finish := schedules[dep].EarliestFinish; finish > s.EarliestStart {
+				s.EarliestStart = finish
+			}
+		}
+		s.EarliestFinish = s.EarliestStart + s.Duration
+		if s.EarliestFinish > total {
+			total = s.EarliestFinish
+		}
+		schedules[id] = s
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		s := schedules[id]
+		s.LatestFinish = total
+		for _, dependent := range g.dependents[id] {
+			if start := schedules[dependent].LatestStart; start < s.LatestFinish {
+				s.LatestFinish = start
+			}
+		}
+		s.LatestStart = s.LatestFinish - s.Duration
+		s.Slack = s.LatestStart - s.EarliestStart
+		schedules[id] = s
+	}
+
+	path := &CriticalPath{
+		Duration:  total,
+		Schedules: schedules,
+	}
+
+	// walk forward from a critical task with no prerequisites, always stepping to a critical dependent
+	// that starts exactly when the current task finishes.
+	var current string
+	for _, id := range order {
+		if s := schedules[id]; s.Slack == 0 && s.EarliestStart == 0 {
+			current = id
+			break
+		}
+	}
+	for current != "" {
+		path.Tasks = append(path.Tasks, g.tasks[current])
+		finish := schedules[current].EarliestFinish
+
+		next := ""
+		for _, dependent := range g.dependents[current] {
+			if s := schedules[dependent]; s.Slack == 0 && s.EarliestStart == finish {
+				next = dependent
+				break
+			}
+		}
+		current = next
+	}
+
+	return path, nil
+}
+
+func taskDuration(task SingleTask) time.Duration {
+	if task.TimeEstimate > 0 {
+		return time.Duration(task.TimeEstimate) * time.Millisecond
+	}
+	if task.StartDate.IsZero() || task.DueDate.IsZero() || !task.DueDate.After(task.StartDate.Time) {
+		return 0
+	}
+	return task.DueDate.Sub(task.StartDate.Time)
+}
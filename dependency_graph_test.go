@@ -0,0 +1,122 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func dependentTask(id string, estimate int, dependsOn ...string) SingleTask {
+	task := SingleTask{ID: id, TimeEstimate: estimate}
+	for _, dep := range dependsOn {
+		task.Dependencies = append(task.Dependencies, TaskDependency{TaskID: id, DependsOn: dep})
+	}
+	return task
+}
+
+func taskIDs(tasks []SingleTask) string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestDependencyGraph(t *testing.T) {
+	tests := []struct {
+		name             string
+		tasks            []SingleTask
+		wantSorted       string
+		wantCycles       int
+		wantCriticalPath string
+		wantDuration     time.Duration
+		wantErr          bool
+	}{
+		{
+			name: "Diamond with a longer branch",
+			tasks: []SingleTask{
+				dependentTask("release", 1000, "build", "docs"),
+				dependentTask("docs", 1000, "design"),
+				dependentTask("build", 5000, "design"),
+				dependentTask("design", 2000),
+				dependentTask("unrelated", 500, "not in set"),
+			},
+			wantSorted:       "design,unrelated,docs,build,release",
+			wantCriticalPath: "design,build,release",
+			wantDuration:     8 * time.Second,
+		},
+		{
+			name: "Duration from start and due dates",
+			tasks: []SingleTask{
+				{ID: "a", StartDate: TimestampFromMillis(1000), DueDate: TimestampFromMillis(4000)},
+				dependentTask("b", 0, "a"),
+			},
+			wantSorted:       "a,b",
+			wantCriticalPath: "a,b",
+			wantDuration:     3 * time.Second,
+		},
+		{
+			name: "Cycle",
+			tasks: []SingleTask{
+				dependentTask("a", 1, "c"),
+				dependentTask("b", 1, "a"),
+				dependentTask("c", 1, "b"),
+				dependentTask("d", 1),
+			},
+			wantCycles: 1,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewDependencyGraph(tt.tasks)
+
+			if cycles := g.Cycles(); len(cycles) != tt.wantCycles {
+				t.Errorf("DependencyGraph.Cycles() = %v, want %d cycles", cycles, tt.wantCycles)
+			}
+
+			sorted, err := g.TopologicalSort()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DependencyGraph.TopologicalSort() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				if !errors.Is(err, ErrDependencyCycle) {
+					t.Errorf("DependencyGraph.TopologicalSort() error = %v, want ErrDependencyCycle", err)
+				}
+				return
+			}
+			if got := taskIDs(sorted); got != tt.wantSorted {
+				t.Errorf("DependencyGraph.TopologicalSort() = %v, want %v", got, tt.wantSorted)
+			}
+
+			path, err := g.CriticalPath()
+			if err != nil {
+				t.Fatalf("DependencyGraph.CriticalPath() error = %v", err)
+			}
+			if got := taskIDs(path.Tasks); got != tt.wantCriticalPath {
+				t.Errorf("DependencyGraph.CriticalPath() = %v, want %v", got, tt.wantCriticalPath)
+			}
+			if path.Duration != tt.wantDuration {
+				t.Errorf("DependencyGraph.CriticalPath().Duration = %v, want %v", path.Duration, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestDependencyGraph_LinkedTo(t *testing.T) {
+	g := NewDependencyGraph([]SingleTask{
+		{ID: "a", LinkedTasks: []TaskLink{{TaskID: "a", LinkID: "b"}}},
+		{ID: "b", LinkedTasks: []TaskLink{{TaskID: "a", LinkID: "b"}}},
+	})
+	if got := g.LinkedTo("b"); len(got) != 1 || got[0] != "a" {
+		t.Errorf("DependencyGraph.LinkedTo() = %v, want [a]", got)
+	}
+}
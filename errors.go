@@ -11,10 +11,18 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 var ErrValidation = errors.New("invalid input provided")
 
+// ErrTaskCycle is returned when a task hierarchy refers back to itself.
+var ErrTaskCycle = errors.New("cycle detected in task hierarchy")
+
+// ErrDependencyCycle is returned when tasks depend on each other in a loop.
+var ErrDependencyCycle = errors.New("cycle detected in task dependencies")
+
 type RateLimitError struct {
 	msg       string
 	limit     string
@@ -31,6 +39,16 @@ func (r *RateLimitError) Unwrap() error {
 	return r.cause
 }
 
+// ResetAt returns the time at which the rate limit resets.  ok is false if Clickup
+// did not provide a usable x-ratelimit-reset header.
+func (r *RateLimitError) ResetAt() (resetAt time.Time, ok bool) {
+	secs, err := strconv.ParseInt(r.resetAt, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0), true
+}
+
 type HTTPError struct {
 	Status     string
 	StatusCode int
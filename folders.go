@@ -7,7 +7,9 @@
 package clickup
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -36,12 +38,10 @@ type SingleFolder struct {
 		} `json:"priority"`
 		Assignee struct {
 		} `json:"assignee"`
-		TaskCount int `json:"task_count"`
-		DueDate   struct {
-		} `json:"due_date"`
-		StartDate struct {
-		} `json:"start_date"`
-		Space struct {
+		TaskCount int       `json:"task_count"`
+		DueDate   Timestamp `json:"due_date"`
+		StartDate Timestamp `json:"start_date"`
+		Space     struct {
 			ID     string `json:"id"`
 			Name   string `json:"name"`
 			Access bool   `json:"access"`
@@ -93,3 +93,75 @@ func (c *Client) FolderByID(ctx context.Context, folderID string) (*SingleFolder
 
 	return &folder, nil
 }
+
+type CreateFolderRequest struct {
+	SpaceID string `json:"-"`
+	Name    string `json:"name"`
+}
+
+// CreateFolder adds a new folder to the space with folder.SpaceID.
+func (c *Client) CreateFolder(ctx context.Context, folder CreateFolderRequest) (*SingleFolder, error) {
+	if folder.SpaceID == "" {
+		return nil, fmt.Errorf("must provide a space id to create a folder: %w", ErrValidation)
+	}
+	if folder.Name == "" {
+		return nil, fmt.Errorf("must provide a name for a new folder: %w", ErrValidation)
+	}
+
+	b, err := json.Marshal(folder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize new folder: %w", err)
+	}
+	buf := bytes.NewBuffer(b)
+
+	endpoint := fmt.Sprintf("/space/%s/folder", folder.SpaceID)
+
+	var newFolder SingleFolder
+
+	if err := c.call(ctx, http.MethodPost, endpoint, buf, &newFolder); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &newFolder, nil
+}
+
+// UpdateFolderRequest uses patch semantics.  Only explicitly set fields are sent to Clickup.
+type UpdateFolderRequest struct {
+	ID   string         `json:"-"`
+	Name OptionalString `json:"name"`
+}
+
+func (u UpdateFolderRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
+}
+
+// UpdateFolder changes the folder with folder.ID.
+func (c *Client) UpdateFolder(ctx context.Context, folder UpdateFolderRequest) (*SingleFolder, error) {
+	if folder.ID == "" {
+		return nil, fmt.Errorf("must provide a folder id to update: %w", ErrValidation)
+	}
+
+	b, err := json.Marshal(folder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize folder: %w", err)
+	}
+	buf := bytes.NewBuffer(b)
+
+	endpoint := fmt.Sprintf("/folder/%s", folder.ID)
+
+	var updatedFolder SingleFolder
+
+	if err := c.call(ctx, http.MethodPut, endpoint, buf, &updatedFolder); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &updatedFolder, nil
+}
+
+// DeleteFolder removes the folder with folderID and its lists.
+func (c *Client) DeleteFolder(ctx context.Context, folderID string) error {
+	if folderID == "" {
+		return fmt.Errorf("must provide a folder id to delete: %w", ErrValidation)
+	}
+	return c.call(ctx, http.MethodDelete, fmt.Sprintf("/folder/%s", folderID), nil, &struct{}{})
+}
@@ -213,3 +213,140 @@ func TestClient_FolderByID(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_CreateFolder(t *testing.T) {
+	tests := []struct {
+		name     string
+		folder   CreateFolderRequest
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "TestSuccessful folder created",
+			folder:   CreateFolderRequest{SpaceID: "fakeSpaceID", Name: "Roadmap"},
+			wantBody: `{"name":"Roadmap"}`,
+		},
+		{
+			name:    "TestFail Missing Space ID",
+			folder:  CreateFolderRequest{Name: "Roadmap"},
+			wantErr: true,
+		},
+		{
+			name:    "TestFail Missing Name",
+			folder:  CreateFolderRequest{SpaceID: "fakeSpaceID"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPost || req.URL.Path != "/space/fakeSpaceID/folder" {
+						t.Errorf("request = %s %s, want POST /space/fakeSpaceID/folder", req.Method, req.URL.Path)
+					}
+					b, _ := ioutil.ReadAll(req.Body)
+					if string(b) != tt.wantBody {
+						t.Errorf("body = %s, want %s", b, tt.wantBody)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id":"f1","name":"Roadmap"}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			got, err := c.CreateFolder(context.Background(), tt.folder)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.CreateFolder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.ID != "f1" {
+				t.Errorf("Client.CreateFolder() id = %s, want f1", got.ID)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateFolder(t *testing.T) {
+	tests := []struct {
+		name     string
+		folder   UpdateFolderRequest
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "TestSuccessful folder renamed",
+			folder:   UpdateFolderRequest{ID: "fakeFolderID", Name: OptString("Roadmap")},
+			wantBody: `{"name":"Roadmap"}`,
+		},
+		{
+			name:     "TestSuccessful unset fields are not sent",
+			folder:   UpdateFolderRequest{ID: "fakeFolderID"},
+			wantBody: `{}`,
+		},
+		{
+			name:    "TestFail Missing Folder ID",
+			folder:  UpdateFolderRequest{Name: OptString("Roadmap")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPut || req.URL.Path != "/folder/fakeFolderID" {
+						t.Errorf("request = %s %s, want PUT /folder/fakeFolderID", req.Method, req.URL.Path)
+					}
+					b, _ := ioutil.ReadAll(req.Body)
+					if string(b) != tt.wantBody {
+						t.Errorf("body = %s, want %s", b, tt.wantBody)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id":"fakeFolderID","name":"Roadmap"}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			got, err := c.UpdateFolder(context.Background(), tt.folder)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.UpdateFolder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Name != "Roadmap" {
+				t.Errorf("Client.UpdateFolder() name = %s, want Roadmap", got.Name)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteFolder(t *testing.T) {
+	tests := []struct {
+		name     string
+		folderID string
+		wantErr  bool
+	}{
+		{name: "TestSuccessful folder deleted", folderID: "fakeFolderID"},
+		{name: "TestFail Missing Folder ID", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodDelete || req.URL.Path != "/folder/fakeFolderID" {
+						t.Errorf("request = %s %s, want DELETE /folder/fakeFolderID", req.Method, req.URL.Path)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			if err := c.DeleteFolder(context.Background(), tt.folderID); (err != nil) != tt.wantErr {
+				t.Errorf("Client.DeleteFolder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package format
+
+import (
+	"strconv"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// Tasks flattens tasks into a table.  Each custom field that is set on any task becomes a column named
+// with CustomFieldPrefix, holding the value from SingleTask.CustomFieldStrings.
+func Tasks(tasks []clickup.SingleTask, opts *Options) Table {
+	rows := make([]row, 0, len(tasks))
+	for _, task := range tasks {
+		var r row
+		r.add("id", task.ID)
+		r.add("custom_id", task.CustomID)
+		r.add("name", task.Name)
+		r.add("description", task.Description)
+		r.add("status", task.Status.Status)
+		r.add("status_type", task.Status.Type)
+		r.add("priority", task.Priority.Priority)
+		r.add("assignees", joinStrings(clickup.Usernames(task.Assignees)))
+		r.add("watchers", joinStrings(clickup.Usernames(task.Watchers)))
+		r.add("creator", task.Creator.Username)
+		r.add("tags", joinStrings(clickup.TagNames(task.Tags)))
+		r.add("parent", task.Parent)
+		r.add("list_id", task.List.ID)
+		r.add("list", task.List.Name)
+		r.add("folder_id", task.Folder.ID)
+		r.add("folder", task.Folder.Name)
+		r.add("space_id", task.Space.ID)
+		r.add("start_date", opts.formatTimestamp(task.StartDate))
+		r.add("due_date", opts.formatTimestamp(task.DueDate))
+		r.add("date_created", opts.formatTimestamp(task.DateCreated))
+		r.add("date_updated", opts.formatTimestamp(task.DateUpdated))
+		r.add("date_closed", opts.formatTimestamp(task.DateClosed))
+		r.add("points", floatString(task.Points))
+		r.add("time_estimate", intString(task.TimeEstimate))
+		r.add("time_spent", intString(task.TimeSpent))
+		r.add("archived", boolString(task.Archived))
+		r.add("url", task.URL)
+
+		for i, field := range task.CustomFields {
+			r.add(CustomFieldPrefix+field.Name, opts.customFieldValue(task, i))
+		}
+		rows = append(rows, r)
+	}
+	return tableOf(rows)
+}
+
+// customFieldValue flattens the i'th custom field of task.  Lists, such as labels and users fields, are
+// written comma separated.
+func (o *Options) customFieldValue(task clickup.SingleTask, i int) string {
+	values := task.CustomFieldStrings(i)
+	if task.CustomFields[i].Type == "date" && len(values) == 1 {
+		if ms, err := strconv.ParseInt(values[0], 10, 64); err == nil {
+			return o.formatTimestamp(clickup.TimestampFromMillis(ms))
+		}
+	}
+	return joinStrings(values)
+}
+
+// Lists flattens lists into a table.
+func Lists(lists []clickup.SingleList, opts *Options) Table {
+	rows := make([]row, 0, len(lists))
+	for _, list := range lists {
+		var r row
+		r.add("id", list.ID)
+		r.add("name", list.Name)
+		r.add("task_count", intString(list.TaskCount))
+		r.add("start_date", opts.formatTimestamp(list.StartDate))
+		r.add("due_date", opts.formatTimestamp(list.DueDate))
+		r.add("folder_id", list.Folder.ID)
+		r.add("folder", list.Folder.Name)
+		r.add("space_id", list.Space.ID)
+		r.add("space", list.Space.Name)
+		r.add("archived", boolString(list.Archived))
+		rows = append(rows, r)
+	}
+	return tableOf(rows)
+}
+
+// Folders flattens folders into a table.
+func Folders(folders []clickup.SingleFolder, opts *Options) Table {
+	rows := make([]row, 0, len(folders))
+	for _, folder := range folders {
+		var r row
+		r.add("id", folder.ID)
+		r.add("name", folder.Name)
+		r.add("task_count", folder.TaskCount)
+		r.add("list_count", intString(len(folder.Lists)))
+		r.add("space_id", folder.Space.ID)
+		r.add("space", folder.Space.Name)
+		r.add("hidden", boolString(folder.Hidden))
+		r.add("archived", boolString(folder.Archived))
+		rows = append(rows, r)
+	}
+	return tableOf(rows)
+}
+
+// Spaces flattens spaces into a table.
+func Spaces(spaces []clickup.SingleSpace, opts *Options) Table {
+	rows := make([]row, 0, len(spaces))
+	for _, space := range spaces {
+		statuses := make([]string, 0, len(space.Statuses))
+		for _, status := range space.Statuses {
+			statuses = append(statuses, status.Status)
+		}
+
+		var r row
+		r.add("id", space.ID)
+		r.add("name", space.Name)
+		r.add("private", boolString(space.Private))
+		r.add("multiple_assignees", boolString(space.MultipleAssignees))
+		r.add("statuses", joinStrings(statuses))
+		rows = append(rows, r)
+	}
+	return tableOf(rows)
+}
+
+// Goals flattens goals into a table.
+func Goals(goals []clickup.GoalResponse, opts *Options) Table {
+	rows := make([]row, 0, len(goals))
+	for _, goal := range goals {
+		var r row
+		r.add("id", goal.ID)
+		r.add("pretty_id", goal.PrettyID)
+		r.add("name", goal.Name)
+		r.add("owners", joinStrings(clickup.Usernames(goal.Owners)))
+		r.add("percent_completed", intString(goal.PercentCompleted))
+		r.add("key_result_count", intString(goal.KeyResultCount))
+		r.add("folder_id", goal.FolderID)
+		r.add("start_date", opts.formatTimestamp(goal.StartDate))
+		r.add("due_date", opts.formatTimestamp(goal.DueDate))
+		r.add("date_created", opts.formatTimestamp(goal.DateCreated))
+		r.add("date_updated", opts.formatTimestamp(goal.DateUpdated))
+		r.add("private", boolString(goal.Private))
+		r.add("archived", boolString(goal.Archived))
+		rows = append(rows, r)
+	}
+	return tableOf(rows)
+}
+
+// Webhooks flattens webhooks into a table.  Webhook secrets are left out.
+func Webhooks(webhooks []clickup.Webhook, opts *Options) Table {
+	rows := make([]row, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		events := make([]string, 0, len(webhook.Events))
+		for _, event := range webhook.Events {
+			events = append(events, string(event))
+		}
+
+		var r row
+		r.add("id", webhook.ID)
+		r.add("endpoint", webhook.Endpoint)
+		r.add("events", joinStrings(events))
+		r.add("task_id", optionalID(webhook.TaskID))
+		r.add("list_id", optionalID(webhook.ListID))
+		r.add("folder_id", optionalID(webhook.FolderID))
+		r.add("space_id", optionalID(webhook.SpaceID))
+		if webhook.Health != nil {
+			r.add("status", webhook.Health.Status)
+			r.add("fail_count", intString(webhook.Health.FailCount))
+		} else {
+			r.add("status", "")
+			r.add("fail_count", "")
+		}
+		rows = append(rows, r)
+	}
+	return tableOf(rows)
+}
+
+func optionalID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return intString(id)
+}
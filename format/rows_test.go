@@ -0,0 +1,184 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package format
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+const tasksJSON = `[
+	{
+		"id": "t1",
+		"custom_id": "ABC-1",
+		"name": "Write docs",
+		"status": {"status": "in progress", "type": "custom"},
+		"priority": {"priority": "high"},
+		"assignees": [{"username": "ana"}, {"username": "bo"}],
+		"tags": [{"name": "docs"}, {"name": "q3"}],
+		"list": {"id": "l1", "name": "Backlog"},
+		"due_date": "1656633600000",
+		"points": 3,
+		"custom_fields": [
+			{"name": "Sprint", "type": "drop_down", "value": "o2", "type_config": {"options": [
+				{"id": "o1", "name": "Sprint 1"},
+				{"id": "o2", "name": "Sprint 2"}
+			]}},
+			{"name": "Kickoff", "type": "date", "value": "1656720000000"}
+		]
+	},
+	{
+		"id": "t2",
+		"name": "Fix bug",
+		"status": {"status": "open", "type": "open"},
+		"custom_fields": [
+			{"name": "Estimate", "type": "number", "value": 2.5}
+		]
+	}
+]`
+
+func TestTasks(t *testing.T) {
+	var tasks []clickup.SingleTask
+	if err := json.Unmarshal([]byte(tasksJSON), &tasks); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    *Options
+		columns []string
+		want    [][]string
+	}{
+		{
+			name:    "Flattened fields",
+			columns: []string{"id", "custom_id", "status", "priority", "assignees", "tags", "list", "points"},
+			want: [][]string{
+				{"t1", "ABC-1", "in progress", "high", "ana,bo", "docs,q3", "Backlog", "3"},
+				{"t2", "", "open", "", "", "", "", "0"},
+			},
+		},
+		{
+			name:    "Custom fields from any task",
+			columns: []string{"cf:Sprint", "cf:Kickoff", "cf:Estimate"},
+			want: [][]string{
+				{"Sprint 2", "2022-07-02T00:00:00Z", ""},
+				{"", "", "2.5"},
+			},
+		},
+		{
+			name:    "Unix milliseconds",
+			opts:    &Options{TimeFormat: UnixMillis},
+			columns: []string{"due_date", "cf:Kickoff", "date_closed"},
+			want: [][]string{
+				{"1656633600000", "1656720000000", ""},
+				{"", "", ""},
+			},
+		},
+		{
+			name:    "Layout and location",
+			opts:    &Options{TimeFormat: "2006-01-02 15:04", Location: time.FixedZone("UTC-5", -5*60*60)},
+			columns: []string{"due_date"},
+			want:    [][]string{{"2022-06-30 19:00"}, {""}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Tasks(tasks, tt.opts).Select(tt.columns...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Rows, tt.want) {
+				t.Errorf("rows = %q, want %q", got.Rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestOtherRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   func() (Table, error)
+		columns []string
+		want    [][]string
+	}{
+		{
+			name: "Lists",
+			table: func() (Table, error) {
+				var lists []clickup.SingleList
+				err := json.Unmarshal([]byte(`[{"id":"l1","name":"Backlog","task_count":4,"folder":{"name":"Eng"},"due_date":"1656633600000"}]`), &lists)
+				return Lists(lists, nil), err
+			},
+			columns: []string{"id", "name", "task_count", "folder", "due_date", "start_date"},
+			want:    [][]string{{"l1", "Backlog", "4", "Eng", "2022-07-01T00:00:00Z", ""}},
+		},
+		{
+			name: "Folders",
+			table: func() (Table, error) {
+				var folders []clickup.SingleFolder
+				err := json.Unmarshal([]byte(`[{"id":"f1","name":"Eng","lists":[{"id":"l1"},{"id":"l2"}],"space":{"id":"s1"}}]`), &folders)
+				return Folders(folders, nil), err
+			},
+			columns: []string{"id", "name", "list_count", "space_id"},
+			want:    [][]string{{"f1", "Eng", "2", "s1"}},
+		},
+		{
+			name: "Spaces",
+			table: func() (Table, error) {
+				var spaces []clickup.SingleSpace
+				err := json.Unmarshal([]byte(`[{"id":"s1","name":"Product","private":true,"statuses":[{"status":"open"},{"status":"closed"}]}]`), &spaces)
+				return Spaces(spaces, nil), err
+			},
+			columns: []string{"id", "name", "private", "statuses"},
+			want:    [][]string{{"s1", "Product", "true", "open,closed"}},
+		},
+		{
+			name: "Goals",
+			table: func() (Table, error) {
+				var goals []clickup.GoalResponse
+				err := json.Unmarshal([]byte(`[{"id":"g1","name":"Ship","percent_completed":40,"owners":[{"username":"ana"}]}]`), &goals)
+				return Goals(goals, nil), err
+			},
+			columns: []string{"id", "name", "percent_completed", "owners"},
+			want:    [][]string{{"g1", "Ship", "40", "ana"}},
+		},
+		{
+			name: "Webhooks",
+			table: func() (Table, error) {
+				var webhooks []clickup.Webhook
+				err := json.Unmarshal([]byte(`[
+					{"id":"w1","endpoint":"https://example.com","events":["taskCreated","taskUpdated"],"list_id":12,"health":{"status":"active","fail_count":1}},
+					{"id":"w2","endpoint":"https://example.org","events":["*"]}
+				]`), &webhooks)
+				return Webhooks(webhooks, nil), err
+			},
+			columns: []string{"id", "events", "list_id", "task_id", "status", "fail_count"},
+			want: [][]string{
+				{"w1", "taskCreated,taskUpdated", "12", "", "active", "1"},
+				{"w2", "*", "", "", "", ""},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := tt.table()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := table.Select(tt.columns...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Rows, tt.want) {
+				t.Errorf("rows = %q, want %q", got.Rows, tt.want)
+			}
+		})
+	}
+}
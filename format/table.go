@@ -0,0 +1,169 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package format flattens Clickup objects into rows and writes them as JSON, YAML, CSV, an aligned
+// text table or through a Go template.
+//
+//	tasks, _ := client.AllTasksForList(ctx, listID, nil)
+//	table, _ := format.Tasks(tasks, nil).Select("id", "name", "status", "cf:Sprint")
+//	table.Write(os.Stdout, format.CSV)
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// Table is a set of flattened records.  Every row has one value for each column.
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Options controls how values are flattened.  A nil *Options uses the defaults.
+type Options struct {
+	// TimeFormat is the layout used for dates.  It defaults to time.RFC3339.  Use UnixMillis to keep
+	// the unix milliseconds Clickup uses.
+	TimeFormat string
+	// Location is the time zone dates are written in.  It defaults to UTC.
+	Location *time.Location
+}
+
+// UnixMillis is a TimeFormat that writes dates as unix milliseconds.
+const UnixMillis = "unix_ms"
+
+// CustomFieldPrefix starts the name of the column for each task custom field, as in "cf:Sprint".
+const CustomFieldPrefix = "cf:"
+
+func (o *Options) formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	layout, loc := time.RFC3339, time.UTC
+	if o != nil && o.TimeFormat != "" {
+		layout = o.TimeFormat
+	}
+	if o != nil && o.Location != nil {
+		loc = o.Location
+	}
+	if layout == UnixMillis {
+		return strconv.FormatInt(clickup.NewTimestamp(t).Millis(), 10)
+	}
+	return t.In(loc).Format(layout)
+}
+
+func (o *Options) formatTimestamp(t clickup.Timestamp) string {
+	return o.formatTime(t.Time)
+}
+
+// Select returns a table with only columns, in that order.  Column names are matched ignoring case.
+func (t Table) Select(columns ...string) (Table, error) {
+	if len(columns) == 0 {
+		return t, nil
+	}
+
+	indexes := make([]int, 0, len(columns))
+	selected := make([]string, 0, len(columns))
+	for _, column := range columns {
+		i := t.index(column)
+		if i < 0 {
+			return Table{}, fmt.Errorf("unknown column %q: columns are %s", column, strings.Join(t.Columns, ", "))
+		}
+		indexes = append(indexes, i)
+		selected = append(selected, t.Columns[i])
+	}
+
+	rows := make([][]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		values := make([]string, len(indexes))
+		for j, i := range indexes {
+			values[j] = row[i]
+		}
+		rows = append(rows, values)
+	}
+	return Table{Columns: selected, Rows: rows}, nil
+}
+
+func (t Table) index(column string) int {
+	for i, c := range t.Columns {
+		if c == column {
+			return i
+		}
+	}
+	for i, c := range t.Columns {
+		if strings.EqualFold(c, column) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Records returns the rows as maps from column name to value.
+func (t Table) Records() []map[string]string {
+	records := make([]map[string]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		record := make(map[string]string, len(t.Columns))
+		for i, column := range t.Columns {
+			record[column] = row[i]
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// row builds one table row from column/value pairs in column order.
+type row struct {
+	columns []string
+	values  []string
+}
+
+func (r *row) add(column, value string) {
+	r.columns = append(r.columns, column)
+	r.values = append(r.values, value)
+}
+
+// tableOf builds a table from rows that share their leading columns and may each add extra
+// columns, such as task custom fields.  Extra columns are ordered by first appearance.
+func tableOf(rows []row) Table {
+	var table Table
+	index := make(map[string]int)
+	for _, r := range rows {
+		for _, column := range r.columns {
+			if _, ok := index[column]; !ok {
+				index[column] = len(table.Columns)
+				table.Columns = append(table.Columns, column)
+			}
+		}
+	}
+	for _, r := range rows {
+		values := make([]string, len(table.Columns))
+		for i, column := range r.columns {
+			values[index[column]] = r.values[i]
+		}
+		table.Rows = append(table.Rows, values)
+	}
+	return table
+}
+
+func joinStrings(values []string) string {
+	return strings.Join(values, ",")
+}
+
+func boolString(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+func intString(n int) string {
+	return strconv.Itoa(n)
+}
+
+func floatString(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
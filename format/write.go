@@ -0,0 +1,198 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package format
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// Format is an output format for a Table.
+type Format string
+
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+	CSV  Format = "csv"
+	Text Format = "table"
+)
+
+// ParseFormat returns the Format named s, ignoring case.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case JSON, YAML, CSV, Text:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown output format %q: use json, yaml, csv or table", s)
+}
+
+// Write writes the table to w in format f.  JSON and YAML are written as a list of objects with
+// keys in column order.
+func (t Table) Write(w io.Writer, f Format) error {
+	switch f {
+	case JSON:
+		return t.writeJSON(w)
+	case YAML:
+		return t.writeYAML(w)
+	case CSV:
+		return t.writeCSV(w)
+	case Text:
+		return t.writeText(w)
+	}
+	return fmt.Errorf("unknown output format %q", f)
+}
+
+// WriteTemplate executes the Go template text once for each row, followed by a newline.  The row is
+// a map from column name to value, so columns are referenced as {{.name}} or {{index . "cf:Sprint"}}.
+func (t Table) WriteTemplate(w io.Writer, text string) error {
+	tmpl, err := template.New("row").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, record := range t.Records() {
+		if err := tmpl.Execute(bw, record); err != nil {
+			return err
+		}
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func (t Table) writeJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if len(t.Rows) == 0 {
+		bw.WriteString("[]\n")
+		return bw.Flush()
+	}
+
+	bw.WriteString("[\n")
+	for i, row := range t.Rows {
+		bw.WriteString("  {")
+		for j, column := range t.Columns {
+			if j > 0 {
+				bw.WriteByte(',')
+			}
+			bw.WriteString("\n    ")
+			bw.Write(jsonString(column))
+			bw.WriteString(": ")
+			bw.Write(jsonString(row[j]))
+		}
+		bw.WriteString("\n  }")
+		if i < len(t.Rows)-1 {
+			bw.WriteByte(',')
+		}
+		bw.WriteByte('\n')
+	}
+	bw.WriteString("]\n")
+	return bw.Flush()
+}
+
+func jsonString(s string) []byte {
+	// Marshalling a string cannot fail.
+	b, _ := json.Marshal(s)
+	return b
+}
+
+func (t Table) writeYAML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if len(t.Rows) == 0 {
+		bw.WriteString("[]\n")
+		return bw.Flush()
+	}
+
+	for _, row := range t.Rows {
+		for j, column := range t.Columns {
+			if j == 0 {
+				bw.WriteString("- ")
+			} else {
+				bw.WriteString("  ")
+			}
+			bw.WriteString(yamlString(column))
+			bw.WriteString(": ")
+			bw.WriteString(yamlString(row[j]))
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+// yamlString returns s as a YAML scalar that reads back as the same string.  Values that are plain
+// words are left bare, anything that YAML could read as another type or as syntax is double quoted.
+func yamlString(s string) string {
+	if s == "" || !yamlPlain(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func yamlPlain(s string) bool {
+	switch strings.ToLower(s) {
+	case "~", "null", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return false
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return false
+	}
+	if strings.TrimSpace(s) != s || strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return false
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return false
+	}
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+func (t Table) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func (t Table) writeText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		header[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, row := range t.Rows {
+		values := make([]string, len(row))
+		for i, value := range row {
+			values[i] = textCell(value)
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+// textCell keeps a value on one line and out of the way of the column separator.
+func textCell(s string) string {
+	return strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+}
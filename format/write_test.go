@@ -0,0 +1,135 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package format
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+var testTable = Table{
+	Columns: []string{"id", "name", "cf:Sprint"},
+	Rows: [][]string{
+		{"1", "Write docs", "Sprint 2"},
+		{"2", "Fix: \"quoted\", bug", ""},
+	},
+}
+
+func TestTable_Write(t *testing.T) {
+	tests := []struct {
+		name   string
+		table  Table
+		format Format
+		want   string
+	}{
+		{
+			name:   "JSON keeps column order",
+			table:  testTable,
+			format: JSON,
+			want: `[
+  {
+    "id": "1",
+    "name": "Write docs",
+    "cf:Sprint": "Sprint 2"
+  },
+  {
+    "id": "2",
+    "name": "Fix: \"quoted\", bug",
+    "cf:Sprint": ""
+  }
+]
+`,
+		},
+		{
+			name:   "Empty JSON",
+			table:  Table{Columns: []string{"id"}},
+			format: JSON,
+			want:   "[]\n",
+		},
+		{
+			name:   "YAML quotes ambiguous values",
+			table:  testTable,
+			format: YAML,
+			want: `- id: "1"
+  name: Write docs
+  cf:Sprint: Sprint 2
+- id: "2"
+  name: "Fix: \"quoted\", bug"
+  cf:Sprint: ""
+`,
+		},
+		{
+			name:   "CSV",
+			table:  testTable,
+			format: CSV,
+			want:   "id,name,cf:Sprint\n1,Write docs,Sprint 2\n2,\"Fix: \"\"quoted\"\", bug\",\n",
+		},
+		{
+			name:   "Aligned table",
+			table:  testTable,
+			format: Text,
+			want: "ID  NAME                CF:SPRINT\n" +
+				"1   Write docs          Sprint 2\n" +
+				"2   Fix: \"quoted\", bug  \n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.table.Write(&buf, tt.format); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Write() =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_WriteTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testTable.WriteTemplate(&buf, `{{.id}}: {{index . "cf:Sprint"}}`); err != nil {
+		t.Fatal(err)
+	}
+	if want := "1: Sprint 2\n2: \n"; buf.String() != want {
+		t.Errorf("WriteTemplate() = %q, want %q", buf.String(), want)
+	}
+
+	if err := testTable.WriteTemplate(&buf, `{{.missing}}`); err == nil {
+		t.Error("WriteTemplate() with an unknown column should fail")
+	}
+}
+
+func TestTable_Select(t *testing.T) {
+	got, err := testTable.Select("CF:sprint", "ID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Table{
+		Columns: []string{"cf:Sprint", "id"},
+		Rows:    [][]string{{"Sprint 2", "1"}, {"", "2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Select() = %v, want %v", got, want)
+	}
+
+	if _, err := testTable.Select("nope"); err == nil {
+		t.Error("Select() with an unknown column should fail")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, s := range []string{"json", "YAML", "csv", "table"} {
+		if _, err := ParseFormat(s); err != nil {
+			t.Errorf("ParseFormat(%q) = %v", s, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml) should fail")
+	}
+}
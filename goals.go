@@ -17,13 +17,13 @@ import (
 )
 
 type CreateGoalRequest struct {
-	WorkspaceID    string `json:"-"`
-	Name           string `json:"name"`
-	DueDate        int    `json:"due_date"`
-	Description    string `json:"description"`
-	MultipleOwners bool   `json:"multiple_owners"`
-	Owners         []int  `json:"owners"`
-	Color          string `json:"color"`
+	WorkspaceID    string    `json:"-"`
+	Name           string    `json:"name"`
+	DueDate        Timestamp `json:"due_date"`
+	Description    string    `json:"description"`
+	MultipleOwners bool      `json:"multiple_owners"`
+	Owners         []int     `json:"owners"`
+	Color          string    `json:"color"`
 }
 
 type CreateGoalResponse struct {
@@ -31,9 +31,9 @@ type CreateGoalResponse struct {
 		ID               string      `json:"id"`
 		Name             string      `json:"name"`
 		TeamID           string      `json:"team_id"`
-		DateCreated      string      `json:"date_created"`
-		StartDate        string      `json:"start_date"`
-		DueDate          string      `json:"due_date"`
+		DateCreated      Timestamp   `json:"date_created"`
+		StartDate        Timestamp   `json:"start_date"`
+		DueDate          Timestamp   `json:"due_date"`
 		Description      string      `json:"description"`
 		Private          bool        `json:"private"`
 		Archived         bool        `json:"archived"`
@@ -72,14 +72,22 @@ func (c *Client) CreateGoal(ctx context.Context, goal CreateGoalRequest) (*Creat
 	return &newGoal, nil
 }
 
+// UpdateGoalRequest uses patch semantics.  Only explicitly set fields are sent to Clickup.
+// A null FolderID removes the goal from its folder.
 type UpdateGoalRequest struct {
-	ID             string `json:"-"`
-	Name           string `json:"name,omitempty"`
-	DueDate        int    `json:"due_date,omitempty"`
-	Description    string `json:"description,omitempty"`
-	MultipleOwners bool   `json:"multiple_owners,omitempty"`
-	Owners         []int  `json:"owners,omitempty"`
-	Color          string `json:"color,omitempty"`
+	ID             string            `json:"-"`
+	Name           OptionalString    `json:"name"`
+	DueDate        OptionalTimestamp `json:"due_date"`
+	Description    OptionalString    `json:"description"`
+	MultipleOwners OptionalBool      `json:"multiple_owners"`
+	AddOwners      []int             `json:"add_owners"`
+	RemoveOwners   []int             `json:"rem_owners"`
+	Color          OptionalString    `json:"color"`
+	FolderID       OptionalString    `json:"folder_id"`
+}
+
+func (u UpdateGoalRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
 }
 
 type UpdateGoalResponse struct {
@@ -87,9 +95,9 @@ type UpdateGoalResponse struct {
 		ID               string      `json:"id"`
 		Name             string      `json:"name"`
 		TeamID           string      `json:"team_id"`
-		DateCreated      string      `json:"date_created"`
-		StartDate        string      `json:"start_date"`
-		DueDate          string      `json:"due_date"`
+		DateCreated      Timestamp   `json:"date_created"`
+		StartDate        Timestamp   `json:"start_date"`
+		DueDate          Timestamp   `json:"due_date"`
 		Description      string      `json:"description"`
 		Private          bool        `json:"private"`
 		Archived         bool        `json:"archived"`
@@ -129,31 +137,62 @@ func (c *Client) UpdateGoal(ctx context.Context, goal UpdateGoalRequest) (*Updat
 }
 
 type GoalResponse struct {
-	ID               string     `json:"id"`
-	PrettyID         string     `json:"pretty_id"`
-	Name             string     `json:"name"`
-	TeamID           string     `json:"team_id"`
-	Creator          int        `json:"creator"`
-	Color            string     `json:"color"`
-	DateCreated      string     `json:"date_created"`
-	StartDate        string     `json:"start_date"`
-	DueDate          string     `json:"due_date"`
-	Description      string     `json:"description"`
-	Private          bool       `json:"private"`
-	Archived         bool       `json:"archived"`
-	MultipleOwners   bool       `json:"multiple_owners"`
-	EditorToken      string     `json:"editor_token"`
-	DateUpdated      string     `json:"date_updated"`
-	LastUpdate       string     `json:"last_update"`
-	FolderID         string     `json:"folder_id"`
-	Pinned           bool       `json:"pinned"`
-	Owners           []TeamUser `json:"owners"`
-	KeyResultCount   int        `json:"key_result_count"`
-	PercentCompleted int        `json:"percent_completed"`
+	ID               string      `json:"id"`
+	PrettyID         string      `json:"pretty_id"`
+	Name             string      `json:"name"`
+	TeamID           string      `json:"team_id"`
+	Creator          int         `json:"creator"`
+	Color            string      `json:"color"`
+	DateCreated      Timestamp   `json:"date_created"`
+	StartDate        Timestamp   `json:"start_date"`
+	DueDate          Timestamp   `json:"due_date"`
+	Description      string      `json:"description"`
+	Private          bool        `json:"private"`
+	Archived         bool        `json:"archived"`
+	MultipleOwners   bool        `json:"multiple_owners"`
+	EditorToken      string      `json:"editor_token"`
+	DateUpdated      Timestamp   `json:"date_updated"`
+	LastUpdate       Timestamp   `json:"last_update"`
+	FolderID         string      `json:"folder_id"`
+	Pinned           bool        `json:"pinned"`
+	Owners           []TeamUser  `json:"owners"`
+	KeyResultCount   int         `json:"key_result_count"`
+	KeyResults       []KeyResult `json:"key_results"`
+	PercentCompleted int         `json:"percent_completed"`
 }
 
 type GetGoalsResponse struct {
-	Goals []GoalResponse `json:"goals"`
+	Goals   []GoalResponse `json:"goals"`
+	Folders []GoalFolder   `json:"folders"`
+}
+
+// AllGoals returns the goals of the workspace and of its goal folders, each once.  Goals listed in a
+// folder have FolderID set to it.
+func (r *GetGoalsResponse) AllGoals() []GoalResponse {
+	var goals []GoalResponse
+	index := make(map[string]int)
+	add := func(goal GoalResponse, folderID string) {
+		if folderID != "" {
+			goal.FolderID = folderID
+		}
+		if i, ok := index[goal.ID]; ok {
+			if goals[i].FolderID == "" {
+				goals[i].FolderID = goal.FolderID
+			}
+			return
+		}
+		index[goal.ID] = len(goals)
+		goals = append(goals, goal)
+	}
+	for _, goal := range r.Goals {
+		add(goal, "")
+	}
+	for _, folder := range r.Folders {
+		for _, goal := range folder.Goals {
+			add(goal, folder.ID)
+		}
+	}
+	return goals
 }
 
 // GoalsForWorkspace queries all goals in a workspace using workspaceID.  Completed goals will be returned
@@ -200,6 +239,113 @@ func (c *Client) DeleteGoal(ctx context.Context, goalID string) error {
 	return c.call(ctx, http.MethodDelete, fmt.Sprintf("/goal/%s", goalID), nil, &struct{}{})
 }
 
+// MoveGoalToFolder moves the goal with goalID into the goal folder with folderID.  An empty
+// folderID removes the goal from its folder.
+func (c *Client) MoveGoalToFolder(ctx context.Context, goalID, folderID string) (*UpdateGoalResponse, error) {
+	folder := OptString(folderID)
+	if folderID == "" {
+		folder = NullString()
+	}
+	return c.UpdateGoal(ctx, UpdateGoalRequest{ID: goalID, FolderID: folder})
+}
+
+type GoalFolder struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	TeamID      string         `json:"team_id"`
+	Private     bool           `json:"private"`
+	DateCreated Timestamp      `json:"date_created"`
+	Creator     int            `json:"creator"`
+	GoalCount   int            `json:"goal_count"`
+	Members     []TeamUser     `json:"members"`
+	Goals       []GoalResponse `json:"goals"`
+}
+
+// GoalFoldersForWorkspace returns the goal folders in the workspace with workspaceID.
+func (c *Client) GoalFoldersForWorkspace(ctx context.Context, workspaceID string) ([]GoalFolder, error) {
+	goals, err := c.GoalsForWorkspace(ctx, workspaceID, true)
+	if err != nil {
+		return nil, err
+	}
+	return goals.Folders, nil
+}
+
+type CreateGoalFolderRequest struct {
+	WorkspaceID string `json:"-"`
+	Name        string `json:"name"`
+}
+
+type GoalFolderResponse struct {
+	GoalFolder GoalFolder `json:"goal_folder"`
+}
+
+// CreateGoalFolder adds a new goal folder to the workspace with folder.WorkspaceID.
+func (c *Client) CreateGoalFolder(ctx context.Context, folder CreateGoalFolderRequest) (*GoalFolderResponse, error) {
+	if folder.WorkspaceID == "" {
+		return nil, fmt.Errorf("must provide a workspace id to create a goal folder: %w", ErrValidation)
+	}
+	if folder.Name == "" {
+		return nil, fmt.Errorf("must provide a name for a new goal folder: %w", ErrValidation)
+	}
+
+	b, err := json.Marshal(folder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize new goal folder: %w", err)
+	}
+	buf := bytes.NewBuffer(b)
+
+	endpoint := fmt.Sprintf("/team/%s/goal_folder", folder.WorkspaceID)
+
+	var newFolder GoalFolderResponse
+
+	if err := c.call(ctx, http.MethodPost, endpoint, buf, &newFolder); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &newFolder, nil
+}
+
+// UpdateGoalFolderRequest uses patch semantics.  Only explicitly set fields are sent to Clickup.
+type UpdateGoalFolderRequest struct {
+	ID   string         `json:"-"`
+	Name OptionalString `json:"name"`
+}
+
+func (u UpdateGoalFolderRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
+}
+
+// UpdateGoalFolder changes the existing goal folder with folder.ID.
+func (c *Client) UpdateGoalFolder(ctx context.Context, folder UpdateGoalFolderRequest) (*GoalFolderResponse, error) {
+	if folder.ID == "" {
+		return nil, fmt.Errorf("must provide a goal folder id to update a goal folder: %w", ErrValidation)
+	}
+
+	b, err := json.Marshal(folder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize goal folder: %w", err)
+	}
+	buf := bytes.NewBuffer(b)
+
+	endpoint := fmt.Sprintf("/goal_folder/%s", folder.ID)
+
+	var updatedFolder GoalFolderResponse
+
+	if err := c.call(ctx, http.MethodPut, endpoint, buf, &updatedFolder); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &updatedFolder, nil
+}
+
+// DeleteGoalFolder removes the goal folder with folderID.  Its goals are kept.
+func (c *Client) DeleteGoalFolder(ctx context.Context, folderID string) error {
+	if folderID == "" {
+		return fmt.Errorf("must provide a goal folder id to delete: %w", ErrValidation)
+	}
+	return c.call(ctx, http.MethodDelete, fmt.Sprintf("/goal_folder/%s", folderID), nil, &struct{}{})
+}
+
 type KeyResultType string
 
 const (
@@ -215,31 +361,36 @@ type CreateKeyResultRequest struct {
 	Name       string        `json:"name"`
 	Owners     []int         `json:"owners"`
 	Type       KeyResultType `json:"type"`
-	StepsStart int           `json:"steps_start"`
-	StepsEnd   int           `json:"steps_end"`
+	StepsStart float64       `json:"steps_start"`
+	StepsEnd   float64       `json:"steps_end"`
 	Unit       string        `json:"unit"`
 	TaskIds    []string      `json:"task_ids"`
 	ListIds    []string      `json:"list_ids"`
 }
 
 type KeyResult struct {
-	ID               string     `json:"id"`
-	GoalID           string     `json:"goal_id"`
-	Name             string     `json:"name"`
-	Creator          int        `json:"creator"`
-	Type             string     `json:"type"`
-	DateCreated      string     `json:"date_created"`
-	GoalPrettyID     string     `json:"goal_pretty_id"`
-	PercentCompleted int        `json:"percent_completed"`
-	Completed        bool       `json:"completed"`
-	TaskIds          []string   `json:"task_ids"`
-	Owners           []TeamUser `json:"owners"`
+	ID               string      `json:"id"`
+	GoalID           string      `json:"goal_id"`
+	Name             string      `json:"name"`
+	Creator          int         `json:"creator"`
+	Type             string      `json:"type"`
+	DateCreated      Timestamp   `json:"date_created"`
+	GoalPrettyID     string      `json:"goal_pretty_id"`
+	PercentCompleted int         `json:"percent_completed"`
+	Completed        bool        `json:"completed"`
+	TaskIds          []string    `json:"task_ids"`
+	ListIds          []string    `json:"list_ids"`
+	Owners           []TeamUser  `json:"owners"`
+	StepsStart       json.Number `json:"steps_start"`
+	StepsEnd         json.Number `json:"steps_end"`
+	StepsCurrent     json.Number `json:"steps_current"`
+	Unit             string      `json:"unit"`
 	LastAction       struct {
-		ID           string `json:"id"`
-		KeyResultID  string `json:"key_result_id"`
-		Userid       int    `json:"userid"`
-		Note         string `json:"note"`
-		DateModified string `json:"date_modified"`
+		ID           string    `json:"id"`
+		KeyResultID  string    `json:"key_result_id"`
+		Userid       int       `json:"userid"`
+		Note         string    `json:"note"`
+		DateModified Timestamp `json:"date_modified"`
 	} `json:"last_action"`
 }
 
@@ -270,18 +421,22 @@ func (c *Client) CreateKeyResultForGoal(ctx context.Context, keyResult CreateKey
 	return &newKeyResult, nil
 }
 
+// UpdateKeyResultRequest uses patch semantics.  Only explicitly set fields are sent to Clickup.
+// Slices are sent when non-nil, so an empty TaskIds clears the linked tasks.
 type UpdateKeyResultRequest struct {
-	ID               string     `json:"-"`
-	GoalID           string     `json:"goal_id,omitempty"`
-	Name             string     `json:"name,omitempty"`
-	Creator          int        `json:"creator,omitempty"`
-	Type             string     `json:"type,omitempty"`
-	DateCreated      string     `json:"date_created,omitempty"`
-	GoalPrettyID     string     `json:"goal_pretty_id,omitempty"`
-	PercentCompleted int        `json:"percent_completed,omitempty"`
-	Completed        bool       `json:"completed,omitempty"`
-	TaskIds          []string   `json:"task_ids,omitempty"`
-	Owners           []TeamUser `json:"owners,omitempty"`
+	ID           string         `json:"-"`
+	Name         OptionalString `json:"name"`
+	StepsCurrent OptionalFloat  `json:"steps_current"`
+	Note         OptionalString `json:"note"`
+	Unit         OptionalString `json:"unit"`
+	TaskIds      []string       `json:"task_ids"`
+	ListIds      []string       `json:"list_ids"`
+	AddOwners    []int          `json:"add_owners"`
+	RemoveOwners []int          `json:"rem_owners"`
+}
+
+func (u UpdateKeyResultRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
 }
 
 type UpdateKeyResultResponse struct {
@@ -318,3 +473,95 @@ func (c *Client) DeleteKeyResult(ctx context.Context, keyResultID string) error
 	}
 	return c.call(ctx, http.MethodDelete, fmt.Sprintf("/key_result/%s", keyResultID), nil, &struct{}{})
 }
+
+// KeyResultProgress validates value as the new current step of keyResult and returns the request
+// that records it, with note describing the change.  Values must lie between StepsStart and StepsEnd,
+// percentages between 0 and 100, and booleans must be 0 or 1.  Automatic key results follow the
+// tasks or lists they are linked to and cannot be updated.
+func KeyResultProgress(keyResult KeyResult, value float64, note string) (UpdateKeyResultRequest, error) {
+	if keyResult.ID == "" {
+		return UpdateKeyResultRequest{}, fmt.Errorf("must provide a key result id to record progress: %w", ErrValidation)
+	}
+
+	switch KeyResultType(keyResult.Type) {
+	case KeyResultAutomatic:
+		return UpdateKeyResultRequest{}, fmt.Errorf("automatic key result %s is read-only: %w", keyResult.ID, ErrValidation)
+	case KeyResultBoolean:
+		if value != 0 && value != 1 {
+			return UpdateKeyResultRequest{}, fmt.Errorf("boolean key result progress must be 0 or 1, got %g: %w", value, ErrValidation)
+		}
+	case KeyResultPercentage:
+		if value < 0 || value > 100 {
+			return UpdateKeyResultRequest{}, fmt.Errorf("percentage key result progress must be between 0 and 100, got %g: %w", value, ErrValidation)
+		}
+		fallthrough
+	case KeyResultNumber, KeyResultCurrency:
+		if err := checkKeyResultSteps(keyResult, value); err != nil {
+			return UpdateKeyResultRequest{}, err
+		}
+	default:
+		return UpdateKeyResultRequest{}, fmt.Errorf("unknown key result type %q: %w", keyResult.Type, ErrValidation)
+	}
+
+	update := UpdateKeyResultRequest{
+		ID:           keyResult.ID,
+		StepsCurrent: OptFloat(value),
+	}
+	if note != "" {
+		update.Note = OptString(note)
+	}
+	return update, nil
+}
+
+// checkKeyResultSteps checks value against the steps of keyResult.  Targets may count down, so
+// StepsEnd can be less than StepsStart.  Steps that are not known are not checked.
+func checkKeyResultSteps(keyResult KeyResult, value float64) error {
+	start, startErr := keyResult.StepsStart.Float64()
+	end, endErr := keyResult.StepsEnd.Float64()
+	if startErr != nil || endErr != nil {
+		return nil
+	}
+	if end < start {
+		start, end = end, start
+	}
+	if value < start || value > end {
+		return fmt.Errorf("key result progress must be between %s and %s, got %g: %w",
+			keyResult.StepsStart, keyResult.StepsEnd, value, ErrValidation)
+	}
+	return nil
+}
+
+// RecordKeyResultNumber sets the current value of a number key result.
+func (c *Client) RecordKeyResultNumber(ctx context.Context, keyResult KeyResult, value float64, note string) (*UpdateKeyResultResponse, error) {
+	return c.recordKeyResultProgress(ctx, KeyResultNumber, keyResult, value, note)
+}
+
+// RecordKeyResultCurrency sets the current amount of a currency key result.
+func (c *Client) RecordKeyResultCurrency(ctx context.Context, keyResult KeyResult, amount float64, note string) (*UpdateKeyResultResponse, error) {
+	return c.recordKeyResultProgress(ctx, KeyResultCurrency, keyResult, amount, note)
+}
+
+// RecordKeyResultPercentage sets the current percentage of a percentage key result.
+func (c *Client) RecordKeyResultPercentage(ctx context.Context, keyResult KeyResult, percent float64, note string) (*UpdateKeyResultResponse, error) {
+	return c.recordKeyResultProgress(ctx, KeyResultPercentage, keyResult, percent, note)
+}
+
+// RecordKeyResultBoolean marks a true/false key result as done or not done.
+func (c *Client) RecordKeyResultBoolean(ctx context.Context, keyResult KeyResult, done bool, note string) (*UpdateKeyResultResponse, error) {
+	value := 0.0
+	if done {
+		value = 1
+	}
+	return c.recordKeyResultProgress(ctx, KeyResultBoolean, keyResult, value, note)
+}
+
+func (c *Client) recordKeyResultProgress(ctx context.Context, keyResultType KeyResultType, keyResult KeyResult, value float64, note string) (*UpdateKeyResultResponse, error) {
+	if KeyResultType(keyResult.Type) != keyResultType {
+		return nil, fmt.Errorf("key result %s is a %s key result, not %s: %w", keyResult.ID, keyResult.Type, keyResultType, ErrValidation)
+	}
+	update, err := KeyResultProgress(keyResult, value, note)
+	if err != nil {
+		return nil, err
+	}
+	return c.UpdateKeyResult(ctx, update)
+}
@@ -114,7 +114,7 @@ func TestClient_UpdateGoal(t *testing.T) {
 				ctx: context.Background(),
 				goal: UpdateGoalRequest{
 					ID:   "Test GoalID",
-					Name: "test goal update",
+					Name: OptString("test goal update"),
 				},
 			},
 			wantErr: false,
@@ -129,7 +129,7 @@ func TestClient_UpdateGoal(t *testing.T) {
 				ctx: context.Background(),
 				goal: UpdateGoalRequest{
 					ID:   "",
-					Name: "test goal",
+					Name: OptString("test goal"),
 				},
 			},
 			wantErr: true,
@@ -439,7 +439,7 @@ func TestClient_UpdateKeyResult(t *testing.T) {
 				ctx: context.Background(),
 				keyResult: UpdateKeyResultRequest{
 					ID:   "test goalID",
-					Name: "test kr",
+					Name: OptString("test kr"),
 				},
 			},
 			wantErr: false,
@@ -535,3 +535,193 @@ func TestClient_DeleteKeyResult(t *testing.T) {
 		})
 	}
 }
+
+func TestKeyResultProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyResult KeyResult
+		value     float64
+		note      string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "Success number within steps",
+			keyResult: KeyResult{ID: "kr", Type: "number", StepsStart: "0", StepsEnd: "10"},
+			value:     10,
+			note:      "done",
+			want:      `{"steps_current":10,"note":"done"}`,
+		},
+		{
+			name:      "Success counting down",
+			keyResult: KeyResult{ID: "kr", Type: "currency", StepsStart: "5000", StepsEnd: "1000"},
+			value:     2500,
+			want:      `{"steps_current":2500}`,
+		},
+		{
+			name:      "Success fractional number",
+			keyResult: KeyResult{ID: "kr", Type: "number", StepsStart: "0", StepsEnd: "10"},
+			value:     2.5,
+			want:      `{"steps_current":2.5}`,
+		},
+		{
+			name:      "Success currency with cents",
+			keyResult: KeyResult{ID: "kr", Type: "currency", StepsStart: "0", StepsEnd: "2500.50"},
+			value:     1999.99,
+			want:      `{"steps_current":1999.99}`,
+		},
+		{
+			name:      "Success unknown steps are not checked",
+			keyResult: KeyResult{ID: "kr", Type: "number"},
+			value:     -3,
+			want:      `{"steps_current":-3}`,
+		},
+		{
+			name:      "Fail number outside of steps",
+			keyResult: KeyResult{ID: "kr", Type: "number", StepsStart: "0", StepsEnd: "10"},
+			value:     11,
+			wantErr:   true,
+		},
+		{
+			name:      "Fail fractional number just outside of steps",
+			keyResult: KeyResult{ID: "kr", Type: "number", StepsStart: "0", StepsEnd: "10"},
+			value:     10.01,
+			wantErr:   true,
+		},
+		{
+			name:      "Fail percentage over 100",
+			keyResult: KeyResult{ID: "kr", Type: "percentage", StepsStart: "0", StepsEnd: "200"},
+			value:     150,
+			wantErr:   true,
+		},
+		{
+			name:      "Fail boolean not 0 or 1",
+			keyResult: KeyResult{ID: "kr", Type: "boolean"},
+			value:     0.5,
+			wantErr:   true,
+		},
+		{
+			name:      "Fail automatic is read-only",
+			keyResult: KeyResult{ID: "kr", Type: "automatic"},
+			wantErr:   true,
+		},
+		{
+			name:      "Fail missing id",
+			keyResult: KeyResult{Type: "number"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KeyResultProgress(tt.keyResult, tt.value, tt.note)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("KeyResultProgress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if b := mustMarshal(t, got); b != tt.want {
+				t.Errorf("KeyResultProgress() = %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_RecordKeyResultBoolean(t *testing.T) {
+	var body string
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			body = string(b)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"key_result": {"id": "kr"}}`)),
+				Request:    req,
+			}, nil
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+
+	if _, err := c.RecordKeyResultBoolean(context.Background(), KeyResult{ID: "kr", Type: "boolean"}, true, ""); err != nil {
+		t.Fatalf("Client.RecordKeyResultBoolean() error = %v", err)
+	}
+	if body != `{"steps_current":1}` {
+		t.Errorf("Client.RecordKeyResultBoolean() body = %s, want {\"steps_current\":1}", body)
+	}
+	if _, err := c.RecordKeyResultBoolean(context.Background(), KeyResult{ID: "kr", Type: "number"}, true, ""); err == nil {
+		t.Errorf("Client.RecordKeyResultBoolean() on a number key result error = nil, want error")
+	}
+}
+
+func TestClient_GoalFolders(t *testing.T) {
+	var requests []string
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			var b []byte
+			if req.Body != nil {
+				b, _ = ioutil.ReadAll(req.Body)
+			}
+			requests = append(requests, req.Method+" "+req.URL.Path+" "+string(b))
+			body := `{"goal_folder": {"id": "folder", "name": "Q1"}}`
+			if req.Method == http.MethodGet {
+				body = `{"goals": [], "folders": [{"id": "folder", "name": "Q1", "goal_count": 2}]}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+	ctx := context.Background()
+
+	folders, err := c.GoalFoldersForWorkspace(ctx, "workspace")
+	if err != nil || len(folders) != 1 || folders[0].GoalCount != 2 {
+		t.Fatalf("Client.GoalFoldersForWorkspace() = %+v, %v", folders, err)
+	}
+	if _, err := c.CreateGoalFolder(ctx, CreateGoalFolderRequest{WorkspaceID: "workspace", Name: "Q1"}); err != nil {
+		t.Fatalf("Client.CreateGoalFolder() error = %v", err)
+	}
+	if _, err := c.UpdateGoalFolder(ctx, UpdateGoalFolderRequest{ID: "folder", Name: OptString("Q2")}); err != nil {
+		t.Fatalf("Client.UpdateGoalFolder() error = %v", err)
+	}
+	if err := c.DeleteGoalFolder(ctx, "folder"); err != nil {
+		t.Fatalf("Client.DeleteGoalFolder() error = %v", err)
+	}
+	if _, err := c.MoveGoalToFolder(ctx, "goal", ""); err != nil {
+		t.Fatalf("Client.MoveGoalToFolder() error = %v", err)
+	}
+
+	want := []string{
+		"GET /team/workspace/goal/ ",
+		`POST /team/workspace/goal_folder {"name":"Q1"}`,
+		`PUT /goal_folder/folder {"name":"Q2"}`,
+		"DELETE /goal_folder/folder ",
+		`PUT /goal/goal {"folder_id":null}`,
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+	if _, err := c.CreateGoalFolder(ctx, CreateGoalFolderRequest{WorkspaceID: "workspace"}); err == nil {
+		t.Errorf("Client.CreateGoalFolder() without name error = nil, want error")
+	}
+}
+
+func TestGetGoalsResponse_AllGoals(t *testing.T) {
+	goals := GetGoalsResponse{
+		Goals: []GoalResponse{{ID: "g1"}, {ID: "g2", FolderID: "f2"}},
+		Folders: []GoalFolder{
+			{ID: "f1", Goals: []GoalResponse{{ID: "g1"}, {ID: "g3"}}},
+			{ID: "f2", Goals: []GoalResponse{{ID: "g2"}}},
+		},
+	}
+	var got []string
+	for _, goal := range goals.AllGoals() {
+		got = append(got, goal.ID+":"+goal.FolderID)
+	}
+	if want := "g1:f1 g2:f2 g3:f1"; strings.Join(got, " ") != want {
+		t.Errorf("GetGoalsResponse.AllGoals() = %v, want %s", got, want)
+	}
+}
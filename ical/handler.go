@@ -0,0 +1,261 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package ical
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// DefaultTTL is how long a Handler serves a feed before reading its tasks again.
+const DefaultTTL = 5 * time.Minute
+
+// Handler serves live iCalendar feeds of tasks read through Client:
+//
+//	/list/<list id>.ics        the tasks of a list
+//	/assignee/<user id>.ics    the tasks assigned to a user in Workspace
+//
+// Feeds are events unless requested with ?todo=true, and include closed tasks with ?closed=true.
+// Each feed is cached for TTL and served with an ETag, so calendar apps that poll it cost a request
+// to Clickup at most once per TTL.  If Clickup cannot be reached, the last copy of a feed is served.
+//
+// Calendar apps cannot send credentials, so feeds are served to anyone who can reach a Handler.  A
+// Handler only serves the feeds named by ListIDs and AssigneeIDs, and serves none unless one of them
+// or Authorize is set.  With only Authorize set, every list Client can read and every user of
+// Workspace has a feed, so Authorize should check a secret in the URL.
+//
+// Mount a Handler under a prefix with http.StripPrefix:
+//
+//	http.Handle("/calendar/", http.StripPrefix("/calendar", &ical.Handler{Client: client, ListIDs: []string{"900100"}}))
+type Handler struct {
+	Client *clickup.Client
+	// Workspace is required for assignee feeds.
+	Workspace string
+	// ListIDs and AssigneeIDs are the feeds served.  Requests for other feeds are answered with 404 Not
+	// Found.  If neither is set, every feed is served to requests that Authorize allows.
+	ListIDs     []string
+	AssigneeIDs []string
+	// Authorize, if set, is called before a feed is served.  Requests it returns false for are answered
+	// with 403 Forbidden.
+	Authorize func(r *http.Request) bool
+	// TTL defaults to DefaultTTL.
+	TTL time.Duration
+	// Location decides which dates are whole days, see Options.
+	Location *time.Location
+
+	mu    sync.Mutex
+	feeds map[feedKey]*feed
+	now   func() time.Time
+}
+
+type feedKey struct {
+	kind   string // "list" or "assignee"
+	id     string
+	todo   bool
+	closed bool
+}
+
+// feed is a cached feed.  mu is held while it is refreshed, so concurrent requests for the same feed
+// wait for a single read of its tasks.
+type feed struct {
+	mu       sync.Mutex
+	body     []byte
+	etag     string
+	modified time.Time
+	expires  time.Time
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key, ok := h.parse(r.URL.Path)
+	if !ok || !h.allowed(key) {
+		http.NotFound(w, r)
+		return
+	}
+	if h.Authorize != nil && !h.Authorize(r) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	query := r.URL.Query()
+	for name, flag := range map[string]*bool{"todo": &key.todo, "closed": &key.closed} {
+		if v := query.Get(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid %s parameter %q", name, v), http.StatusBadRequest)
+				return
+			}
+			*flag = b
+		}
+	}
+
+	body, etag, modified, err := h.feed(r.Context(), key)
+	if err != nil {
+		http.Error(w, "failed to read tasks from Clickup", http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(h.ttl().Seconds())))
+	http.ServeContent(w, r, key.id+".ics", modified, bytes.NewReader(body))
+}
+
+// parse returns the feed for a path such as /list/123.ics.
+func (h *Handler) parse(path string) (feedKey, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 2 || !strings.HasSuffix(parts[1], ".ics") {
+		return feedKey{}, false
+	}
+	key := feedKey{kind: parts[0], id: strings.TrimSuffix(parts[1], ".ics")}
+	switch {
+	case key.id == "":
+		return feedKey{}, false
+	case key.kind == "list":
+		return key, true
+	case key.kind == "assignee" && h.Workspace != "":
+		_, err := strconv.Atoi(key.id)
+		return key, err == nil
+	}
+	return feedKey{}, false
+}
+
+// allowed reports whether the feed of key is in ListIDs or AssigneeIDs.  If neither is set, only a
+// Handler with Authorize serves feeds.
+func (h *Handler) allowed(key feedKey) bool {
+	if len(h.ListIDs) == 0 && len(h.AssigneeIDs) == 0 {
+		return h.Authorize != nil
+	}
+	ids := h.ListIDs
+	if key.kind == "assignee" {
+		ids = h.AssigneeIDs
+	}
+	for _, id := range ids {
+		if id == key.id {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) ttl() time.Duration {
+	if h.TTL <= 0 {
+		return DefaultTTL
+	}
+	return h.TTL
+}
+
+func (h *Handler) clock() time.Time {
+	if h.now != nil {
+		return h.now()
+	}
+	return time.Now()
+}
+
+// feed returns the cached feed for key, reading it again if it has expired.
+func (h *Handler) feed(ctx context.Context, key feedKey) ([]byte, string, time.Time, error) {
+	h.mu.Lock()
+	if h.feeds == nil {
+		h.feeds = make(map[feedKey]*feed)
+	}
+	f, ok := h.feeds[key]
+	if !ok {
+		f = &feed{}
+		h.feeds[key] = f
+	}
+	h.mu.Unlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := h.clock()
+	if f.body != nil && now.Before(f.expires) {
+		return f.body, f.etag, f.modified, nil
+	}
+
+	body, err := h.render(ctx, key, now)
+	if err != nil {
+		if f.body != nil {
+			return f.body, f.etag, f.modified, nil
+		}
+		// Only feeds that could be read are kept, so unknown ids do not fill the cache.
+		h.mu.Lock()
+		delete(h.feeds, key)
+		h.mu.Unlock()
+		return nil, "", time.Time{}, err
+	}
+
+	sum := sha256.Sum256(body)
+	if etag := fmt.Sprintf(`"%x"`, sum[:16]); etag != f.etag {
+		f.body, f.etag, f.modified = body, etag, now
+	}
+	f.expires = now.Add(h.ttl())
+	return f.body, f.etag, f.modified, nil
+}
+
+// render reads the tasks of key and writes them as a calendar.
+func (h *Handler) render(ctx context.Context, key feedKey, now time.Time) ([]byte, error) {
+	query := clickup.TaskQueryOptions{IncludeSubtasks: true, IncludeClosed: key.closed}
+
+	var tasks []clickup.SingleTask
+	var err error
+	var name string
+	switch key.kind {
+	case "list":
+		tasks, err = h.Client.AllTasksForList(ctx, key.id, &query)
+		name = "Clickup list " + key.id
+		if len(tasks) > 0 && tasks[0].List.Name != "" {
+			name = tasks[0].List.Name
+		}
+	case "assignee":
+		query.Assignees = []string{key.id}
+		tasks, err = h.Client.AllTasksForWorkspace(ctx, h.Workspace, &clickup.WorkspaceTaskQueryOptions{TaskQueryOptions: query})
+		name = "Clickup tasks for " + assigneeName(tasks, key.id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &Options{
+		Name:      name,
+		Component: Event,
+		Location:  h.Location,
+		Now:       func() time.Time { return now },
+	}
+	if key.todo {
+		opts.Component = Todo
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, tasks, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// assigneeName returns the username of the user with id from the assignees of tasks, or id.
+func assigneeName(tasks []clickup.SingleTask, id string) string {
+	for _, task := range tasks {
+		for _, assignee := range task.Assignees {
+			if strconv.Itoa(assignee.ID) == id && assignee.Username != "" {
+				return assignee.Username
+			}
+		}
+	}
+	return id
+}
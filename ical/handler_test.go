@@ -0,0 +1,281 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package ical
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// fakeDoer answers every request with response, or with status if it is set.
+type fakeDoer struct {
+	paths    []string
+	status   int
+	response string
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	d.paths = append(d.paths, req.URL.RequestURI())
+	status := d.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(d.response)),
+		Request:    req,
+	}, nil
+}
+
+const listTasks = `{"tasks":[{"id":"t1","name":"Launch","date_created":"1656000000000","due_date":"1656633600000","list":{"id":"l1","name":"Roadmap"},
+	"assignees":[{"id":7,"username":"ana"}]}]}`
+
+func newTestHandler(doer *fakeDoer, now *time.Time) *Handler {
+	return &Handler{
+		Client:      clickup.NewClient(&clickup.ClientOpts{Doer: doer, Authenticator: &clickup.APITokenAuthenticator{}}),
+		Workspace:   "w1",
+		ListIDs:     []string{"l1", "l2"},
+		AssigneeIDs: []string{"7"},
+		TTL:         time.Minute,
+		now:         func() time.Time { return *now },
+	}
+}
+
+func serve(h http.Handler, method, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		workspace  string
+		lists      []string
+		assignees  []string
+		authorize  func(r *http.Request) bool
+		zeroConfig bool
+		status     int
+		wantStatus int
+		wantPath   string
+		wantBody   []string
+	}{
+		{
+			name:       "List feed",
+			target:     "/list/l1.ics",
+			wantStatus: http.StatusOK,
+			wantPath:   "/api/v2/list/l1/task/?page=0&subtasks=true",
+			wantBody:   []string{"X-WR-CALNAME:Roadmap", "BEGIN:VEVENT", "DTSTART;VALUE=DATE:20220701"},
+		},
+		{
+			name:       "Assignee to-dos with closed tasks",
+			target:     "/assignee/7.ics?todo=1&closed=true",
+			wantStatus: http.StatusOK,
+			wantPath:   "/api/v2/team/w1/task?assignees%5B%5D=7&include_closed=true&page=0&subtasks=true",
+			wantBody:   []string{"X-WR-CALNAME:Clickup tasks for ana", "BEGIN:VTODO", "DUE;VALUE=DATE:20220701"},
+		},
+		{
+			name:       "Assignee feed needs a workspace",
+			target:     "/assignee/7.ics",
+			workspace:  "-",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Assignee is a user id",
+			target:     "/assignee/ana.ics",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Unknown feed",
+			target:     "/space/s1.ics",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Missing extension",
+			target:     "/list/l1",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Invalid flag",
+			target:     "/list/l1.ics?todo=maybe",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Method not allowed",
+			method:     http.MethodPost,
+			target:     "/list/l1.ics",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "No feeds without allowed ids or Authorize",
+			target:     "/list/l1.ics",
+			zeroConfig: true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Allowed list",
+			target:     "/list/l1.ics",
+			lists:      []string{"l2", "l1"},
+			wantStatus: http.StatusOK,
+			wantPath:   "/api/v2/list/l1/task/?page=0&subtasks=true",
+		},
+		{
+			name:       "List not allowed",
+			target:     "/list/l3.ics",
+			lists:      []string{"l1"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Assignee not allowed by list ids",
+			target:     "/assignee/7.ics",
+			lists:      []string{"l1"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Allowed assignee",
+			target:     "/assignee/7.ics",
+			assignees:  []string{"7"},
+			wantStatus: http.StatusOK,
+			wantPath:   "/api/v2/team/w1/task?assignees%5B%5D=7&page=0&subtasks=true",
+		},
+		{
+			name:       "List not allowed by assignee ids",
+			target:     "/list/l1.ics",
+			assignees:  []string{"7"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Authorized",
+			target:     "/list/l1.ics?key=secret",
+			authorize:  func(r *http.Request) bool { return r.URL.Query().Get("key") == "secret" },
+			wantStatus: http.StatusOK,
+			wantPath:   "/api/v2/list/l1/task/?page=0&subtasks=true",
+		},
+		{
+			name:       "Not authorized",
+			target:     "/list/l1.ics?key=guess",
+			authorize:  func(r *http.Request) bool { return r.URL.Query().Get("key") == "secret" },
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Clickup error",
+			target:     "/list/l1.ics",
+			status:     http.StatusUnauthorized,
+			wantStatus: http.StatusBadGateway,
+			wantPath:   "/api/v2/list/l1/task/?page=0&subtasks=true",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+			doer := &fakeDoer{status: tt.status, response: listTasks}
+			h := newTestHandler(doer, &now)
+			if tt.workspace == "-" {
+				h.Workspace = ""
+			}
+			if tt.zeroConfig || tt.lists != nil || tt.assignees != nil || tt.authorize != nil {
+				h.ListIDs, h.AssigneeIDs, h.Authorize = tt.lists, tt.assignees, tt.authorize
+			}
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			rec := serve(h, method, tt.target, nil)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantPath == "" && len(doer.paths) != 0 || tt.wantPath != "" && (len(doer.paths) != 1 || doer.paths[0] != tt.wantPath) {
+				t.Errorf("requests = %v, want %q", doer.paths, tt.wantPath)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(rec.Body.String(), want+"\r\n") {
+					t.Errorf("body does not contain %q:\n%s", want, rec.Body.String())
+				}
+			}
+			if tt.wantStatus == http.StatusOK {
+				if got := rec.Header().Get("Content-Type"); got != "text/calendar; charset=utf-8" {
+					t.Errorf("Content-Type = %q", got)
+				}
+				if got := rec.Header().Get("Cache-Control"); got != "max-age=60" {
+					t.Errorf("Cache-Control = %q", got)
+				}
+			}
+			if tt.wantStatus == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != "GET, HEAD" {
+				t.Errorf("Allow = %q", rec.Header().Get("Allow"))
+			}
+		})
+	}
+}
+
+func TestHandler_cache(t *testing.T) {
+	now := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+	doer := &fakeDoer{response: listTasks}
+	h := newTestHandler(doer, &now)
+
+	first := serve(h, http.MethodGet, "/list/l1.ics", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("first request = %d with ETag %q", first.Code, etag)
+	}
+
+	// Within the TTL the cached feed is served, and a matching ETag is not modified.
+	now = now.Add(30 * time.Second)
+	if rec := serve(h, http.MethodGet, "/list/l1.ics", http.Header{"If-None-Match": {etag}}); rec.Code != http.StatusNotModified {
+		t.Errorf("conditional request = %d, want %d", rec.Code, http.StatusNotModified)
+	}
+	if len(doer.paths) != 1 {
+		t.Errorf("made %d requests within the TTL, want 1", len(doer.paths))
+	}
+
+	// After the TTL the tasks are read again, and an unchanged feed keeps its ETag and time.
+	now = now.Add(time.Minute)
+	rec := serve(h, http.MethodGet, "/list/l1.ics", nil)
+	if len(doer.paths) != 2 {
+		t.Errorf("made %d requests after the TTL, want 2", len(doer.paths))
+	}
+	if rec.Header().Get("ETag") != etag || rec.Header().Get("Last-Modified") != first.Header().Get("Last-Modified") {
+		t.Errorf("unchanged feed headers = %v, want ETag %s", rec.Header(), etag)
+	}
+
+	// A changed feed gets a new ETag.
+	now = now.Add(2 * time.Minute)
+	doer.response = strings.Replace(listTasks, "Launch", "Launch v2", 1)
+	rec = serve(h, http.MethodGet, "/list/l1.ics", nil)
+	changed := rec.Header().Get("ETag")
+	if changed == etag || !strings.Contains(rec.Body.String(), "SUMMARY:Launch v2") {
+		t.Errorf("changed feed has ETag %s and body:\n%s", changed, rec.Body.String())
+	}
+
+	// When Clickup fails the last copy is served.
+	now = now.Add(2 * time.Minute)
+	doer.status = http.StatusInternalServerError
+	rec = serve(h, http.MethodGet, "/list/l1.ics", nil)
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") != changed {
+		t.Errorf("stale feed = %d with ETag %s, want %d with %s", rec.Code, rec.Header().Get("ETag"), http.StatusOK, changed)
+	}
+
+	// Feeds that could never be read are not kept.
+	if rec := serve(h, http.MethodGet, "/list/l2.ics", nil); rec.Code != http.StatusBadGateway {
+		t.Errorf("unreadable feed = %d, want %d", rec.Code, http.StatusBadGateway)
+	}
+	if _, ok := h.feeds[feedKey{kind: "list", id: "l2"}]; ok {
+		t.Error("unreadable feed was cached")
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package ical writes Clickup tasks as an RFC 5545 iCalendar document, so that start and due dates
+// show up in calendar apps, and serves live .ics feeds of lists and assignees with Handler.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// Component is the kind of calendar component written for each task.
+type Component string
+
+const (
+	Event Component = "VEVENT" // shown on the calendar from the start date to the due date
+	Todo  Component = "VTODO"  // shown as a to-do with a due date and completion status
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+// Options controls a calendar.  A nil *Options writes events in UTC.
+type Options struct {
+	// Name is the calendar name shown by calendar apps.
+	Name      string
+	Component Component
+	// Location decides which dates are whole days: a date at midnight in Location is written as a
+	// date without a time.  It defaults to UTC.
+	Location *time.Location
+	// Now is the time the calendar is written, for tasks that have never been updated.  It defaults to
+	// time.Now.
+	Now func() time.Time
+}
+
+// Write writes tasks to w as an iCalendar document with a component for every task that has a start
+// or due date.  Tasks without either are left out.
+func Write(w io.Writer, tasks []clickup.SingleTask, opts *Options) error {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Component == "" {
+		o.Component = Event
+	}
+	if o.Component != Event && o.Component != Todo {
+		return fmt.Errorf("invalid component %q: %w", o.Component, clickup.ErrValidation)
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+
+	cw := &contentWriter{w: bufio.NewWriter(w)}
+	cw.line("BEGIN", "VCALENDAR")
+	cw.line("VERSION", "2.0")
+	cw.line("PRODID", "-//clickup-client-go//ical//EN")
+	cw.line("CALSCALE", "GREGORIAN")
+	if o.Name != "" {
+		cw.line("X-WR-CALNAME", escape(o.Name))
+	}
+	now := o.Now()
+	for _, task := range tasks {
+		if task.StartDate.IsZero() && task.DueDate.IsZero() {
+			continue
+		}
+		o.component(cw, task, now)
+	}
+	cw.line("END", "VCALENDAR")
+	return cw.flush()
+}
+
+func (o *Options) component(cw *contentWriter, task clickup.SingleTask, now time.Time) {
+	cw.line("BEGIN", string(o.Component))
+	cw.line("UID", task.ID+"@clickup.com")
+	// The task's own times keep a feed unchanged until the task is.
+	stamp := now
+	if !task.DateUpdated.IsZero() {
+		stamp = task.DateUpdated.Time
+		cw.line("LAST-MODIFIED", stamp.UTC().Format(dateTimeLayout))
+	} else if !task.DateCreated.IsZero() {
+		stamp = task.DateCreated.Time
+	}
+	cw.line("DTSTAMP", stamp.UTC().Format(dateTimeLayout))
+	if !task.DateCreated.IsZero() {
+		cw.line("CREATED", task.DateCreated.UTC().Format(dateTimeLayout))
+	}
+	cw.line("SUMMARY", escape(task.Name))
+
+	start, due := task.StartDate, task.DueDate
+	switch o.Component {
+	case Event:
+		if start.IsZero() || !due.IsZero() && due.Before(start.Time) {
+			start = due
+		}
+		cw.line(o.date("DTSTART", start))
+		if o.wholeDay(start) {
+			// The end of a whole day event is exclusive.
+			end := due
+			if end.IsZero() || !o.wholeDay(end) {
+				end = start
+			}
+			cw.line(o.date("DTEND", clickup.NewTimestamp(end.In(o.Location).AddDate(0, 0, 1))))
+		} else if !due.IsZero() && due.After(start.Time) {
+			cw.line(o.date("DTEND", due))
+		}
+	case Todo:
+		if !start.IsZero() && (due.IsZero() || start.Before(due.Time)) {
+			cw.line(o.date("DTSTART", start))
+		}
+		if !due.IsZero() {
+			cw.line(o.date("DUE", due))
+		}
+		if closed(task) {
+			cw.line("STATUS", "COMPLETED")
+			if !task.DateClosed.IsZero() {
+				cw.line("COMPLETED", task.DateClosed.UTC().Format(dateTimeLayout))
+			}
+		} else {
+			cw.line("STATUS", "NEEDS-ACTION")
+		}
+	}
+
+	if priority, ok := priorities[task.Priority.ID]; ok {
+		cw.line("PRIORITY", priority)
+	}
+	if task.Status.Status != "" {
+		cw.line("CATEGORIES", escape(task.Status.Status))
+	}
+	if task.URL != "" {
+		cw.line("URL", task.URL)
+	}
+	for _, assignee := range task.Assignees {
+		if assignee.Email == "" {
+			continue
+		}
+		name := ""
+		if assignee.Username != "" {
+			name = ";CN=" + paramValue(assignee.Username)
+		}
+		cw.line("ATTENDEE"+name, "mailto:"+assignee.Email)
+	}
+	cw.line("DESCRIPTION", escape(description(task)))
+	cw.line("END", string(o.Component))
+}
+
+// priorities maps Clickup priorities, 1 (urgent) to 4 (low), to iCalendar priorities, 1 (highest) to
+// 9 (lowest).
+var priorities = map[string]string{
+	"1": "1",
+	"2": "3",
+	"3": "5",
+	"4": "9",
+}
+
+func closed(task clickup.SingleTask) bool {
+	return task.Status.Type == "closed" || task.Status.Type == "done"
+}
+
+// description returns the text of a task followed by its status, assignees and url, which calendar
+// apps show even when they ignore the properties of the same values.
+func description(task clickup.SingleTask) string {
+	var b strings.Builder
+	if text := strings.TrimSpace(task.TextContent); text != "" {
+		b.WriteString(text)
+		b.WriteString("\n\n")
+	}
+	if task.Status.Status != "" {
+		fmt.Fprintf(&b, "Status: %s\n", task.Status.Status)
+	}
+	if len(task.Assignees) > 0 {
+		fmt.Fprintf(&b, "Assignees: %s\n", strings.Join(clickup.Usernames(task.Assignees), ", "))
+	}
+	if task.URL != "" {
+		b.WriteString(task.URL)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// wholeDay reports whether t is a date without a time of day.
+func (o *Options) wholeDay(t clickup.Timestamp) bool {
+	local := t.In(o.Location)
+	return local.Hour() == 0 && local.Minute() == 0 && local.Second() == 0 && local.Nanosecond() == 0
+}
+
+// date returns the property name and value for t, as a date for whole days.
+func (o *Options) date(name string, t clickup.Timestamp) (string, string) {
+	if o.wholeDay(t) {
+		return name + ";VALUE=DATE", t.In(o.Location).Format(dateLayout)
+	}
+	return name, t.UTC().Format(dateTimeLayout)
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// paramValue quotes a parameter value if it contains a character with a meaning in a content line.
+// Parameter values cannot contain double quotes, so they are dropped.
+func paramValue(s string) string {
+	s = strings.ReplaceAll(s, `"`, "")
+	if strings.ContainsAny(s, ";:,") {
+		return `"` + s + `"`
+	}
+	return s
+}
+
+// contentWriter writes content lines, folded at 75 octets and ended with CRLF.  The first error is
+// kept and returned by flush.
+type contentWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (cw *contentWriter) line(name, value string) {
+	if cw.err != nil {
+		return
+	}
+	line := name + ":" + value
+	// Continuation lines start with a space, which counts towards their length.
+	for limit := maxLineOctets; len(line) > limit; limit = maxLineOctets - 1 {
+		// Lines are folded between characters, never inside one.
+		n := limit
+		for n > 0 && !utf8.RuneStart(line[n]) {
+			n--
+		}
+		cw.write(line[:n] + "\r\n ")
+		line = line[n:]
+	}
+	cw.write(line + "\r\n")
+}
+
+func (cw *contentWriter) write(s string) {
+	if cw.err == nil {
+		_, cw.err = cw.w.WriteString(s)
+	}
+}
+
+func (cw *contentWriter) flush() error {
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
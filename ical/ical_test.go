@@ -0,0 +1,188 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package ical
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func tasksFromJSON(t *testing.T, s string) []clickup.SingleTask {
+	t.Helper()
+	var tasks []clickup.SingleTask
+	if err := json.Unmarshal([]byte(s), &tasks); err != nil {
+		t.Fatal(err)
+	}
+	return tasks
+}
+
+const testTasks = `[
+	{"id":"t1","name":"Launch; v2, final","text_content":"Ship it\nthen rest","status":{"status":"in progress","type":"custom"},
+		"priority":{"id":"2"},"date_created":"1656000000000","date_updated":"1656500000000",
+		"start_date":"1656633600000","due_date":"1656806400000","url":"https://app.clickup.com/t/t1",
+		"assignees":[{"id":7,"username":"ana","email":"ana@example.com"},{"id":8,"username":"bo"}]},
+	{"id":"t2","name":"Review","status":{"status":"done","type":"closed"},"date_closed":"1656700000000",
+		"due_date":"1656667800000"},
+	{"id":"t3","name":"No dates"}
+]`
+
+func TestWrite(t *testing.T) {
+	now := func() time.Time { return time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name string
+		opts *Options
+		want []string
+	}{
+		{
+			name: "Events",
+			opts: &Options{Name: "Team, deadlines", Now: now},
+			want: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//clickup-client-go//ical//EN",
+				"CALSCALE:GREGORIAN",
+				`X-WR-CALNAME:Team\, deadlines`,
+				"BEGIN:VEVENT",
+				"UID:t1@clickup.com",
+				"LAST-MODIFIED:20220629T105320Z",
+				"DTSTAMP:20220629T105320Z",
+				"CREATED:20220623T160000Z",
+				`SUMMARY:Launch\; v2\, final`,
+				"DTSTART;VALUE=DATE:20220701",
+				"DTEND;VALUE=DATE:20220704",
+				"PRIORITY:3",
+				"CATEGORIES:in progress",
+				"URL:https://app.clickup.com/t/t1",
+				"ATTENDEE;CN=ana:mailto:ana@example.com",
+				`DESCRIPTION:Ship it\nthen rest\n\nStatus: in progress\nAssignees: ana\, bo\`,
+				" nhttps://app.clickup.com/t/t1",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:t2@clickup.com",
+				"DTSTAMP:20220701T120000Z",
+				"SUMMARY:Review",
+				"DTSTART:20220701T093000Z",
+				"CATEGORIES:done",
+				`DESCRIPTION:Status: done`,
+				"END:VEVENT",
+				"END:VCALENDAR",
+			},
+		},
+		{
+			name: "To-dos",
+			opts: &Options{Component: Todo, Now: now},
+			want: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//clickup-client-go//ical//EN",
+				"CALSCALE:GREGORIAN",
+				"BEGIN:VTODO",
+				"UID:t1@clickup.com",
+				"LAST-MODIFIED:20220629T105320Z",
+				"DTSTAMP:20220629T105320Z",
+				"CREATED:20220623T160000Z",
+				`SUMMARY:Launch\; v2\, final`,
+				"DTSTART;VALUE=DATE:20220701",
+				"DUE;VALUE=DATE:20220703",
+				"STATUS:NEEDS-ACTION",
+				"PRIORITY:3",
+				"CATEGORIES:in progress",
+				"URL:https://app.clickup.com/t/t1",
+				"ATTENDEE;CN=ana:mailto:ana@example.com",
+				`DESCRIPTION:Ship it\nthen rest\n\nStatus: in progress\nAssignees: ana\, bo\`,
+				" nhttps://app.clickup.com/t/t1",
+				"END:VTODO",
+				"BEGIN:VTODO",
+				"UID:t2@clickup.com",
+				"DTSTAMP:20220701T120000Z",
+				"SUMMARY:Review",
+				"DUE:20220701T093000Z",
+				"STATUS:COMPLETED",
+				"COMPLETED:20220701T182640Z",
+				"CATEGORIES:done",
+				`DESCRIPTION:Status: done`,
+				"END:VTODO",
+				"END:VCALENDAR",
+			},
+		},
+		{
+			name: "Whole days in a time zone",
+			opts: &Options{Now: now, Location: time.FixedZone("UTC-6", -6*60*60)},
+			want: []string{
+				"DTSTART:20220701T000000Z",
+				"DTEND:20220703T000000Z",
+				"DTSTART:20220701T093000Z",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := Write(&b, tasksFromJSON(t, testTasks), tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasSuffix(b.String(), "\r\n") {
+				t.Fatalf("calendar does not end with CRLF: %q", b.String())
+			}
+			lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+			if tt.name == "Whole days in a time zone" {
+				var dates []string
+				for _, line := range lines {
+					if strings.HasPrefix(line, "DTSTART") || strings.HasPrefix(line, "DTEND") {
+						dates = append(dates, line)
+					}
+				}
+				lines = dates
+			}
+			if strings.Join(lines, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Write() =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+
+	if err := Write(&strings.Builder{}, nil, &Options{Component: "VJOURNAL"}); !errors.Is(err, clickup.ErrValidation) {
+		t.Errorf("Write() with an invalid component error = %v, want ErrValidation", err)
+	}
+}
+
+func TestContentWriter_folding(t *testing.T) {
+	var b strings.Builder
+	cw := &contentWriter{w: bufio.NewWriter(&b)}
+	value := strings.Repeat("é", 100)
+	cw.line("SUMMARY", value)
+	if err := cw.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %d is %d octets: %q", i, len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a character: %q", i, line)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Fatalf("continuation line %d does not start with a space: %q", i, line)
+			}
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	if unfolded.String() != "SUMMARY:"+value {
+		t.Errorf("unfolded = %q", unfolded.String())
+	}
+}
@@ -7,7 +7,9 @@
 package clickup
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -18,18 +20,17 @@ type SingleList struct {
 	ID         string `json:"id"`
 	Name       string `json:"name"`
 	Orderindex int    `json:"-"`
+	Content    string `json:"content"`
 	Status     struct {
 	} `json:"status"`
 	Priority struct {
 	} `json:"priority"`
 	Assignee struct {
 	} `json:"assignee"`
-	TaskCount int `json:"task_count"`
-	DueDate   struct {
-	} `json:"due_date"`
-	StartDate struct {
-	} `json:"start_date"`
-	Folder struct {
+	TaskCount int       `json:"task_count"`
+	DueDate   Timestamp `json:"due_date"`
+	StartDate Timestamp `json:"start_date"`
+	Folder    struct {
 		ID     string `json:"id"`
 		Name   string `json:"name"`
 		Hidden bool   `json:"hidden"`
@@ -66,6 +67,26 @@ func (c *Client) ListsForFolder(ctx context.Context, folderID string, includeArc
 	return &lists, nil
 }
 
+// FolderlessLists returns the lists of spaceID that are not in a folder.  Use includeArchived to return archived lists.
+func (c *Client) FolderlessLists(ctx context.Context, spaceID string, includeArchived bool) (*ListsResponse, error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("must provide a space id to retrieve folderless lists: %w", ErrValidation)
+	}
+
+	urlValues := url.Values{}
+	urlValues.Set("archived", strconv.FormatBool(includeArchived))
+
+	endpoint := fmt.Sprintf("/space/%s/list/?%s", spaceID, urlValues.Encode())
+
+	var lists ListsResponse
+
+	if err := c.call(ctx, http.MethodGet, endpoint, nil, &lists); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &lists, nil
+}
+
 // ListByID returns a single list using listID.
 func (c *Client) ListByID(ctx context.Context, listID string) (*SingleList, error) {
 
@@ -79,3 +100,94 @@ func (c *Client) ListByID(ctx context.Context, listID string) (*SingleList, erro
 
 	return &list, nil
 }
+
+// CreateListRequest describes a new list in the folder with FolderID, or in the space with SpaceID
+// outside of any folder.  Exactly one of them must be set.
+type CreateListRequest struct {
+	FolderID    string     `json:"-"`
+	SpaceID     string     `json:"-"`
+	Name        string     `json:"name"`
+	Content     string     `json:"content,omitempty"`
+	DueDate     *Timestamp `json:"due_date,omitempty"`
+	DueDateTime bool       `json:"due_date_time,omitempty"`
+	Priority    *int       `json:"priority,omitempty"`
+	Assignee    int        `json:"assignee,omitempty"` // user id
+	Status      string     `json:"status,omitempty"`
+}
+
+// CreateList adds a new list to a folder or a space.
+func (c *Client) CreateList(ctx context.Context, list CreateListRequest) (*SingleList, error) {
+	if (list.FolderID == "") == (list.SpaceID == "") {
+		return nil, fmt.Errorf("must provide either a folder id or a space id to create a list: %w", ErrValidation)
+	}
+	if list.Name == "" {
+		return nil, fmt.Errorf("must provide a name for a new list: %w", ErrValidation)
+	}
+
+	b, err := json.Marshal(list)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize new list: %w", err)
+	}
+	buf := bytes.NewBuffer(b)
+
+	endpoint := fmt.Sprintf("/folder/%s/list", list.FolderID)
+	if list.SpaceID != "" {
+		endpoint = fmt.Sprintf("/space/%s/list", list.SpaceID)
+	}
+
+	var newList SingleList
+
+	if err := c.call(ctx, http.MethodPost, endpoint, buf, &newList); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &newList, nil
+}
+
+// UpdateListRequest uses patch semantics.  Only explicitly set fields are sent to Clickup.
+type UpdateListRequest struct {
+	ID          string            `json:"-"`
+	Name        OptionalString    `json:"name"`
+	Content     OptionalString    `json:"content"`
+	DueDate     OptionalTimestamp `json:"due_date"`
+	DueDateTime OptionalBool      `json:"due_date_time"`
+	Priority    OptionalInt       `json:"priority"`
+	Assignee    OptionalInt       `json:"assignee"` // user id
+	Status      OptionalString    `json:"status"`
+	UnsetStatus OptionalBool      `json:"unset_status"`
+}
+
+func (u UpdateListRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
+}
+
+// UpdateList makes changes to the list with list.ID.
+func (c *Client) UpdateList(ctx context.Context, list UpdateListRequest) (*SingleList, error) {
+	if list.ID == "" {
+		return nil, fmt.Errorf("must provide a list id to update: %w", ErrValidation)
+	}
+
+	b, err := json.Marshal(list)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize list: %w", err)
+	}
+	buf := bytes.NewBuffer(b)
+
+	endpoint := fmt.Sprintf("/list/%s", list.ID)
+
+	var updatedList SingleList
+
+	if err := c.call(ctx, http.MethodPut, endpoint, buf, &updatedList); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &updatedList, nil
+}
+
+// DeleteList removes the list with listID and its tasks.
+func (c *Client) DeleteList(ctx context.Context, listID string) error {
+	if listID == "" {
+		return fmt.Errorf("must provide a list id to delete: %w", ErrValidation)
+	}
+	return c.call(ctx, http.MethodDelete, fmt.Sprintf("/list/%s", listID), nil, &struct{}{})
+}
@@ -216,3 +216,176 @@ func TestClient_ListByID(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_FolderlessLists(t *testing.T) {
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			if got := req.URL.String(); got != "/space/fakeSpaceID/list/?archived=true" {
+				t.Errorf("url = %s, want /space/fakeSpaceID/list/?archived=true", got)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"lists":[{"id":"l1","name":"Inbox","content":"Triage"}]}`)),
+				Request:    req,
+			}, nil
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+	got, err := c.FolderlessLists(context.Background(), "fakeSpaceID", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Lists) != 1 || got.Lists[0].Content != "Triage" {
+		t.Errorf("Client.FolderlessLists() = %+v", got.Lists)
+	}
+
+	if _, err := c.FolderlessLists(context.Background(), "", false); err == nil {
+		t.Error("Client.FolderlessLists() without a space id did not fail")
+	}
+}
+
+func TestClient_CreateList(t *testing.T) {
+	priority := 2
+	tests := []struct {
+		name     string
+		list     CreateListRequest
+		wantPath string
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "TestSuccessful list in folder",
+			list:     CreateListRequest{FolderID: "fakeFolderID", Name: "Sprint 1", Priority: &priority, DueDate: TimestampFromMillis(1656633600000).Ptr()},
+			wantPath: "/folder/fakeFolderID/list",
+			wantBody: `{"name":"Sprint 1","due_date":1656633600000,"priority":2}`,
+		},
+		{
+			name:     "TestSuccessful folderless list",
+			list:     CreateListRequest{SpaceID: "fakeSpaceID", Name: "Inbox", Content: "Triage"},
+			wantPath: "/space/fakeSpaceID/list",
+			wantBody: `{"name":"Inbox","content":"Triage"}`,
+		},
+		{
+			name:    "TestFail Folder And Space",
+			list:    CreateListRequest{FolderID: "fakeFolderID", SpaceID: "fakeSpaceID", Name: "Inbox"},
+			wantErr: true,
+		},
+		{
+			name:    "TestFail Missing Parent",
+			list:    CreateListRequest{Name: "Inbox"},
+			wantErr: true,
+		},
+		{
+			name:    "TestFail Missing Name",
+			list:    CreateListRequest{SpaceID: "fakeSpaceID"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPost || req.URL.Path != tt.wantPath {
+						t.Errorf("request = %s %s, want POST %s", req.Method, req.URL.Path, tt.wantPath)
+					}
+					b, _ := ioutil.ReadAll(req.Body)
+					if string(b) != tt.wantBody {
+						t.Errorf("body = %s, want %s", b, tt.wantBody)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id":"l1"}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			got, err := c.CreateList(context.Background(), tt.list)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.CreateList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.ID != "l1" {
+				t.Errorf("Client.CreateList() id = %s, want l1", got.ID)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateList(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     UpdateListRequest
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "TestSuccessful only set fields sent",
+			list:     UpdateListRequest{ID: "fakeListID", Name: OptString("Sprint 2"), DueDate: NullTimestamp(), UnsetStatus: OptBool(true)},
+			wantBody: `{"name":"Sprint 2","due_date":null,"unset_status":true}`,
+		},
+		{
+			name:    "TestFail Missing List ID",
+			list:    UpdateListRequest{Name: OptString("Sprint 2")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPut || req.URL.Path != "/list/fakeListID" {
+						t.Errorf("request = %s %s, want PUT /list/fakeListID", req.Method, req.URL.Path)
+					}
+					b, _ := ioutil.ReadAll(req.Body)
+					if string(b) != tt.wantBody {
+						t.Errorf("body = %s, want %s", b, tt.wantBody)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id":"fakeListID","name":"Sprint 2"}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			got, err := c.UpdateList(context.Background(), tt.list)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.UpdateList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Name != "Sprint 2" {
+				t.Errorf("Client.UpdateList() name = %s, want Sprint 2", got.Name)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteList(t *testing.T) {
+	tests := []struct {
+		name    string
+		listID  string
+		wantErr bool
+	}{
+		{name: "TestSuccessful list deleted", listID: "fakeListID"},
+		{name: "TestFail Missing List ID", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodDelete || req.URL.Path != "/list/fakeListID" {
+						t.Errorf("request = %s %s, want DELETE /list/fakeListID", req.Method, req.URL.Path)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			if err := c.DeleteList(context.Background(), tt.listID); (err != nil) != tt.wantErr {
+				t.Errorf("Client.DeleteList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAtRiskWindow   = 14 * 24 * time.Hour
+	defaultAtRiskProgress = 70
+	defaultStaleAfter     = 14 * 24 * time.Hour
+)
+
+// OKRReportOptions configures OKRReport.  The zero value reports on open goals, flags key results under
+// 70% complete within two weeks of the goal's due date as at risk, and flags key results without an
+// update in two weeks as stale.
+type OKRReportOptions struct {
+	IncludeCompleted bool
+	// AtRiskWindow is how close to its goal's due date an incomplete key result must be to be at risk.
+	AtRiskWindow time.Duration
+	// AtRiskProgress is the percent complete below which a key result near its due date is at risk.
+	AtRiskProgress int
+	// StaleAfter is how long a key result can go without an update before it is stale.
+	StaleAfter     time.Duration
+	MaxConcurrency int
+	// Now is the time the report is generated at.  It defaults to time.Now.
+	Now time.Time
+}
+
+// OKRReport summarizes the progress of the goals in a workspace.
+type OKRReport struct {
+	GeneratedAt time.Time    `json:"generated_at"`
+	Goals       []GoalReport `json:"goals"`
+	Owners      []OKRSummary `json:"owners"`  // one per goal owner, sorted by name
+	Folders     []OKRSummary `json:"folders"` // one per goal folder, sorted by name.  Goals outside of a folder have an empty ID.
+}
+
+type GoalReport struct {
+	ID              string            `json:"id"`
+	Name            string            `json:"name"`
+	FolderID        string            `json:"folder_id,omitempty"`
+	FolderName      string            `json:"folder_name,omitempty"`
+	Owners          []string          `json:"owners"`
+	DueDate         *time.Time        `json:"due_date,omitempty"`
+	PercentComplete int               `json:"percent_complete"`
+	KeyResults      []KeyResultReport `json:"key_results"`
+}
+
+type KeyResultReport struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Type            string     `json:"type"`
+	Owners          []string   `json:"owners"`
+	PercentComplete int        `json:"percent_complete"`
+	Completed       bool       `json:"completed"`
+	LastUpdated     *time.Time `json:"last_updated,omitempty"`
+	AtRisk          bool       `json:"at_risk"`
+	Stale           bool       `json:"stale"`
+}
+
+// OKRSummary totals the goals of a single owner or goal folder.
+type OKRSummary struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Goals           int    `json:"goals"`
+	KeyResults      int    `json:"key_results"`
+	PercentComplete int    `json:"percent_complete"` // average of the goals
+	AtRisk          int    `json:"at_risk"`
+	Stale           int    `json:"stale"`
+}
+
+// OKRReport fetches every goal in the workspace with workspaceID along with its key results and
+// summarizes them per goal, owner and goal folder.  The progress of automatic key results is
+// calculated from the share of their linked tasks, and tasks of linked lists, that are closed.
+// opts may be nil.
+func (c *Client) OKRReport(ctx context.Context, workspaceID string, opts *OKRReportOptions) (*OKRReport, error) {
+	options := OKRReportOptions{}
+	if opts != nil {
+		options = *opts
+	}
+	if options.AtRiskWindow <= 0 {
+		options.AtRiskWindow = defaultAtRiskWindow
+	}
+	if options.AtRiskProgress <= 0 {
+		options.AtRiskProgress = defaultAtRiskProgress
+	}
+	if options.StaleAfter <= 0 {
+		options.StaleAfter = defaultStaleAfter
+	}
+	if options.MaxConcurrency <= 0 {
+		options.MaxConcurrency = defaultConcurrency
+	}
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+
+	var list *GetGoalsResponse
+	err := retryOnRateLimit(ctx, func() error {
+		var err error
+		list, err = c.GoalsForWorkspace(ctx, workspaceID, options.IncludeCompleted)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	folderNames := make(map[string]string, len(list.Folders))
+	for _, folder := range list.Folders {
+		folderNames[folder.ID] = folder.Name
+	}
+
+	listed := list.AllGoals()
+	goals := make([]GoalReport, len(listed))
+	owners := make([][]TeamUser, len(listed))
+	err = runConcurrently(ctx, len(listed), options.MaxConcurrency, func(ctx context.Context, i int) error {
+		var goal *GoalResponse
+		err := retryOnRateLimit(ctx, func() error {
+			var err error
+			goal, err = c.GoalForWorkSpace(ctx, listed[i].ID)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("unable to fetch goal %s: %w", listed[i].ID, err)
+		}
+		// the listing knows the folder even if the single goal does not
+		if goal.FolderID == "" {
+			goal.FolderID = listed[i].FolderID
+		}
+
+		report, err := c.goalReport(ctx, *goal, options)
+		if err != nil {
+			return err
+		}
+		report.FolderName = folderNames[report.FolderID]
+		goals[i] = report
+		owners[i] = goal.Owners
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OKRReport{
+		GeneratedAt: options.Now,
+		Goals:       goals,
+	}
+	report.summarize(owners)
+
+	return report, nil
+}
+
+func (c *Client) goalReport(ctx context.Context, goal GoalResponse, options OKRReportOptions) (GoalReport, error) {
+	report := GoalReport{
+		ID:              goal.ID,
+		Name:            goal.Name,
+		FolderID:        goal.FolderID,
+		Owners:          Usernames(goal.Owners),
+		PercentComplete: goal.PercentCompleted,
+		KeyResults:      make([]KeyResultReport, 0, len(goal.KeyResults)),
+	}
+	if !goal.DueDate.IsZero() {
+		dueDate := goal.DueDate.Time
+		report.DueDate = &dueDate
+	}
+
+	for _, keyResult := range goal.KeyResults {
+		krReport := KeyResultReport{
+			ID:              keyResult.ID,
+			Name:            keyResult.Name,
+			Type:            keyResult.Type,
+			Owners:          Usernames(keyResult.Owners),
+			PercentComplete: keyResult.PercentCompleted,
+			Completed:       keyResult.Completed,
+		}
+
+		if KeyResultType(keyResult.Type) == KeyResultAutomatic {
+			percent, err := c.automaticKeyResultProgress(ctx, keyResult)
+			if err != nil {
+				return GoalReport{}, fmt.Errorf("unable to resolve automatic key result %s: %w", keyResult.ID, err)
+			}
+			krReport.PercentComplete = percent
+			krReport.Completed = krReport.Completed || percent == 100
+		}
+
+		lastUpdated := keyResult.LastAction.DateModified.Time
+		if lastUpdated.IsZero() {
+			lastUpdated = keyResult.DateCreated.Time
+		}
+		if !lastUpdated.IsZero() {
+			krReport.LastUpdated = &lastUpdated
+		}
+
+		if !krReport.Completed {
+			krReport.Stale = !lastUpdated.IsZero() && options.Now.Sub(lastUpdated) > options.StaleAfter
+			krReport.AtRisk = report.DueDate != nil &&
+				report.DueDate.Sub(options.Now) <= options.AtRiskWindow &&
+				krReport.PercentComplete < options.AtRiskProgress
+		}
+
+		report.KeyResults = append(report.KeyResults, krReport)
+	}
+
+	return report, nil
+}
+
+// automaticKeyResultProgress returns the percent of the tasks linked to keyResult, directly or through
+// a list, that are closed.
+func (c *Client) automaticKeyResultProgress(ctx context.Context, keyResult KeyResult) (int, error) {
+	closed := make(map[string]bool)
+
+	for _, taskID := range keyResult.TaskIds {
+		var task *SingleTask
+		err := retryOnRateLimit(ctx, func() error {
+			var err error
+			task, err = c.TaskByID(ctx, taskID, "", false, false)
+			return err
+		})
+		if err != nil {
+			return 0, err
+		}
+		closed[task.ID] = task.Status.Type == "closed"
+	}
+	for _, listID := range keyResult.ListIds {
+		tasks, err := c.AllTasksForList(ctx, listID, &TaskQueryOptions{IncludeClosed: true})
+		if err != nil {
+			return 0, err
+		}
+		for _, task := range tasks {
+			closed[task.ID] = task.Status.Type == "closed"
+		}
+	}
+
+	if len(closed) == 0 {
+		return keyResult.PercentCompleted, nil
+	}
+	done := 0
+	for _, isClosed := range closed {
+		if isClosed {
+			done++
+		}
+	}
+	return done * 100 / len(closed), nil
+}
+
+// summarize totals the goals of the report by owner and folder.  goalOwners holds the owners of each goal.
+func (r *OKRReport) summarize(goalOwners [][]TeamUser) {
+	owners := make(map[string]*okrTotals)
+	folders := make(map[string]*okrTotals)
+
+	for i, goal := range r.Goals {
+		for _, owner := range goalOwners[i] {
+			id := strconv.Itoa(owner.ID)
+			if owners[id] == nil {
+				owners[id] = &okrTotals{summary: OKRSummary{ID: id, Name: owner.Username}}
+			}
+			owners[id].add(goal)
+		}
+		if folders[goal.FolderID] == nil {
+			folders[goal.FolderID] = &okrTotals{summary: OKRSummary{ID: goal.FolderID, Name: goal.FolderName}}
+		}
+		folders[goal.FolderID].add(goal)
+	}
+
+	r.Owners = sortedSummaries(owners)
+	r.Folders = sortedSummaries(folders)
+}
+
+type okrTotals struct {
+	summary OKRSummary
+	percent int
+}
+
+func (t *okrTotals) add(goal GoalReport) {
+	t.summary.Goals++
+	t.percent += goal.PercentComplete
+	t.summary.PercentComplete = t.percent / t.summary.Goals
+	for _, keyResult := range goal.KeyResults {
+		t.summary.KeyResults++
+		if keyResult.AtRisk {
+			t.summary.AtRisk++
+		}
+		if keyResult.Stale {
+			t.summary.Stale++
+		}
+	}
+}
+
+func sortedSummaries(totals map[string]*okrTotals) []OKRSummary {
+	summaries := make([]OKRSummary, 0, len(totals))
+	for _, total := range totals {
+		summaries = append(summaries, total.summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Name != summaries[j].Name {
+			return summaries[i].Name < summaries[j].Name
+		}
+		return summaries[i].ID < summaries[j].ID
+	})
+	return summaries
+}
+
+// WriteJSON writes the report to w as indented JSON.
+func (r *OKRReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+var okrCSVHeader = []string{
+	"folder", "goal_id", "goal", "goal_owners", "goal_due_date", "goal_percent_complete",
+	"key_result_id", "key_result", "type", "key_result_owners", "percent_complete", "completed",
+	"last_updated", "at_risk", "stale",
+}
+
+// WriteCSV writes the report to w as CSV with a row per key result.  Goals without key results have
+// a single row with the key result columns left empty.
+func (r *OKRReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(okrCSVHeader); err != nil {
+		return err
+	}
+
+	for _, goal := range r.Goals {
+		goalColumns := []string{
+			goal.FolderName, goal.ID, goal.Name, strings.Join(goal.Owners, ";"), formatReportTime(goal.DueDate),
+			strconv.Itoa(goal.PercentComplete),
+		}
+		if len(goal.KeyResults) == 0 {
+			if err := writer.Write(append(goalColumns, make([]string, len(okrCSVHeader)-len(goalColumns))...)); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, keyResult := range goal.KeyResults {
+			row := append(append([]string{}, goalColumns...),
+				keyResult.ID, keyResult.Name, keyResult.Type, strings.Join(keyResult.Owners, ";"),
+				strconv.Itoa(keyResult.PercentComplete), strconv.FormatBool(keyResult.Completed),
+				formatReportTime(keyResult.LastUpdated), strconv.FormatBool(keyResult.AtRisk),
+				strconv.FormatBool(keyResult.Stale),
+			)
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatReportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
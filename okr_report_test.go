@@ -0,0 +1,115 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_OKRReport(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	ms := func(t time.Time) string { return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10) }
+	dueSoon := ms(now.Add(7 * 24 * time.Hour))
+	longAgo := ms(now.Add(-30 * 24 * time.Hour))
+	recently := ms(now.Add(-24 * time.Hour))
+
+	responses := map[string]string{
+		"/team/workspace/goal/": `{
+			"goals": [{"id": "g2"}],
+			"folders": [{"id": "f1", "name": "Engineering", "goals": [{"id": "g1"}]}]
+		}`,
+		"/goal/g1": `{"id": "g1", "name": "Ship v2", "due_date": "` + dueSoon + `", "percent_completed": 40,
+			"owners": [{"id": 1, "username": "ana"}, {"id": 2, "username": "bo"}],
+			"key_results": [
+				{"id": "kr1", "name": "Docs", "type": "number", "percent_completed": 20, "last_action": {"date_modified": "` + longAgo + `"}},
+				{"id": "kr2", "name": "Tasks", "type": "automatic", "task_ids": ["t1", "t2"], "list_ids": ["l1"], "last_action": {"date_modified": "` + recently + `"}}
+			]}`,
+		"/goal/g2": `{"id": "g2", "name": "Hire", "percent_completed": 100, "owners": [{"id": 1, "username": "ana"}],
+			"key_results": [{"id": "kr3", "name": "Offers", "type": "boolean", "completed": true, "percent_completed": 100, "date_created": "` + longAgo + `"}]}`,
+		"/task/t1/":      `{"id": "t1", "status": {"type": "closed"}}`,
+		"/task/t2/":      `{"id": "t2", "status": {"type": "open"}}`,
+		"/list/l1/task/": `{"tasks": [{"id": "t2", "status": {"type": "open"}}, {"id": "t3", "status": {"type": "closed"}}]}`,
+	}
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			body, ok := responses[req.URL.Path]
+			status := http.StatusOK
+			if !ok {
+				status = http.StatusNotFound
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+
+	report, err := c.OKRReport(context.Background(), "workspace", &OKRReportOptions{Now: now})
+	if err != nil {
+		t.Fatalf("Client.OKRReport() error = %v", err)
+	}
+
+	ship := report.Goals[1]
+	if ship.FolderName != "Engineering" || len(ship.KeyResults) != 2 {
+		t.Fatalf("goal report = %+v", ship)
+	}
+	if kr := ship.KeyResults[0]; !kr.AtRisk || !kr.Stale {
+		t.Errorf("number key result at risk %v stale %v, want true true", kr.AtRisk, kr.Stale)
+	}
+	if kr := ship.KeyResults[1]; kr.PercentComplete != 66 || !kr.AtRisk || kr.Stale {
+		t.Errorf("automatic key result = %d%% at risk %v stale %v, want 66%% true false", kr.PercentComplete, kr.AtRisk, kr.Stale)
+	}
+	if kr := report.Goals[0].KeyResults[0]; kr.AtRisk || kr.Stale {
+		t.Errorf("completed key result at risk %v stale %v, want false false", kr.AtRisk, kr.Stale)
+	}
+
+	wantOwners := []OKRSummary{
+		{ID: "1", Name: "ana", Goals: 2, KeyResults: 3, PercentComplete: 70, AtRisk: 2, Stale: 1},
+		{ID: "2", Name: "bo", Goals: 1, KeyResults: 2, PercentComplete: 40, AtRisk: 2, Stale: 1},
+	}
+	if got, want := mustMarshal(t, report.Owners), mustMarshal(t, wantOwners); got != want {
+		t.Errorf("OKRReport.Owners = %s, want %s", got, want)
+	}
+	wantFolders := []OKRSummary{
+		{Goals: 1, KeyResults: 1, PercentComplete: 100},
+		{ID: "f1", Name: "Engineering", Goals: 1, KeyResults: 2, PercentComplete: 40, AtRisk: 2, Stale: 1},
+	}
+	if got, want := mustMarshal(t, report.Folders), mustMarshal(t, wantFolders); got != want {
+		t.Errorf("OKRReport.Folders = %s, want %s", got, want)
+	}
+
+	var jsonOut bytes.Buffer
+	if err := report.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("OKRReport.WriteJSON() error = %v", err)
+	}
+	var decoded OKRReport
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil || len(decoded.Goals) != 2 {
+		t.Errorf("OKRReport.WriteJSON() did not round trip: %v", err)
+	}
+
+	var csvOut bytes.Buffer
+	if err := report.WriteCSV(&csvOut); err != nil {
+		t.Fatalf("OKRReport.WriteCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("OKRReport.WriteCSV() rows = %d, want 4", len(lines))
+	}
+	if want := "Engineering,g1,Ship v2,ana;bo,2022-06-08T00:00:00Z,40,kr1,Docs,number,,20,false,2022-05-02T00:00:00Z,true,true"; lines[2] != want {
+		t.Errorf("OKRReport.WriteCSV() row = %s, want %s", lines[2], want)
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// optionalState tracks whether an optional field was left alone, explicitly cleared
+// or explicitly set to a value.
+type optionalState int
+
+const (
+	optionalUnset optionalState = iota
+	optionalNull
+	optionalValue
+)
+
+var nullJSON = []byte("null")
+
+// optional is implemented by the Optional* field types so that update requests
+// can omit anything that the caller did not explicitly set.
+type optional interface {
+	isSet() bool
+}
+
+// OptionalString is a string field for update (patch) requests.  The zero value is unset
+// and will be left out of the request entirely, NullString clears the field in Clickup,
+// and OptString sets it to a value (including the empty string).
+type OptionalString struct {
+	value string
+	state optionalState
+}
+
+// OptString returns an OptionalString that is set to v.
+func OptString(v string) OptionalString { return OptionalString{value: v, state: optionalValue} }
+
+// NullString returns an OptionalString that will be sent as an explicit null.
+func NullString() OptionalString { return OptionalString{state: optionalNull} }
+
+// Get returns the value of o and whether or not it holds a value.
+func (o OptionalString) Get() (string, bool) { return o.value, o.state == optionalValue }
+
+// IsNull returns true if o will be sent as an explicit null.
+func (o OptionalString) IsNull() bool { return o.state == optionalNull }
+
+func (o OptionalString) isSet() bool { return o.state != optionalUnset }
+
+func (o OptionalString) MarshalJSON() ([]byte, error) {
+	if o.state != optionalValue {
+		return nullJSON, nil
+	}
+	return json.Marshal(o.value)
+}
+
+func (o *OptionalString) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, nullJSON) {
+		*o = NullString()
+		return nil
+	}
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*o = OptString(v)
+	return nil
+}
+
+// OptionalInt is an int field for update (patch) requests.  See OptionalString.
+type OptionalInt struct {
+	value int
+	state optionalState
+}
+
+// OptInt returns an OptionalInt that is set to v.
+func OptInt(v int) OptionalInt { return OptionalInt{value: v, state: optionalValue} }
+
+// NullInt returns an OptionalInt that will be sent as an explicit null.
+func NullInt() OptionalInt { return OptionalInt{state: optionalNull} }
+
+// Get returns the value of o and whether or not it holds a value.
+func (o OptionalInt) Get() (int, bool) { return o.value, o.state == optionalValue }
+
+// IsNull returns true if o will be sent as an explicit null.
+func (o OptionalInt) IsNull() bool { return o.state == optionalNull }
+
+func (o OptionalInt) isSet() bool { return o.state != optionalUnset }
+
+func (o OptionalInt) MarshalJSON() ([]byte, error) {
+	if o.state != optionalValue {
+		return nullJSON, nil
+	}
+	return json.Marshal(o.value)
+}
+
+func (o *OptionalInt) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, nullJSON) {
+		*o = NullInt()
+		return nil
+	}
+	var v int
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*o = OptInt(v)
+	return nil
+}
+
+// OptionalFloat is a float64 field for update (patch) requests.  See OptionalString.
+type OptionalFloat struct {
+	value float64
+	state optionalState
+}
+
+// OptFloat returns an OptionalFloat that is set to v.
+func OptFloat(v float64) OptionalFloat { return OptionalFloat{value: v, state: optionalValue} }
+
+// NullFloat returns an OptionalFloat that will be sent as an explicit null.
+func NullFloat() OptionalFloat { return OptionalFloat{state: optionalNull} }
+
+// Get returns the value of o and whether or not it holds a value.
+func (o OptionalFloat) Get() (float64, bool) { return o.value, o.state == optionalValue }
+
+// IsNull returns true if o will be sent as an explicit null.
+func (o OptionalFloat) IsNull() bool { return o.state == optionalNull }
+
+func (o OptionalFloat) isSet() bool { return o.state != optionalUnset }
+
+func (o OptionalFloat) MarshalJSON() ([]byte, error) {
+	if o.state != optionalValue {
+		return nullJSON, nil
+	}
+	return json.Marshal(o.value)
+}
+
+func (o *OptionalFloat) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, nullJSON) {
+		*o = NullFloat()
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*o = OptFloat(v)
+	return nil
+}
+
+// OptionalBool is a bool field for update (patch) requests.  See OptionalString.
+type OptionalBool struct {
+	value bool
+	state optionalState
+}
+
+// OptBool returns an OptionalBool that is set to v.
+func OptBool(v bool) OptionalBool { return OptionalBool{value: v, state: optionalValue} }
+
+// NullBool returns an OptionalBool that will be sent as an explicit null.
+func NullBool() OptionalBool { return OptionalBool{state: optionalNull} }
+
+// Get returns the value of o and whether or not it holds a value.
+func (o OptionalBool) Get() (bool, bool) { return o.value, o.state == optionalValue }
+
+// IsNull returns true if o will be sent as an explicit null.
+func (o OptionalBool) IsNull() bool { return o.state == optionalNull }
+
+func (o OptionalBool) isSet() bool { return o.state != optionalUnset }
+
+func (o OptionalBool) MarshalJSON() ([]byte, error) {
+	if o.state != optionalValue {
+		return nullJSON, nil
+	}
+	return json.Marshal(o.value)
+}
+
+func (o *OptionalBool) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, nullJSON) {
+		*o = NullBool()
+		return nil
+	}
+	var v bool
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*o = OptBool(v)
+	return nil
+}
+
+// OptionalTimestamp is a date field for update (patch) requests.  See OptionalString.
+type OptionalTimestamp struct {
+	value Timestamp
+	state optionalState
+}
+
+// OptTimestamp returns an OptionalTimestamp that is set to v.  A zero v is sent as null.
+func OptTimestamp(v time.Time) OptionalTimestamp {
+	if v.IsZero() {
+		return NullTimestamp()
+	}
+	return OptionalTimestamp{value: NewTimestamp(v), state: optionalValue}
+}
+
+// NullTimestamp returns an OptionalTimestamp that will be sent as an explicit null, clearing the date.
+func NullTimestamp() OptionalTimestamp { return OptionalTimestamp{state: optionalNull} }
+
+// Get returns the value of o and whether or not it holds a value.
+func (o OptionalTimestamp) Get() (time.Time, bool) { return o.value.Time, o.state == optionalValue }
+
+// IsNull returns true if o will be sent as an explicit null.
+func (o OptionalTimestamp) IsNull() bool { return o.state == optionalNull }
+
+func (o OptionalTimestamp) isSet() bool { return o.state != optionalUnset }
+
+func (o OptionalTimestamp) MarshalJSON() ([]byte, error) {
+	if o.state != optionalValue {
+		return nullJSON, nil
+	}
+	return o.value.MarshalJSON()
+}
+
+func (o *OptionalTimestamp) UnmarshalJSON(b []byte) error {
+	var v Timestamp
+	if err := v.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	*o = OptTimestamp(v.Time)
+	return nil
+}
+
+// marshalPatch serializes the exported fields of the struct v for an update request.
+// Optional fields are only written when they were explicitly set (to a value or to null),
+// slices are only written when they are non-nil so that an empty slice can be used to clear
+// a field, and everything else follows the usual encoding/json tag rules.
+func marshalPatch(v interface{}) ([]byte, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	rt := rv.Type()
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	first := true
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx != -1 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		omitEmpty := strings.Contains(opts, "omitempty")
+
+		fv := rv.Field(i)
+		if o, ok := fv.Interface().(optional); ok {
+			if !o.isSet() {
+				continue
+			}
+		} else if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Map {
+			if fv.IsNil() {
+				continue
+			}
+		} else if omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		b, err := json.Marshal(fv.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(b)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
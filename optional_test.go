@@ -0,0 +1,102 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTaskUpdateRequest_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		request TaskUpdateRequest
+		want    string
+	}{
+		{
+			name:    "Nothing set",
+			request: TaskUpdateRequest{ID: "abc"},
+			want:    `{}`,
+		},
+		{
+			name: "Zero values explicitly set",
+			request: TaskUpdateRequest{
+				ID:          "abc",
+				Description: OptString(""),
+				Priority:    OptInt(0),
+				Archived:    OptBool(false),
+			},
+			want: `{"description":"","priority":0,"archived":false}`,
+		},
+		{
+			name: "Clear due date",
+			request: TaskUpdateRequest{
+				ID:      "abc",
+				Name:    OptString("new name"),
+				DueDate: NullTimestamp(),
+			},
+			want: `{"name":"new name","due_date":null}`,
+		},
+		{
+			name: "Set start date",
+			request: TaskUpdateRequest{
+				ID:        "abc",
+				StartDate: OptTimestamp(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			want: `{"start_date":1654041600000}`,
+		},
+		{
+			name: "Empty slice is sent but nil slice is not",
+			request: TaskUpdateRequest{
+				ID:   "abc",
+				Tags: []string{},
+			},
+			want: `{"tags":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", b, tt.want)
+			}
+
+			// pointers should serialize the same way
+			b, err = json.Marshal(&tt.request)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptional_UnmarshalJSON(t *testing.T) {
+	var v struct {
+		Name     OptionalString `json:"name"`
+		Position OptionalInt    `json:"position"`
+		Resolved OptionalBool   `json:"resolved"`
+	}
+	if err := json.Unmarshal([]byte(`{"name": null, "resolved": false}`), &v); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !v.Name.IsNull() {
+		t.Errorf("Name.IsNull() = false, want true")
+	}
+	if v.Position.isSet() {
+		t.Errorf("Position.isSet() = true, want false")
+	}
+	if resolved, ok := v.Resolved.Get(); !ok || resolved {
+		t.Errorf("Resolved.Get() = %v, %v, want false, true", resolved, ok)
+	}
+}
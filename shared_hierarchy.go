@@ -19,11 +19,11 @@ type SharedHierarchyResponse struct {
 				Type       string `json:"type"`
 				Orderindex int    `json:"-"`
 			} `json:"status"`
-			Orderindex  string `json:"-"`
-			DateCreated string `json:"date_created"`
-			DateUpdated string `json:"date_updated"`
-			DateClosed  string `json:"date_closed"`
-			Archived    bool   `json:"archived"`
+			Orderindex  string    `json:"-"`
+			DateCreated Timestamp `json:"date_created"`
+			DateUpdated Timestamp `json:"date_updated"`
+			DateClosed  Timestamp `json:"date_closed"`
+			Archived    bool      `json:"archived"`
 			Creator     struct {
 				ID             int    `json:"id"`
 				Username       string `json:"username"`
@@ -43,11 +43,9 @@ type SharedHierarchyResponse struct {
 			} `json:"parent"`
 			Priority struct {
 			} `json:"priority"`
-			DueDate struct {
-			} `json:"due_date"`
-			StartDate struct {
-			} `json:"start_date"`
-			Points struct {
+			DueDate   Timestamp `json:"due_date"`
+			StartDate Timestamp `json:"start_date"`
+			Points    struct {
 			} `json:"points"`
 			TimeEstimate struct {
 			} `json:"time_estimate"`
@@ -64,9 +62,9 @@ type SharedHierarchyResponse struct {
 						Orderindex int    `json:"-"`
 					} `json:"options"`
 				} `json:"type_config"`
-				DateCreated    string `json:"date_created"`
-				HideFromGuests bool   `json:"hide_from_guests"`
-				Required       bool   `json:"required"`
+				DateCreated    Timestamp `json:"date_created"`
+				HideFromGuests bool      `json:"hide_from_guests"`
+				Required       bool      `json:"required"`
 			} `json:"custom_fields"`
 			TeamID          string `json:"team_id"`
 			URL             string `json:"url"`
@@ -226,11 +226,18 @@ func (c *Client) CreateSpaceForWorkspace(ctx context.Context, space CreateSpaceR
 	return &newSpace, nil
 }
 
+// UpdateSpaceRequest uses patch semantics.  Only explicitly set fields are sent to Clickup.
 type UpdateSpaceRequest struct {
-	ID                string
-	Name              string    `json:"name,omitempty"`
-	MultipleAssignees bool      `json:"multiple_assignees,omitempty"`
-	Features          *Features `json:"features,omitempty"`
+	ID                string         `json:"-"`
+	Name              OptionalString `json:"name"`
+	Color             OptionalString `json:"color"`
+	Private           OptionalBool   `json:"private"`
+	MultipleAssignees OptionalBool   `json:"multiple_assignees"`
+	Features          *Features      `json:"features,omitempty"`
+}
+
+func (u UpdateSpaceRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
 }
 
 // UpdateSpaceForWorkspace makes changes to an existing space using parameters specified in space.
@@ -0,0 +1,252 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package sprint charts the burndown and burnup of a sprint.  Clickup models a sprint as a list, so a
+// sprint is the tasks of a list between a start and an end date.
+//
+// A task is part of the sprint from when it was created, so tasks created after the start are scope
+// added during the sprint.  A task is complete if its status is closed or done, from its DateClosed or
+// else from when it entered that status.  Tasks completed before the sprint started are left out.
+package sprint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// Unit is what the scope of a sprint is measured in.
+type Unit string
+
+const (
+	Tasks  Unit = "tasks"  // every task counts as one
+	Points Unit = "points" // sprint points
+	Hours  Unit = "hours"  // time estimates
+)
+
+type Options struct {
+	// Start and End are the first and last days of the sprint.  Both are required.
+	Start, End time.Time
+	Unit       Unit // defaults to Tasks
+	// Location is where days start.  It defaults to UTC.
+	Location *time.Location
+	// Now is the time the report is generated at.  Days after it have no actual values.  It defaults
+	// to time.Now.
+	Now             time.Time
+	IncludeSubtasks bool
+	MaxConcurrency  int // maximum number of in flight requests.  Defaults to 4.
+}
+
+// Report is the daily scope of a sprint.
+type Report struct {
+	ListID      string    `json:"list_id"`
+	Name        string    `json:"name"`
+	Unit        Unit      `json:"unit"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Days        []Day     `json:"days"`
+	Tasks       []Task    `json:"tasks"`
+}
+
+// Day is the scope of a sprint at the end of Date, or so far for the current day.  The actual values
+// are nil for days after the current day.
+type Day struct {
+	Date      time.Time `json:"date"`
+	Ideal     float64   `json:"ideal"` // remaining scope if the initial scope were completed at an even pace
+	Scope     *float64  `json:"scope"`
+	Completed *float64  `json:"completed"`
+	Remaining *float64  `json:"remaining"`
+	Added     *float64  `json:"added"` // scope added during the day
+}
+
+// Task is a task of the sprint and its share of the scope.
+type Task struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Status    string     `json:"status"`
+	Value     float64    `json:"value"`
+	Added     *time.Time `json:"added,omitempty"` // set if the task was created after the sprint started
+	Completed *time.Time `json:"completed,omitempty"`
+
+	created time.Time
+}
+
+// ForList requests the tasks of listID, including closed tasks, and reports on them.  The status
+// history is requested for complete tasks without a DateClosed.
+func ForList(ctx context.Context, client *clickup.Client, listID string, opts *Options) (*Report, error) {
+	o, err := opts.defaults()
+	if err != nil {
+		return nil, err
+	}
+	if listID == "" {
+		return nil, fmt.Errorf("must provide a list id for a sprint report: %w", clickup.ErrValidation)
+	}
+
+	tasks, err := client.AllTasksForList(ctx, listID, &clickup.TaskQueryOptions{
+		IncludeClosed:   true,
+		IncludeSubtasks: o.IncludeSubtasks,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for _, task := range tasks {
+		if complete(task) && task.DateClosed.IsZero() {
+			unknown = append(unknown, task.ID)
+		}
+	}
+	var histories map[string]clickup.TaskTimeInStatusResponse
+	if len(unknown) > 0 {
+		histories, err = client.AllTaskTimeInStatus(ctx, unknown, &clickup.TimeInStatusOptions{MaxConcurrency: o.MaxConcurrency})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := build(tasks, histories, o)
+	report.ListID = listID
+	return report, nil
+}
+
+// Build reports on the tasks of a sprint.  histories may be nil, or hold the status history of
+// complete tasks without a DateClosed, keyed by task id.
+func Build(tasks []clickup.SingleTask, histories map[string]clickup.TaskTimeInStatusResponse, opts *Options) (*Report, error) {
+	o, err := opts.defaults()
+	if err != nil {
+		return nil, err
+	}
+	return build(tasks, histories, o), nil
+}
+
+func (opts *Options) defaults() (Options, error) {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Start.IsZero() || o.End.IsZero() {
+		return o, fmt.Errorf("a sprint must have a start and an end: %w", clickup.ErrValidation)
+	}
+	if o.Unit == "" {
+		o.Unit = Tasks
+	}
+	if o.Unit != Tasks && o.Unit != Points && o.Unit != Hours {
+		return o, fmt.Errorf("unknown unit %q: %w", o.Unit, clickup.ErrValidation)
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	o.Start, o.End = startOfDay(o.Start, o.Location), startOfDay(o.End, o.Location)
+	if o.End.Before(o.Start) {
+		return o, fmt.Errorf("sprint ends before it starts: %w", clickup.ErrValidation)
+	}
+	return o, nil
+}
+
+func build(tasks []clickup.SingleTask, histories map[string]clickup.TaskTimeInStatusResponse, o Options) *Report {
+	report := &Report{
+		Unit:        o.Unit,
+		Start:       o.Start,
+		End:         o.End,
+		GeneratedAt: o.Now,
+		Tasks:       []Task{},
+	}
+	if len(tasks) > 0 {
+		report.ListID, report.Name = tasks[0].List.ID, tasks[0].List.Name
+	}
+
+	for _, t := range tasks {
+		task := Task{
+			ID:      t.ID,
+			Name:    t.Name,
+			Status:  t.Status.Status,
+			Value:   value(t, o.Unit),
+			created: t.DateCreated.Time,
+		}
+		if complete(t) {
+			completed := t.DateClosed.Time
+			if completed.IsZero() {
+				completed = histories[t.ID].CurrentStatus.TotalTime.Since.Time
+			}
+			if completed.IsZero() {
+				completed = t.DateUpdated.Time
+			}
+			if !completed.IsZero() {
+				if completed.Before(o.Start) {
+					continue
+				}
+				task.Completed = &completed
+			}
+		}
+		if task.created.After(o.Start) {
+			added := task.created
+			task.Added = &added
+		}
+		report.Tasks = append(report.Tasks, task)
+	}
+	sort.Slice(report.Tasks, func(i, j int) bool { return report.Tasks[i].ID < report.Tasks[j].ID })
+
+	initial := 0.0
+	for _, task := range report.Tasks {
+		if !task.created.After(o.Start) {
+			initial += task.Value
+		}
+	}
+
+	days := int(o.End.Sub(o.Start).Hours()/24+0.5) + 1
+	today := startOfDay(o.Now, o.Location)
+	for i := 0; i < days; i++ {
+		date := o.Start.AddDate(0, 0, i)
+		end := date.AddDate(0, 0, 1)
+		day := Day{Date: date, Ideal: initial * float64(days-1-i) / float64(days)}
+		if !date.After(today) {
+			var scope, completed, added float64
+			for _, task := range report.Tasks {
+				if task.created.Before(end) {
+					scope += task.Value
+					if task.Added != nil && !task.Added.Before(date) {
+						added += task.Value
+					}
+				}
+				if task.Completed != nil && task.Completed.Before(end) {
+					completed += task.Value
+				}
+			}
+			remaining := scope - completed
+			day.Scope, day.Completed, day.Remaining, day.Added = &scope, &completed, &remaining, &added
+		}
+		report.Days = append(report.Days, day)
+	}
+	return report
+}
+
+// complete reports whether a task is in a closed or done status.
+func complete(task clickup.SingleTask) bool {
+	return task.Status.Type == "closed" || task.Status.Type == "done"
+}
+
+func value(task clickup.SingleTask, unit Unit) float64 {
+	switch unit {
+	case Points:
+		return task.Points
+	case Hours:
+		return float64(task.TimeEstimate) / float64(time.Hour/time.Millisecond)
+	}
+	return 1
+}
+
+// startOfDay returns midnight of the day of t in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
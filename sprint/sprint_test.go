@@ -0,0 +1,213 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package sprint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func ms(t time.Time) int64 {
+	return clickup.NewTimestamp(t).Millis()
+}
+
+func date(d, hour int) time.Time {
+	return time.Date(2022, 7, d, hour, 0, 0, 0, time.UTC)
+}
+
+// testTasks is a sprint from July 4 to 8 with a task added on the 5th, a task in a done status without a
+// closed date and a task closed before the sprint.
+var testTasks = fmt.Sprintf(`{"tasks": [
+	{"id": "t1", "name": "Login", "points": 3, "time_estimate": 5400000, "status": {"status": "closed", "type": "closed"},
+		"date_created": "%d", "date_closed": "%d", "list": {"id": "l1", "name": "Sprint 12"}},
+	{"id": "t2", "name": "Signup", "points": 5, "status": {"status": "open", "type": "open"}, "date_created": "%d"},
+	{"id": "t3", "name": "Hotfix", "points": 2, "status": {"status": "shipped", "type": "done"}, "date_created": "%d"},
+	{"id": "t4", "name": "Old", "points": 8, "status": {"status": "closed", "type": "closed"},
+		"date_created": "%d", "date_closed": "%d"}
+]}`, ms(date(1, 0).AddDate(0, 0, -1)), ms(date(5, 10)), ms(date(1, 0)), ms(date(5, 9)), ms(date(1, 0).AddDate(0, 0, -10)), ms(date(1, 0)))
+
+var testHistories = map[string]clickup.TaskTimeInStatusResponse{}
+
+func init() {
+	body := fmt.Sprintf(`{"current_status": {"status": "shipped", "total_time": {"by_minute": 60, "since": "%d"}}}`, ms(date(6, 11)))
+	var history clickup.TaskTimeInStatusResponse
+	if err := json.Unmarshal([]byte(body), &history); err != nil {
+		panic(err)
+	}
+	testHistories["t3"] = history
+}
+
+func decodeTasks(t *testing.T) []clickup.SingleTask {
+	t.Helper()
+	var response clickup.GetTasksResponse
+	if err := json.Unmarshal([]byte(testTasks), &response); err != nil {
+		t.Fatal(err)
+	}
+	return response.Tasks
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func TestBuild(t *testing.T) {
+	opts := &Options{Start: date(4, 9), End: date(8, 0), Unit: Points, Now: date(6, 15)}
+	report, err := Build(decodeTasks(t), testHistories, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.ListID != "l1" || report.Name != "Sprint 12" || !report.Start.Equal(date(4, 0)) || !report.End.Equal(date(8, 0)) {
+		t.Errorf("report = %s %q from %s to %s", report.ListID, report.Name, report.Start, report.End)
+	}
+
+	var ids []string
+	for _, task := range report.Tasks {
+		ids = append(ids, task.ID)
+	}
+	if want := []string{"t1", "t2", "t3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("tasks = %v, want %v without the task closed before the sprint", ids, want)
+	}
+	if t3 := report.Tasks[2]; t3.Added == nil || !t3.Added.Equal(date(5, 9)) || t3.Completed == nil || !t3.Completed.Equal(date(6, 11)) {
+		t.Errorf("t3 added %v and completed %v, want %v and %v from its status history", t3.Added, t3.Completed, date(5, 9), date(6, 11))
+	}
+
+	days := []Day{
+		{Date: date(4, 0), Ideal: 6.4, Scope: ptr(8), Completed: ptr(0), Remaining: ptr(8), Added: ptr(0)},
+		{Date: date(5, 0), Ideal: 4.8, Scope: ptr(10), Completed: ptr(3), Remaining: ptr(7), Added: ptr(2)},
+		{Date: date(6, 0), Ideal: 3.2, Scope: ptr(10), Completed: ptr(5), Remaining: ptr(5), Added: ptr(0)},
+		{Date: date(7, 0), Ideal: 1.6},
+		{Date: date(8, 0), Ideal: 0},
+	}
+	if len(report.Days) != len(days) {
+		t.Fatalf("got %d days, want %d", len(report.Days), len(days))
+	}
+	for i, want := range days {
+		got := report.Days[i]
+		got.Ideal = float64(int(got.Ideal*100+0.5)) / 100
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("day %d = %s, want %s", i, dayString(got), dayString(want))
+		}
+	}
+}
+
+func dayString(d Day) string {
+	f := func(v *float64) string {
+		if v == nil {
+			return "nil"
+		}
+		return fmt.Sprint(*v)
+	}
+	return fmt.Sprintf("%s ideal %v scope %s completed %s remaining %s added %s",
+		d.Date.Format("2006-01-02"), d.Ideal, f(d.Scope), f(d.Completed), f(d.Remaining), f(d.Added))
+}
+
+func TestBuild_options(t *testing.T) {
+	tasks := decodeTasks(t)
+	tests := []struct {
+		name       string
+		opts       *Options
+		wantErr    bool
+		wantDays   int
+		wantValues []float64
+	}{
+		{
+			name:       "Tasks by default",
+			opts:       &Options{Start: date(4, 0), End: date(8, 0), Now: date(9, 0)},
+			wantDays:   5,
+			wantValues: []float64{1, 1, 1},
+		},
+		{
+			name:       "Hours",
+			opts:       &Options{Start: date(4, 0), End: date(4, 0), Unit: Hours, Now: date(9, 0)},
+			wantDays:   1,
+			wantValues: []float64{1.5, 0, 0},
+		},
+		{
+			name:       "Days start in Location",
+			opts:       &Options{Start: date(4, 3), End: date(8, 3), Location: time.FixedZone("UTC+5", 5*60*60), Now: date(9, 0)},
+			wantDays:   5,
+			wantValues: []float64{1, 1, 1},
+		},
+		{name: "Missing end", opts: &Options{Start: date(4, 0)}, wantErr: true},
+		{name: "Nil options", wantErr: true},
+		{name: "End before start", opts: &Options{Start: date(4, 0), End: date(3, 0)}, wantErr: true},
+		{name: "Unknown unit", opts: &Options{Start: date(4, 0), End: date(8, 0), Unit: "days"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Build(tasks, testHistories, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, clickup.ErrValidation) {
+					t.Errorf("Build() error = %v, want ErrValidation", err)
+				}
+				return
+			}
+			if len(report.Days) != tt.wantDays {
+				t.Errorf("got %d days, want %d", len(report.Days), tt.wantDays)
+			}
+			var values []float64
+			for _, task := range report.Tasks {
+				values = append(values, task.Value)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("values = %v, want %v", values, tt.wantValues)
+			}
+		})
+	}
+}
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestForList(t *testing.T) {
+	var paths []string
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.RequestURI())
+		body := testTasks
+		if strings.Contains(req.URL.Path, "time_in_status") {
+			body = fmt.Sprintf(`{"current_status": {"status": "shipped", "total_time": {"by_minute": 60, "since": "%d"}}}`, ms(date(6, 11)))
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body)), Request: req}, nil
+	})
+	client := clickup.NewClient(&clickup.ClientOpts{Doer: doer, Authenticator: &clickup.APITokenAuthenticator{}})
+
+	report, err := ForList(context.Background(), client, "l1", &Options{Start: date(4, 0), End: date(8, 0), Now: date(6, 15)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"/api/v2/list/l1/task/?include_closed=true&page=0",
+		"/api/v2/task/t3/time_in_status/?custom_task_ids=false&task_id=t3&team_id=",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("requests = %v, want %v", paths, want)
+	}
+	if got := report.Days[2].Completed; got == nil || *got != 2 {
+		t.Errorf("completed on the third day = %v, want 2", got)
+	}
+
+	if _, err := ForList(context.Background(), client, "", &Options{Start: date(4, 0), End: date(8, 0)}); !errors.Is(err, clickup.ErrValidation) {
+		t.Errorf("ForList() without a list error = %v, want ErrValidation", err)
+	}
+}
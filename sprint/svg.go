@@ -0,0 +1,204 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package sprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// Chart is a kind of sprint chart.
+type Chart string
+
+const (
+	Burndown Chart = "burndown" // remaining scope against the ideal
+	Burnup   Chart = "burnup"   // completed scope against the total scope
+)
+
+const (
+	chartWidth   = 800
+	chartHeight  = 420
+	marginLeft   = 60
+	marginRight  = 20
+	marginTop    = 50
+	marginBottom = 70
+	maxDayLabels = 14
+)
+
+// series is a line of a chart.  Values are nil where the line has no point.
+type series struct {
+	name   string
+	color  string
+	dashed bool
+	values []*float64
+}
+
+// WriteJSON writes the report to w as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteSVG writes chart to w as a standalone SVG image.
+func (r *Report) WriteSVG(w io.Writer, chart Chart) error {
+	var lines []series
+	switch chart {
+	case Burndown:
+		ideal := series{name: "Ideal", color: "#9e9e9e", dashed: true}
+		remaining := series{name: "Remaining", color: "#1e88e5"}
+		for i := range r.Days {
+			ideal.values = append(ideal.values, &r.Days[i].Ideal)
+			remaining.values = append(remaining.values, r.Days[i].Remaining)
+		}
+		lines = []series{ideal, remaining}
+	case Burnup:
+		scope := series{name: "Scope", color: "#fb8c00"}
+		completed := series{name: "Completed", color: "#43a047"}
+		for _, day := range r.Days {
+			scope.values = append(scope.values, day.Scope)
+			completed.values = append(completed.values, day.Completed)
+		}
+		lines = []series{scope, completed}
+	default:
+		return fmt.Errorf("unknown chart %q: %w", chart, clickup.ErrValidation)
+	}
+
+	title := r.Name
+	if title == "" {
+		title = "Sprint"
+	}
+	title += " " + string(chart)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		chartWidth, chartHeight, chartWidth, chartHeight)
+	fmt.Fprintf(&b, "<title>%s</title>\n", escapeText(title))
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", chartWidth, chartHeight)
+	fmt.Fprintf(&b, `<text x="%d" y="28" text-anchor="middle" font-size="16">%s</text>`+"\n", chartWidth/2, escapeText(title))
+
+	max, step := yScale(lines)
+	plot := plotArea{days: len(r.Days), max: max}
+	for v := 0.0; v <= max+step/2; v += step {
+		y := plot.y(v)
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#e0e0e0"/>`+"\n", marginLeft, y, chartWidth-marginRight, y)
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end">%s</text>`+"\n", marginLeft-8, y+4, formatValue(v))
+	}
+	fmt.Fprintf(&b, `<text transform="translate(16 %d) rotate(-90)" text-anchor="middle">%s</text>`+"\n",
+		marginTop+(chartHeight-marginTop-marginBottom)/2, r.Unit)
+
+	every := (len(r.Days) + maxDayLabels - 1) / maxDayLabels
+	for i, day := range r.Days {
+		if i%every != 0 && i != len(r.Days)-1 {
+			continue
+		}
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n",
+			plot.x(i), chartHeight-marginBottom+18, day.Date.Format("Jan 2"))
+	}
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#616161"/>`+"\n",
+		marginLeft, chartHeight-marginBottom, chartWidth-marginRight, chartHeight-marginBottom)
+
+	for _, line := range lines {
+		var points []string
+		for i, v := range line.values {
+			if v != nil {
+				points = append(points, fmt.Sprintf("%.1f,%.1f", plot.x(i), plot.y(*v)))
+			}
+		}
+		if len(points) == 0 {
+			continue
+		}
+		dash := ""
+		if line.dashed {
+			dash = ` stroke-dasharray="6 4"`
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2"%s points="%s"/>`+"\n", line.color, dash, strings.Join(points, " "))
+		if !line.dashed {
+			for _, point := range points {
+				xy := strings.Split(point, ",")
+				fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="3" fill="%s"/>`+"\n", xy[0], xy[1], line.color)
+			}
+		}
+	}
+
+	for i, line := range lines {
+		x := marginLeft + i*140
+		y := chartHeight - 24
+		dash := ""
+		if line.dashed {
+			dash = ` stroke-dasharray="6 4"`
+		}
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="2"%s/>`+"\n", x, y, x+24, y, line.color, dash)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`+"\n", x+30, y+4, line.name)
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// plotArea maps days and values to coordinates.
+type plotArea struct {
+	days int
+	max  float64
+}
+
+func (p plotArea) x(day int) float64 {
+	width := float64(chartWidth - marginLeft - marginRight)
+	if p.days < 2 {
+		return marginLeft + width/2
+	}
+	return marginLeft + width*float64(day)/float64(p.days-1)
+}
+
+func (p plotArea) y(v float64) float64 {
+	height := float64(chartHeight - marginTop - marginBottom)
+	return marginTop + height*(1-v/p.max)
+}
+
+// yScale returns the top of the value axis and the step between its grid lines, rounded to 1, 2 or 5
+// times a power of ten so that there are at most 5 steps.
+func yScale(lines []series) (float64, float64) {
+	max := 0.0
+	for _, line := range lines {
+		for _, v := range line.values {
+			if v != nil && *v > max {
+				max = *v
+			}
+		}
+	}
+	if max <= 0 {
+		return 1, 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(max/5)))
+	step := magnitude
+	for _, m := range []float64{1, 2, 5, 10} {
+		step = m * magnitude
+		if max/step <= 5 {
+			break
+		}
+	}
+	return math.Ceil(max/step) * step, step
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func escapeText(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package sprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func testReport(t *testing.T) *Report {
+	t.Helper()
+	report, err := Build(decodeTasks(t), testHistories, &Options{Start: date(4, 0), End: date(8, 0), Unit: Points, Now: date(6, 15)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report.Name = "Sprint <12>"
+	return report
+}
+
+func TestReport_WriteSVG(t *testing.T) {
+	tests := []struct {
+		name        string
+		chart       Chart
+		wantLines   int
+		wantCircles int
+		want        []string
+	}{
+		{
+			name:        "Burndown",
+			chart:       Burndown,
+			wantLines:   2,
+			wantCircles: 3,
+			want:        []string{"<title>Sprint &lt;12&gt; burndown</title>", `stroke-dasharray="6 4"`, ">Ideal<", ">Remaining<", ">Jul 8<", ">points<"},
+		},
+		{
+			name:        "Burnup",
+			chart:       Burnup,
+			wantLines:   2,
+			wantCircles: 6,
+			want:        []string{"<title>Sprint &lt;12&gt; burnup</title>", ">Scope<", ">Completed<", ">10<"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := testReport(t).WriteSVG(&b, tt.chart); err != nil {
+				t.Fatal(err)
+			}
+			svg := b.String()
+			if got := strings.Count(svg, "<polyline"); got != tt.wantLines {
+				t.Errorf("got %d lines, want %d", got, tt.wantLines)
+			}
+			if got := strings.Count(svg, "<circle"); got != tt.wantCircles {
+				t.Errorf("got %d points, want %d", got, tt.wantCircles)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(svg, want) {
+					t.Errorf("SVG does not contain %s", want)
+				}
+			}
+
+			decoder := xml.NewDecoder(&b)
+			for {
+				_, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("SVG is not well formed: %v", err)
+				}
+			}
+		})
+	}
+
+	if err := testReport(t).WriteSVG(&bytes.Buffer{}, "pie"); !errors.Is(err, clickup.ErrValidation) {
+		t.Errorf("WriteSVG() of an unknown chart error = %v, want ErrValidation", err)
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := testReport(t).WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Unit Unit `json:"unit"`
+		Days []struct {
+			Remaining *float64 `json:"remaining"`
+		} `json:"days"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Unit != Points || len(got.Days) != 5 || *got.Days[2].Remaining != 5 || got.Days[3].Remaining != nil {
+		t.Errorf("WriteJSON() = %s", b.String())
+	}
+}
+
+func TestYScale(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		wantMax  float64
+		wantStep float64
+	}{
+		{name: "Empty", wantMax: 1, wantStep: 1},
+		{name: "Small", values: []float64{3}, wantMax: 3, wantStep: 1},
+		{name: "Tens", values: []float64{8, 42}, wantMax: 50, wantStep: 10},
+		{name: "Twenties", values: []float64{61}, wantMax: 80, wantStep: 20},
+		{name: "Fractions", values: []float64{0.7}, wantMax: 0.8, wantStep: 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := series{}
+			for i := range tt.values {
+				line.values = append(line.values, &tt.values[i])
+			}
+			max, step := yScale([]series{line})
+			if !near(max, tt.wantMax) || !near(step, tt.wantStep) {
+				t.Errorf("yScale() = %v, %v, want %v, %v", max, step, tt.wantMax, tt.wantStep)
+			}
+		})
+	}
+}
+
+func near(a, b float64) bool {
+	return a-b < 1e-9 && b-a < 1e-9
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrStopWalk can be returned from a WalkFunc to end a walk early without an error.
+var ErrStopWalk = errors.New("stop walking task tree")
+
+type TaskTreeOptions struct {
+	WorkspaceID      string
+	UseCustomTaskIDs bool // applies to the root task id only; subtasks are always fetched by their Clickup id
+	MaxConcurrency   int  // maximum number of in flight requests.  Defaults to 4.
+}
+
+// TaskTree fetches taskID along with every level of subtasks beneath it.  The returned task's
+// Subtasks (and theirs, and so on) are fully populated.
+// Only subtasks that were returned without a subtasks array of their own are fetched again.
+// Each level of the tree is fetched concurrently with at most opts.MaxConcurrency requests in flight,
+// and requests that exceed the Clickup rate limit are retried once the limit resets.
+// ErrTaskCycle is returned if a subtask refers back to one of its ancestors.
+func (c *Client) TaskTree(ctx context.Context, taskID string, opts *TaskTreeOptions) (*SingleTask, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("must provide a task id to build a task tree: %w", ErrValidation)
+	}
+	if opts == nil {
+		opts = &TaskTreeOptions{}
+	}
+	if opts.UseCustomTaskIDs && opts.WorkspaceID == "" {
+		return nil, fmt.Errorf("workspaceID must be provided if querying by custom task id: %w", ErrValidation)
+	}
+	concurrency := opts.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	var root *SingleTask
+	err := retryOnRateLimit(ctx, func() error {
+		var err error
+		root, err = c.TaskByID(ctx, taskID, opts.WorkspaceID, opts.UseCustomTaskIDs, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	visited := map[string]bool{root.ID: true}
+	level := []*SingleTask{root}
+
+	for len(level) > 0 {
+		var next, missing []*SingleTask
+		for _, parent := range level {
+			for i := range parent.Subtasks {
+				child := &parent.Subtasks[i]
+				if visited[child.ID] {
+					return nil, fmt.Errorf("task %s appears more than once beneath %s: %w", child.ID, root.ID, ErrTaskCycle)
+				}
+				visited[child.ID] = true
+				next = append(next, child)
+				// a subtasks array in the parent's response, even an empty one, is complete
+				if child.Subtasks == nil {
+					missing = append(missing, child)
+				}
+			}
+		}
+
+		if err := c.fetchSubtasks(ctx, missing, opts.WorkspaceID, concurrency); err != nil {
+			return nil, err
+		}
+		level = next
+	}
+
+	return root, nil
+}
+
+// fetchSubtasks replaces each task in tasks with a fresh copy that includes its direct subtasks.
+func (c *Client) fetchSubtasks(ctx context.Context, tasks []*SingleTask, workspaceID string, concurrency int) error {
+	return runConcurrently(ctx, len(tasks), concurrency, func(ctx context.Context, i int) error {
+		task := tasks[i]
+
+		var fetched *SingleTask
+		err := retryOnRateLimit(ctx, func() error {
+			var err error
+			fetched, err = c.TaskByID(ctx, task.ID, workspaceID, false, true)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch subtask %s: %w", task.ID, err)
+		}
+		*task = *fetched
+		return nil
+	})
+}
+
+// TaskTreesForList queries every task in listID, including subtasks, and assembles them into trees.
+// Only the root tasks are returned; all descendants are reachable through Subtasks.
+// queryOpts may be nil.  Every page of the list is requested, so queryOpts.Page is ignored.
+// Subtasks whose parent is not part of the list are returned as roots.
+// ErrTaskCycle is returned if the parent references of the tasks form a loop.
+func (c *Client) TaskTreesForList(ctx context.Context, listID string, queryOpts *TaskQueryOptions) ([]SingleTask, error) {
+	if listID == "" {
+		return nil, fmt.Errorf("must provide a list id to build task trees: %w", ErrValidation)
+	}
+
+	opts := TaskQueryOptions{}
+	if queryOpts != nil {
+		opts = *queryOpts
+	}
+	opts.IncludeSubtasks = true
+
+	tasks, err := c.AllTasksForList(ctx, listID, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildTaskTrees(tasks)
+}
+
+// BuildTaskTrees links a flat slice of tasks together using SingleTask.Parent and returns the roots.
+// The relative order of tasks is preserved.  ErrTaskCycle is returned if the parent references loop.
+func BuildTaskTrees(tasks []SingleTask) ([]SingleTask, error) {
+	byID := make(map[string]SingleTask, len(tasks))
+	order := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		if _, ok := byID[task.ID]; ok {
+			continue
+		}
+		byID[task.ID] = task
+		order = append(order, task.ID)
+	}
+
+	children := make(map[string][]string, len(order))
+	var roots []string
+	for _, id := range order {
+		parent := byID[id].Parent
+		if _, ok := byID[parent]; parent != "" && ok {
+			children[parent] = append(children[parent], id)
+			continue
+		}
+		roots = append(roots, id)
+	}
+
+	var assembled int
+	var build func(id string) SingleTask
+	build = func(id string) SingleTask {
+		task := byID[id]
+		assembled++
+		task.Subtasks = nil
+		for _, childID := range children[id] {
+			task.Subtasks = append(task.Subtasks, build(childID))
+		}
+		return task
+	}
+
+	trees := make([]SingleTask, 0, len(roots))
+	for _, id := range roots {
+		trees = append(trees, build(id))
+	}
+
+	// tasks that are only reachable from each other never hang off of a root
+	if assembled != len(byID) {
+		return nil, fmt.Errorf("%d tasks could not be attached to a root task: %w", len(byID)-assembled, ErrTaskCycle)
+	}
+
+	return trees, nil
+}
+
+// WalkFunc is called for each task visited by SingleTask.Walk.  depth is 0 for the task
+// Walk was called on.  Returning ErrStopWalk ends the walk without an error.
+type WalkFunc func(task *SingleTask, depth int) error
+
+// Walk visits t and all of its descendants depth first, parents before children.
+func (t *SingleTask) Walk(fn WalkFunc) error {
+	err := t.walk(fn, 0)
+	if errors.Is(err, ErrStopWalk) {
+		return nil
+	}
+	return err
+}
+
+func (t *SingleTask) walk(fn WalkFunc, depth int) error {
+	if err := fn(t, depth); err != nil {
+		return err
+	}
+	for i := range t.Subtasks {
+		if err := t.Subtasks[i].walk(fn, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flatten returns t and all of its descendants in depth first order.  The Subtasks of the
+// returned tasks are left intact.
+func (t *SingleTask) Flatten() []SingleTask {
+	var flat []SingleTask
+	t.Walk(func(task *SingleTask, depth int) error {
+		flat = append(flat, *task)
+		return nil
+	})
+	return flat
+}
+
+// TaskRollup holds totals that are summed across a task tree.
+type TaskRollup struct {
+	Tasks        int
+	ClosedTasks  int
+	TimeEstimate int // milliseconds
+	TimeSpent    int // milliseconds
+	Points       float64
+	MaxDepth     int
+}
+
+// Rollup sums the time estimates, time spent, and points of every descendant of t.
+// t itself is not included in the totals.
+func (t *SingleTask) Rollup() TaskRollup {
+	var rollup TaskRollup
+	t.Walk(func(task *SingleTask, depth int) error {
+		if depth == 0 {
+			return nil
+		}
+		rollup.Tasks++
+		if task.Status.Type == "closed" {
+			rollup.ClosedTasks++
+		}
+		rollup.TimeEstimate += task.TimeEstimate
+		rollup.TimeSpent += task.TimeSpent
+		rollup.Points += task.Points
+		if depth > rollup.MaxDepth {
+			rollup.MaxDepth = depth
+		}
+		return nil
+	})
+	return rollup
+}
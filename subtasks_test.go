@@ -0,0 +1,206 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// mockTaskTreeDoer answers task requests from tasks and appends each requested id to fetched.
+func mockTaskTreeDoer(tasks map[string]string, fetched *[]string) *mockHTTPClient {
+	var mu sync.Mutex
+	return newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+		id := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/task/"), "/")
+		mu.Lock()
+		*fetched = append(*fetched, id)
+		mu.Unlock()
+		body, ok := tasks[id]
+		if !ok {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"err": "Task not found", "ECODE": "ITEM_013"}`)),
+				Request:    req,
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestClient_TaskTree(t *testing.T) {
+	type args struct {
+		taskID string
+		opts   *TaskTreeOptions
+	}
+	tests := []struct {
+		name         string
+		tasks        map[string]string
+		args         args
+		wantRollup   TaskRollup
+		wantFetched  []string
+		wantErr      bool
+		wantCycleErr bool
+	}{
+		{
+			name: "Success three levels",
+			tasks: map[string]string{
+				"root": `{"id": "root", "time_estimate": 1, "subtasks": [{"id": "a"}, {"id": "b"}]}`,
+				"a":    `{"id": "a", "parent": "root", "time_estimate": 10, "points": 1, "subtasks": [{"id": "a1"}]}`,
+				"b":    `{"id": "b", "parent": "root", "time_estimate": 20, "points": 2, "status": {"type": "closed"}}`,
+				"a1":   `{"id": "a1", "parent": "a", "time_estimate": 30, "points": 0.5}`,
+			},
+			args: args{
+				taskID: "root",
+				opts:   &TaskTreeOptions{MaxConcurrency: 2},
+			},
+			wantRollup: TaskRollup{
+				Tasks:        3,
+				ClosedTasks:  1,
+				TimeEstimate: 60,
+				Points:       3.5,
+				MaxDepth:     2,
+			},
+			wantFetched: []string{"a", "a1", "b", "root"},
+		},
+		{
+			name: "Success inline subtasks are not fetched again",
+			tasks: map[string]string{
+				"root": `{"id": "root", "subtasks": [
+					{"id": "a", "points": 1, "subtasks": [{"id": "a1", "points": 0.5, "subtasks": []}]},
+					{"id": "b", "points": 2}
+				]}`,
+				"b": `{"id": "b", "points": 2, "subtasks": []}`,
+			},
+			args: args{
+				taskID: "root",
+			},
+			wantRollup: TaskRollup{
+				Tasks:    3,
+				Points:   3.5,
+				MaxDepth: 2,
+			},
+			wantFetched: []string{"b", "root"},
+		},
+		{
+			name: "Fail cycle",
+			tasks: map[string]string{
+				"root": `{"id": "root", "subtasks": [{"id": "a"}]}`,
+				"a":    `{"id": "a", "subtasks": [{"id": "root"}]}`,
+			},
+			args: args{
+				taskID: "root",
+			},
+			wantErr:      true,
+			wantCycleErr: true,
+		},
+		{
+			name: "Fail subtask not found",
+			tasks: map[string]string{
+				"root": `{"id": "root", "subtasks": [{"id": "missing"}]}`,
+			},
+			args: args{
+				taskID: "root",
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Fail custom task id without workspace",
+			tasks: map[string]string{},
+			args: args{
+				taskID: "root",
+				opts:   &TaskTreeOptions{UseCustomTaskIDs: true},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched []string
+			c := &Client{
+				doer:          mockTaskTreeDoer(tt.tasks, &fetched),
+				authenticator: &APITokenAuthenticator{},
+			}
+			got, err := c.TaskTree(context.Background(), tt.args.taskID, tt.args.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.TaskTree() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if errors.Is(err, ErrTaskCycle) != tt.wantCycleErr {
+				t.Errorf("Client.TaskTree() error = %v, wantCycleErr %v", err, tt.wantCycleErr)
+			}
+			if err != nil {
+				return
+			}
+			if rollup := got.Rollup(); rollup != tt.wantRollup {
+				t.Errorf("SingleTask.Rollup() = %+v, want %+v", rollup, tt.wantRollup)
+			}
+			sort.Strings(fetched)
+			if !reflect.DeepEqual(fetched, tt.wantFetched) {
+				t.Errorf("Client.TaskTree() fetched %v, want %v", fetched, tt.wantFetched)
+			}
+		})
+	}
+}
+
+func TestBuildTaskTrees(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []SingleTask
+		wantFlat []string
+		wantErr  bool
+	}{
+		{
+			name: "Success nested and orphaned subtasks",
+			tasks: []SingleTask{
+				{ID: "a1", Parent: "a"},
+				{ID: "a"},
+				{ID: "orphan", Parent: "elsewhere"},
+				{ID: "a2", Parent: "a"},
+				{ID: "a1x", Parent: "a1"},
+			},
+			wantFlat: []string{"a", "a1", "a1x", "a2", "orphan"},
+		},
+		{
+			name: "Fail parent cycle",
+			tasks: []SingleTask{
+				{ID: "root"},
+				{ID: "x", Parent: "y"},
+				{ID: "y", Parent: "x"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trees, err := BuildTaskTrees(tt.tasks)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildTaskTrees() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var flat []string
+			for _, tree := range trees {
+				for _, task := range tree.Flatten() {
+					flat = append(flat, task.ID)
+				}
+			}
+			if strings.Join(flat, ",") != strings.Join(tt.wantFlat, ",") {
+				t.Errorf("BuildTaskTrees() flattened = %v, want %v", flat, tt.wantFlat)
+			}
+		})
+	}
+}
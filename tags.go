@@ -12,6 +12,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 type Tag struct {
@@ -21,6 +23,15 @@ type Tag struct {
 	Creator int    `json:"creator"`
 }
 
+// TagNames returns the names of tags.
+func TagNames(tags []Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
 type TagsQueryResponse struct {
 	Tags []Tag `json:"tags"`
 }
@@ -54,7 +65,104 @@ func (c *Client) CreateSpaceTag(ctx context.Context, spaceID string, tag Tag) er
 	return c.call(ctx, http.MethodPost, endpoint, buf, &struct{}{})
 }
 
-// UpdateSpaceTag updates an existing tag in the specified space with the parameters from tag.
-func (c *Client) UpdateSpaceTag(ctx context.Context, spacID, tag Tag) error {
-	panic("TODO not implemented")
+// updateSpaceTagRequest is the body of Edit Space Tag, which names the colors fg_color and bg_color
+// rather than tag_fg and tag_bg as tags are read.
+type updateSpaceTagRequest struct {
+	Tag struct {
+		Name    string `json:"name"`
+		FgColor string `json:"fg_color,omitempty"`
+		BgColor string `json:"bg_color,omitempty"`
+	} `json:"tag"`
+}
+
+// UpdateSpaceTag updates the existing tag named tagName in the space with spaceID using the parameters from tag.
+// The tag is renamed if tag.Name is provided and differs from tagName.  Tasks that have the tag keep it under its new name.
+// If tag.Name is empty, the tag keeps its current name.
+func (c *Client) UpdateSpaceTag(ctx context.Context, spaceID, tagName string, tag Tag) error {
+	if spaceID == "" {
+		return fmt.Errorf("must provide a space id to update a tag: %w", ErrValidation)
+	}
+	if tagName == "" {
+		return fmt.Errorf("must provide the name of the tag to update: %w", ErrValidation)
+	}
+
+	var request updateSpaceTagRequest
+	request.Tag.Name = tagName
+	if tag.Name != "" {
+		request.Tag.Name = tag.Name
+	}
+	request.Tag.FgColor = tag.TagFg
+	request.Tag.BgColor = tag.TagBg
+
+	b, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("unable to serialize tag: %w", err)
+	}
+	buf := bytes.NewBuffer(b)
+
+	endpoint := fmt.Sprintf("/space/%s/tag/%s", spaceID, url.PathEscape(tagName))
+
+	if err := c.call(ctx, http.MethodPut, endpoint, buf, &struct{}{}); err != nil {
+		return fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSpaceTag removes the tag named tagName from the space with spaceID.
+func (c *Client) DeleteSpaceTag(ctx context.Context, spaceID, tagName string) error {
+	if spaceID == "" {
+		return fmt.Errorf("must provide a space id to delete a tag: %w", ErrValidation)
+	}
+	if tagName == "" {
+		return fmt.Errorf("must provide the name of the tag to delete: %w", ErrValidation)
+	}
+
+	endpoint := fmt.Sprintf("/space/%s/tag/%s", spaceID, url.PathEscape(tagName))
+
+	if err := c.call(ctx, http.MethodDelete, endpoint, nil, &struct{}{}); err != nil {
+		return fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return nil
+}
+
+// AddTagToTask adds the existing space tag named tagName to taskID.  useCustomTaskIDs should be true if taskID is a custom ID.
+func (c *Client) AddTagToTask(ctx context.Context, taskID, tagName, workspaceID string, useCustomTaskIDs bool) error {
+	return c.taskTag(ctx, http.MethodPost, taskID, tagName, workspaceID, useCustomTaskIDs)
+}
+
+// RemoveTagFromTask removes the tag named tagName from taskID.  The tag itself remains in the space.
+// useCustomTaskIDs should be true if taskID is a custom ID.
+func (c *Client) RemoveTagFromTask(ctx context.Context, taskID, tagName, workspaceID string, useCustomTaskIDs bool) error {
+	return c.taskTag(ctx, http.MethodDelete, taskID, tagName, workspaceID, useCustomTaskIDs)
+}
+
+func (c *Client) taskTag(ctx context.Context, method, taskID, tagName, workspaceID string, useCustomTaskIDs bool) error {
+	if useCustomTaskIDs && workspaceID == "" {
+		return fmt.Errorf("workspaceID must be provided if using a custom task id: %w", ErrValidation)
+	}
+	if taskID == "" {
+		return fmt.Errorf("must provide a task id: %w", ErrValidation)
+	}
+	if tagName == "" {
+		return fmt.Errorf("must provide a tag name: %w", ErrValidation)
+	}
+
+	urlValues := url.Values{}
+	urlValues.Set("custom_task_ids", strconv.FormatBool(useCustomTaskIDs))
+	urlValues.Add("team_id", workspaceID)
+
+	endpoint := fmt.Sprintf("/task/%s/tag/%s/?%s", taskID, url.PathEscape(tagName), urlValues.Encode())
+
+	var data *bytes.Buffer
+	if method == http.MethodPost {
+		data = &bytes.Buffer{}
+	}
+
+	if err := c.call(ctx, method, endpoint, data, &struct{}{}); err != nil {
+		return fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return nil
 }
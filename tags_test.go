@@ -133,32 +133,200 @@ func TestClient_CreateSpaceTag(t *testing.T) {
 
 func TestClient_UpdateSpaceTag(t *testing.T) {
 	type fields struct {
-		doer          ClientDoer
-		authenticator Authenticator
-		baseURL       string
+		doer    ClientDoer
+		baseURL string
 	}
 	type args struct {
-		spacID Tag
-		tag    Tag
+		spaceID string
+		tagName string
+		tag     Tag
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name: "TestSuccessful rename tag",
+			args: args{
+				spaceID: "fakeSpaceID",
+				tagName: "old name",
+				tag:     Tag{Name: "new name", TagBg: "#ffffff"},
+			},
+			wantBody: `{"tag":{"name":"new name","bg_color":"#ffffff"}}`,
+			wantErr:  false,
+		},
+		{
+			name: "TestSuccessful recolor keeps name",
+			args: args{
+				spaceID: "fakeSpaceID",
+				tagName: "old name",
+				tag:     Tag{TagFg: "#000000"},
+			},
+			wantBody: `{"tag":{"name":"old name","fg_color":"#000000"}}`,
+			wantErr:  false,
+		},
+		{
+			name: "TestFailure missing tag name",
+			args: args{
+				spaceID: "fakeSpaceID",
+				tag:     Tag{Name: "new name"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.URL.EscapedPath() != "/space/fakeSpaceID/tag/old%20name" {
+						t.Errorf("unexpected path %s", req.URL.EscapedPath())
+					}
+					b, _ := ioutil.ReadAll(req.Body)
+					if string(b) != tt.wantBody {
+						t.Errorf("request body = %s, want %s", b, tt.wantBody)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+				baseURL:       tt.fields.baseURL,
+			}
+			if err := c.UpdateSpaceTag(context.Background(), tt.args.spaceID, tt.args.tagName, tt.args.tag); (err != nil) != tt.wantErr {
+				t.Errorf("Client.UpdateSpaceTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteSpaceTag(t *testing.T) {
+	type args struct {
+		spaceID string
+		tagName string
 	}
 	tests := []struct {
 		name    string
-		fields  fields
+		doer    ClientDoer
 		args    args
 		wantErr bool
 	}{
-		// TODO: Add test cases.
+		{
+			name: "TestSuccessful delete tag",
+			doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+					Request:    req,
+				}, nil
+			}),
+			args: args{
+				spaceID: "fakeSpaceID",
+				tagName: "fakeTag",
+			},
+			wantErr: false,
+		},
+		{
+			name: "TestFailure missing space id",
+			args: args{
+				tagName: "fakeTag",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Client{
-				doer:          tt.fields.doer,
-				authenticator: tt.fields.authenticator,
-				baseURL:       tt.fields.baseURL,
+				doer:          tt.doer,
+				authenticator: &APITokenAuthenticator{},
 			}
-			if err := c.UpdateSpaceTag(context.Background(), tt.args.spacID, tt.args.tag); (err != nil) != tt.wantErr {
-				t.Errorf("Client.UpdateSpaceTag() error = %v, wantErr %v", err, tt.wantErr)
+			if err := c.DeleteSpaceTag(context.Background(), tt.args.spaceID, tt.args.tagName); (err != nil) != tt.wantErr {
+				t.Errorf("Client.DeleteSpaceTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_AddTagToTask(t *testing.T) {
+	type args struct {
+		taskID           string
+		tagName          string
+		workspaceID      string
+		useCustomTaskIDs bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "TestSuccessful add tag with custom task id",
+			args: args{
+				taskID:           "CUSTOM-1",
+				tagName:          "fakeTag",
+				workspaceID:      "fakeWorkspace",
+				useCustomTaskIDs: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "TestFailure custom task id without workspace",
+			args: args{
+				taskID:           "CUSTOM-1",
+				tagName:          "fakeTag",
+				useCustomTaskIDs: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "TestFailure missing tag name",
+			args: args{
+				taskID: "fakeTask",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPost || req.URL.Query().Get("custom_task_ids") != "true" {
+						t.Errorf("unexpected request %s %s", req.Method, req.URL)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			if err := c.AddTagToTask(context.Background(), tt.args.taskID, tt.args.tagName, tt.args.workspaceID, tt.args.useCustomTaskIDs); (err != nil) != tt.wantErr {
+				t.Errorf("Client.AddTagToTask() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestClient_RemoveTagFromTask(t *testing.T) {
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodDelete || req.URL.Path != "/task/fakeTask/tag/fakeTag/" {
+				t.Errorf("unexpected request %s %s", req.Method, req.URL)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+				Request:    req,
+			}, nil
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+	if err := c.RemoveTagFromTask(context.Background(), "fakeTask", "fakeTag", "", false); err != nil {
+		t.Errorf("Client.RemoveTagFromTask() error = %v", err)
+	}
+}
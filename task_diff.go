@@ -0,0 +1,295 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaskField is a field of a task compared by DiffTasks.
+type TaskField string
+
+const (
+	TaskFieldName          TaskField = "name"
+	TaskFieldDescription   TaskField = "description"
+	TaskFieldStatus        TaskField = "status"
+	TaskFieldPriority      TaskField = "priority"
+	TaskFieldAssignees     TaskField = "assignees"
+	TaskFieldWatchers      TaskField = "watchers"
+	TaskFieldTags          TaskField = "tags"
+	TaskFieldDueDate       TaskField = "due_date"
+	TaskFieldStartDate     TaskField = "start_date"
+	TaskFieldPoints        TaskField = "points"
+	TaskFieldTimeEstimate  TaskField = "time_estimate"
+	TaskFieldParent        TaskField = "parent"
+	TaskFieldCustomField   TaskField = "custom_field"
+	TaskFieldChecklistItem TaskField = "checklist_item"
+)
+
+// TaskChange is a change to one field of a task.  Values are formatted as text and are empty when
+// unset: dates as RFC 3339, time estimates as durations such as "1h30m0s", users by username and
+// custom fields by option name, label or username where they refer to one.
+type TaskChange struct {
+	Field TaskField `json:"field"`
+	// ID and Name identify the custom field or checklist item that changed.
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+	// Added and Removed are set instead of Before and After for assignees, watchers and tags.
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// TaskChangeset is the difference between two versions of a task.
+type TaskChangeset struct {
+	TaskID  string       `json:"task_id"`
+	Changes []TaskChange `json:"changes"`
+}
+
+// DiffTasks compares two versions of a task and returns the fields that changed, in the order of
+// the TaskField constants.  Custom fields are matched by id and checklist items by id, and only the
+// resolution of checklist items is compared.
+func DiffTasks(before, after SingleTask) *TaskChangeset {
+	d := &TaskChangeset{TaskID: after.ID, Changes: []TaskChange{}}
+	if d.TaskID == "" {
+		d.TaskID = before.ID
+	}
+
+	d.value(TaskFieldName, before.Name, after.Name)
+	d.value(TaskFieldDescription, before.Description, after.Description)
+	d.value(TaskFieldStatus, before.Status.Status, after.Status.Status)
+	d.value(TaskFieldPriority, before.Priority.Priority, after.Priority.Priority)
+	d.users(TaskFieldAssignees, before.Assignees, after.Assignees)
+	d.users(TaskFieldWatchers, before.Watchers, after.Watchers)
+	d.set(TaskFieldTags, TagNames(before.Tags), TagNames(after.Tags))
+	d.value(TaskFieldDueDate, diffTime(before.DueDate), diffTime(after.DueDate))
+	d.value(TaskFieldStartDate, diffTime(before.StartDate), diffTime(after.StartDate))
+	d.value(TaskFieldPoints, diffFloat(before.Points), diffFloat(after.Points))
+	d.value(TaskFieldTimeEstimate, diffDuration(before.TimeEstimate), diffDuration(after.TimeEstimate))
+	d.value(TaskFieldParent, before.Parent, after.Parent)
+	d.customFields(before, after)
+	d.checklistItems(before, after)
+	return d
+}
+
+// Empty reports whether nothing changed.
+func (d *TaskChangeset) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Summary describes the changes in English, one per line.
+func (d *TaskChangeset) Summary() string {
+	lines := make([]string, 0, len(d.Changes))
+	for _, c := range d.Changes {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// String describes the change in English, such as `status changed from "open" to "done"`.
+func (c TaskChange) String() string {
+	label := strings.Replace(string(c.Field), "_", " ", -1)
+	switch c.Field {
+	case TaskFieldCustomField:
+		label = fmt.Sprintf("%s %q", label, c.Name)
+	case TaskFieldChecklistItem:
+		if c.After == "true" {
+			return fmt.Sprintf("%s %q resolved", label, c.Name)
+		}
+		return fmt.Sprintf("%s %q unresolved", label, c.Name)
+	case TaskFieldDescription:
+		if c.Before != "" && c.After != "" {
+			return "description changed"
+		}
+	case TaskFieldAssignees, TaskFieldWatchers, TaskFieldTags:
+		var parts []string
+		if len(c.Added) > 0 {
+			parts = append(parts, "added "+strings.Join(c.Added, ", "))
+		}
+		if len(c.Removed) > 0 {
+			parts = append(parts, "removed "+strings.Join(c.Removed, ", "))
+		}
+		return label + ": " + strings.Join(parts, "; ")
+	}
+
+	switch {
+	case c.Before == "":
+		return fmt.Sprintf("%s set to %q", label, c.After)
+	case c.After == "":
+		return fmt.Sprintf("%s cleared (was %q)", label, c.Before)
+	}
+	return fmt.Sprintf("%s changed from %q to %q", label, c.Before, c.After)
+}
+
+func (d *TaskChangeset) value(field TaskField, before, after string) {
+	if before != after {
+		d.Changes = append(d.Changes, TaskChange{Field: field, Before: before, After: after})
+	}
+}
+
+func (d *TaskChangeset) users(field TaskField, before, after []TeamUser) {
+	name := func(user TeamUser) string {
+		switch {
+		case user.Username != "":
+			return user.Username
+		case user.Email != "":
+			return user.Email
+		}
+		return strconv.Itoa(user.ID)
+	}
+	ids := func(users []TeamUser) map[int]bool {
+		m := map[int]bool{}
+		for _, user := range users {
+			m[user.ID] = true
+		}
+		return m
+	}
+	beforeIDs, afterIDs := ids(before), ids(after)
+
+	var change TaskChange
+	for _, user := range after {
+		if !beforeIDs[user.ID] {
+			change.Added = append(change.Added, name(user))
+		}
+	}
+	for _, user := range before {
+		if !afterIDs[user.ID] {
+			change.Removed = append(change.Removed, name(user))
+		}
+	}
+	if len(change.Added) > 0 || len(change.Removed) > 0 {
+		change.Field = field
+		d.Changes = append(d.Changes, change)
+	}
+}
+
+// set records the strings added to and removed from a set, such as tag names.
+func (d *TaskChangeset) set(field TaskField, before, after []string) {
+	contains := func(values []string, v string) bool {
+		for _, value := range values {
+			if value == v {
+				return true
+			}
+		}
+		return false
+	}
+	change := TaskChange{Field: field}
+	for _, v := range after {
+		if !contains(before, v) {
+			change.Added = append(change.Added, v)
+		}
+	}
+	for _, v := range before {
+		if !contains(after, v) {
+			change.Removed = append(change.Removed, v)
+		}
+	}
+	if len(change.Added) > 0 || len(change.Removed) > 0 {
+		d.Changes = append(d.Changes, change)
+	}
+}
+
+// customFields compares custom field values by field id, in the order of the fields of after and then
+// of the fields only before has.
+func (d *TaskChangeset) customFields(before, after SingleTask) {
+	type field struct {
+		name  string
+		value string
+	}
+	values := func(task SingleTask) ([]string, map[string]field) {
+		var ids []string
+		m := map[string]field{}
+		for i, f := range task.CustomFields {
+			ids = append(ids, f.ID)
+			m[f.ID] = field{name: f.Name, value: customFieldText(task, i)}
+		}
+		return ids, m
+	}
+	beforeIDs, beforeValues := values(before)
+	afterIDs, afterValues := values(after)
+
+	seen := map[string]bool{}
+	for _, id := range append(afterIDs, beforeIDs...) {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		b, a := beforeValues[id], afterValues[id]
+		if b.value == a.value {
+			continue
+		}
+		name := a.name
+		if name == "" {
+			name = b.name
+		}
+		d.Changes = append(d.Changes, TaskChange{Field: TaskFieldCustomField, ID: id, Name: name, Before: b.value, After: a.value})
+	}
+}
+
+// checklistItems records the checklist items of after whose resolution differs from before.
+func (d *TaskChangeset) checklistItems(before, after SingleTask) {
+	resolved := map[string]bool{}
+	for _, checklist := range before.Checklists {
+		for _, item := range checklist.Items {
+			resolved[item.ID] = item.Resolved
+		}
+	}
+	for _, checklist := range after.Checklists {
+		for _, item := range checklist.Items {
+			was, ok := resolved[item.ID]
+			if !ok || was == item.Resolved {
+				continue
+			}
+			d.Changes = append(d.Changes, TaskChange{
+				Field:  TaskFieldChecklistItem,
+				ID:     item.ID,
+				Name:   item.Name,
+				Before: strconv.FormatBool(was),
+				After:  strconv.FormatBool(item.Resolved),
+			})
+		}
+	}
+}
+
+// customFieldText formats the i'th custom field of task.  Dates are written as RFC 3339 and lists, such
+// as labels, as sorted, comma separated text.
+func customFieldText(task SingleTask, i int) string {
+	values := task.CustomFieldStrings(i)
+	if task.CustomFields[i].Type == "date" && len(values) == 1 {
+		if ms, err := strconv.ParseInt(values[0], 10, 64); err == nil {
+			return diffTime(TimestampFromMillis(ms))
+		}
+	}
+	sort.Strings(values)
+	return strings.Join(values, ", ")
+}
+
+func diffTime(t Timestamp) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func diffFloat(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func diffDuration(ms int) string {
+	if ms == 0 {
+		return ""
+	}
+	return (time.Duration(ms) * time.Millisecond).String()
+}
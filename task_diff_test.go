@@ -0,0 +1,152 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func taskFromJSON(t *testing.T, s string) SingleTask {
+	t.Helper()
+	var task SingleTask
+	if err := json.Unmarshal([]byte(s), &task); err != nil {
+		t.Fatal(err)
+	}
+	return task
+}
+
+func TestDiffTasks(t *testing.T) {
+	tests := []struct {
+		name        string
+		before      string
+		after       string
+		want        []TaskChange
+		wantSummary string
+	}{
+		{
+			name:        "Unchanged",
+			before:      `{"id": "t1", "name": "Fix it", "tags": [{"name": "bug"}], "assignees": [{"id": 1}]}`,
+			after:       `{"id": "t1", "name": "Fix it", "tags": [{"name": "bug"}], "assignees": [{"id": 1}], "date_updated": "1656633600000"}`,
+			want:        []TaskChange{},
+			wantSummary: "",
+		},
+		{
+			name: "Fields",
+			before: `{"id": "t1", "name": "Fix it", "description": "Old", "status": {"status": "open"},
+				"priority": {"priority": "normal"}, "due_date": "1656633600000", "points": 3, "time_estimate": 3600000}`,
+			after: `{"id": "t1", "name": "Fix it now", "description": "New", "status": {"status": "in progress"},
+				"priority": null, "start_date": "1656547200000", "points": 5, "time_estimate": 5400000, "parent": "t0"}`,
+			want: []TaskChange{
+				{Field: TaskFieldName, Before: "Fix it", After: "Fix it now"},
+				{Field: TaskFieldDescription, Before: "Old", After: "New"},
+				{Field: TaskFieldStatus, Before: "open", After: "in progress"},
+				{Field: TaskFieldPriority, Before: "normal"},
+				{Field: TaskFieldDueDate, Before: "2022-07-01T00:00:00Z"},
+				{Field: TaskFieldStartDate, After: "2022-06-30T00:00:00Z"},
+				{Field: TaskFieldPoints, Before: "3", After: "5"},
+				{Field: TaskFieldTimeEstimate, Before: "1h0m0s", After: "1h30m0s"},
+				{Field: TaskFieldParent, After: "t0"},
+			},
+			wantSummary: `name changed from "Fix it" to "Fix it now"
+description changed
+status changed from "open" to "in progress"
+priority cleared (was "normal")
+due date cleared (was "2022-07-01T00:00:00Z")
+start date set to "2022-06-30T00:00:00Z"
+points changed from "3" to "5"
+time estimate changed from "1h0m0s" to "1h30m0s"
+parent set to "t0"`,
+		},
+		{
+			name: "People and tags",
+			before: `{"id": "t1", "assignees": [{"id": 1, "username": "ana"}, {"id": 2, "username": "bo"}],
+				"watchers": [{"id": 1, "username": "ana"}], "tags": [{"name": "bug"}, {"name": "ui"}]}`,
+			after: `{"id": "t1", "assignees": [{"id": 2, "username": "bo"}, {"id": 3, "email": "cy@example.com"}],
+				"watchers": [{"id": 1, "username": "ana"}, {"id": 4}], "tags": [{"name": "ui"}, {"name": "p1"}]}`,
+			want: []TaskChange{
+				{Field: TaskFieldAssignees, Added: []string{"cy@example.com"}, Removed: []string{"ana"}},
+				{Field: TaskFieldWatchers, Added: []string{"4"}},
+				{Field: TaskFieldTags, Added: []string{"p1"}, Removed: []string{"bug"}},
+			},
+			wantSummary: "assignees: added cy@example.com; removed ana\nwatchers: added 4\ntags: added p1; removed bug",
+		},
+		{
+			name: "Custom fields",
+			before: `{"id": "t1", "custom_fields": [
+				{"id": "cf1", "name": "Sprint", "type": "number", "value": "4"},
+				{"id": "cf2", "name": "Size", "type": "drop_down", "value": "o1",
+					"type_config": {"options": [{"id": "o1", "name": "S"}, {"id": "o2", "name": "L"}]}},
+				{"id": "cf3", "name": "Areas", "type": "labels", "value": ["a1"],
+					"type_config": {"options": [{"id": "a1", "label": "API"}, {"id": "a2", "label": "Web"}]}},
+				{"id": "cf4", "name": "Old", "type": "short_text", "value": "gone"}]}`,
+			after: `{"id": "t1", "custom_fields": [
+				{"id": "cf1", "name": "Sprint", "type": "number", "value": "4"},
+				{"id": "cf2", "name": "Size", "type": "drop_down", "value": "o2",
+					"type_config": {"options": [{"id": "o1", "name": "S"}, {"id": "o2", "name": "L"}]}},
+				{"id": "cf3", "name": "Areas", "type": "labels", "value": ["a2", "a1"],
+					"type_config": {"options": [{"id": "a1", "label": "API"}, {"id": "a2", "label": "Web"}]}},
+				{"id": "cf5", "name": "Review", "type": "date", "value": "1656633600000"}]}`,
+			want: []TaskChange{
+				{Field: TaskFieldCustomField, ID: "cf2", Name: "Size", Before: "S", After: "L"},
+				{Field: TaskFieldCustomField, ID: "cf3", Name: "Areas", Before: "API", After: "API, Web"},
+				{Field: TaskFieldCustomField, ID: "cf5", Name: "Review", After: "2022-07-01T00:00:00Z"},
+				{Field: TaskFieldCustomField, ID: "cf4", Name: "Old", Before: "gone"},
+			},
+			wantSummary: `custom field "Size" changed from "S" to "L"
+custom field "Areas" changed from "API" to "API, Web"
+custom field "Review" set to "2022-07-01T00:00:00Z"
+custom field "Old" cleared (was "gone")`,
+		},
+		{
+			name: "Drop down by orderindex",
+			before: `{"id": "t1", "custom_fields": [{"id": "cf2", "name": "Size", "type": "drop_down", "value": 1,
+				"type_config": {"options": [{"id": "o1", "name": "S", "orderindex": 0}, {"id": "o2", "name": "M", "orderindex": 1},
+					{"id": "o3", "name": "L", "orderindex": 2}]}}]}`,
+			after: `{"id": "t1", "custom_fields": [{"id": "cf2", "name": "Size", "type": "drop_down", "value": 2,
+				"type_config": {"options": [{"id": "o1", "name": "S", "orderindex": 0}, {"id": "o2", "name": "M", "orderindex": 1},
+					{"id": "o3", "name": "L", "orderindex": 2}]}}]}`,
+			want:        []TaskChange{{Field: TaskFieldCustomField, ID: "cf2", Name: "Size", Before: "M", After: "L"}},
+			wantSummary: `custom field "Size" changed from "M" to "L"`,
+		},
+		{
+			name: "Checklist items",
+			before: `{"id": "t1", "checklists": [{"id": "c1", "items": [
+				{"id": "i1", "name": "Write docs", "resolved": false},
+				{"id": "i2", "name": "Tests", "resolved": true},
+				{"id": "i3", "name": "Same", "resolved": true}]}]}`,
+			after: `{"id": "t1", "checklists": [{"id": "c1", "items": [
+				{"id": "i1", "name": "Write docs", "resolved": true},
+				{"id": "i2", "name": "Tests", "resolved": false},
+				{"id": "i3", "name": "Same", "resolved": true},
+				{"id": "i4", "name": "New", "resolved": true}]}]}`,
+			want: []TaskChange{
+				{Field: TaskFieldChecklistItem, ID: "i1", Name: "Write docs", Before: "false", After: "true"},
+				{Field: TaskFieldChecklistItem, ID: "i2", Name: "Tests", Before: "true", After: "false"},
+			},
+			wantSummary: "checklist item \"Write docs\" resolved\nchecklist item \"Tests\" unresolved",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffTasks(taskFromJSON(t, tt.before), taskFromJSON(t, tt.after))
+			if got.TaskID != "t1" {
+				t.Errorf("TaskID = %q, want t1", got.TaskID)
+			}
+			if !reflect.DeepEqual(got.Changes, tt.want) {
+				t.Errorf("Changes = %+v, want %+v", got.Changes, tt.want)
+			}
+			if got.Empty() != (len(tt.want) == 0) {
+				t.Errorf("Empty() = %v", got.Empty())
+			}
+			if summary := got.Summary(); summary != tt.wantSummary {
+				t.Errorf("Summary() =\n%s\nwant\n%s", summary, tt.wantSummary)
+			}
+		})
+	}
+}
@@ -0,0 +1,535 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaskQuery filters, sorts, groups and totals tasks that have already been fetched, such as the
+// results of TasksForList, AllTasksForList or TasksForView.  Every method returns a new query and
+// leaves the original unchanged.
+//
+//	q := clickup.QueryTasks(list.Tasks, view.Tasks).
+//		Where(clickup.HasStatusType("open"), clickup.DueBetween(time.Time{}, friday)).
+//		SortBy(clickup.ByPriority(), clickup.ByDueDate())
+//	for _, group := range q.GroupBy(clickup.GroupByAssignee()) { ... }
+type TaskQuery struct {
+	tasks      []SingleTask
+	predicates []TaskPredicate
+	orders     []TaskOrder
+}
+
+// QueryTasks starts a query over one or more sets of tasks.  Tasks that appear in more than one set
+// are included once, from the first set they appear in.
+func QueryTasks(taskSets ...[]SingleTask) *TaskQuery {
+	seen := make(map[string]bool)
+	var tasks []SingleTask
+	for _, set := range taskSets {
+		for _, task := range set {
+			if task.ID != "" && seen[task.ID] {
+				continue
+			}
+			seen[task.ID] = true
+			tasks = append(tasks, task)
+		}
+	}
+	return &TaskQuery{tasks: tasks}
+}
+
+// Where narrows the query to the tasks matching every predicate.
+func (q *TaskQuery) Where(predicates ...TaskPredicate) *TaskQuery {
+	next := q.clone()
+	next.predicates = append(next.predicates, predicates...)
+	return next
+}
+
+// SortBy orders the tasks by the first order, breaking ties with the ones that follow.  Calling SortBy
+// again replaces the previous orders.
+func (q *TaskQuery) SortBy(orders ...TaskOrder) *TaskQuery {
+	next := q.clone()
+	next.orders = append([]TaskOrder{}, orders...)
+	return next
+}
+
+func (q *TaskQuery) clone() *TaskQuery {
+	return &TaskQuery{
+		tasks:      q.tasks,
+		predicates: append([]TaskPredicate{}, q.predicates...),
+		orders:     append([]TaskOrder{}, q.orders...),
+	}
+}
+
+// Tasks runs the query and returns the matching tasks.
+func (q *TaskQuery) Tasks() []SingleTask {
+	var matched []SingleTask
+	for _, task := range q.tasks {
+		if AllOf(q.predicates...)(task) {
+			matched = append(matched, task)
+		}
+	}
+
+	if len(q.orders) > 0 {
+		sort.SliceStable(matched, func(i, j int) bool {
+			for _, order := range q.orders {
+				if c := order(matched[i], matched[j]); c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+
+	return matched
+}
+
+// First returns the first matching task.
+func (q *TaskQuery) First() (SingleTask, bool) {
+	tasks := q.Tasks()
+	if len(tasks) == 0 {
+		return SingleTask{}, false
+	}
+	return tasks[0], true
+}
+
+// Count returns the number of matching tasks.
+func (q *TaskQuery) Count() int {
+	return len(q.Tasks())
+}
+
+// TaskAggregate totals a set of tasks.  Times are in milliseconds.
+type TaskAggregate struct {
+	Count        int     `json:"count"`
+	TimeEstimate int     `json:"time_estimate"`
+	TimeSpent    int     `json:"time_spent"`
+	Points       float64 `json:"points"`
+}
+
+// Aggregate totals the matching tasks.
+func (q *TaskQuery) Aggregate() TaskAggregate {
+	return aggregateTasks(q.Tasks())
+}
+
+func aggregateTasks(tasks []SingleTask) TaskAggregate {
+	aggregate := TaskAggregate{Count: len(tasks)}
+	for _, task := range tasks {
+		aggregate.TimeEstimate += task.TimeEstimate
+		aggregate.TimeSpent += task.TimeSpent
+		aggregate.Points += task.Points
+	}
+	return aggregate
+}
+
+// TaskGroup is the tasks that share a key, such as a status or an assignee.
+type TaskGroup struct {
+	Key       string
+	Tasks     []SingleTask
+	Aggregate TaskAggregate
+}
+
+// TaskGroupKey returns the keys of the groups a task belongs to.  A task with no keys is put in the
+// group with the empty key.
+type TaskGroupKey func(task SingleTask) []string
+
+// GroupBy runs the query and groups the matching tasks by key.  Groups are ordered by their first
+// task, so sorting the query also orders the groups.  A task with several keys, such as several
+// assignees, is part of each of their groups.
+func (q *TaskQuery) GroupBy(key TaskGroupKey) []TaskGroup {
+	var groups []TaskGroup
+	index := make(map[string]int)
+
+	for _, task := range q.Tasks() {
+		keys := key(task)
+		if len(keys) == 0 {
+			keys = []string{""}
+		}
+		for _, k := range keys {
+			i, ok := index[k]
+			if !ok {
+				i = len(groups)
+				index[k] = i
+				groups = append(groups, TaskGroup{Key: k})
+			}
+			groups[i].Tasks = append(groups[i].Tasks, task)
+		}
+	}
+
+	for i := range groups {
+		groups[i].Aggregate = aggregateTasks(groups[i].Tasks)
+	}
+	return groups
+}
+
+// GroupByStatus groups tasks by the name of their status.
+func GroupByStatus() TaskGroupKey {
+	return func(task SingleTask) []string {
+		return []string{task.Status.Status}
+	}
+}
+
+// GroupByAssignee groups tasks by the username of each of their assignees.
+func GroupByAssignee() TaskGroupKey {
+	return func(task SingleTask) []string {
+		return Usernames(task.Assignees)
+	}
+}
+
+// GroupByList groups tasks by the name of their list.
+func GroupByList() TaskGroupKey {
+	return func(task SingleTask) []string {
+		return []string{task.List.Name}
+	}
+}
+
+// GroupByTag groups tasks by each of their tags.
+func GroupByTag() TaskGroupKey {
+	return func(task SingleTask) []string {
+		tags := make([]string, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			tags = append(tags, tag.Name)
+		}
+		return tags
+	}
+}
+
+// GroupByCustomField groups tasks by the value of the custom field with fieldName.  See
+// SingleTask.CustomFieldVal.
+func GroupByCustomField(fieldName string) TaskGroupKey {
+	return func(task SingleTask) []string {
+		if value, ok := customFieldString(task, fieldName); ok {
+			return []string{value}
+		}
+		return nil
+	}
+}
+
+// TaskPredicate reports whether a task matches a condition.  Any func(SingleTask) bool can be used
+// as a predicate.
+type TaskPredicate func(task SingleTask) bool
+
+// AllOf matches tasks that match every predicate.
+func AllOf(predicates ...TaskPredicate) TaskPredicate {
+	return func(task SingleTask) bool {
+		for _, predicate := range predicates {
+			if !predicate(task) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyOf matches tasks that match at least one predicate.
+func AnyOf(predicates ...TaskPredicate) TaskPredicate {
+	return func(task SingleTask) bool {
+		for _, predicate := range predicates {
+			if predicate(task) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches tasks that do not match predicate.
+func Not(predicate TaskPredicate) TaskPredicate {
+	return func(task SingleTask) bool {
+		return !predicate(task)
+	}
+}
+
+// HasStatus matches tasks with any of the statuses, ignoring case.
+func HasStatus(statuses ...string) TaskPredicate {
+	return func(task SingleTask) bool {
+		return containsFold(statuses, task.Status.Status)
+	}
+}
+
+// HasStatusType matches tasks whose status is any of the types, such as "open", "custom" or "closed".
+func HasStatusType(types ...string) TaskPredicate {
+	return func(task SingleTask) bool {
+		return containsFold(types, task.Status.Type)
+	}
+}
+
+// HasTag matches tasks with at least one of the tags, ignoring case.
+func HasTag(tags ...string) TaskPredicate {
+	return func(task SingleTask) bool {
+		for _, tag := range task.Tags {
+			if containsFold(tags, tag.Name) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HasAllTags matches tasks with every one of the tags, ignoring case.
+func HasAllTags(tags ...string) TaskPredicate {
+	return func(task SingleTask) bool {
+		for _, want := range tags {
+			if !HasTag(want)(task) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AssignedTo matches tasks assigned to at least one of the users with userIDs.
+func AssignedTo(userIDs ...int) TaskPredicate {
+	return func(task SingleTask) bool {
+		for _, assignee := range task.Assignees {
+			for _, id := range userIDs {
+				if assignee.ID == id {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// Unassigned matches tasks without assignees.
+func Unassigned() TaskPredicate {
+	return func(task SingleTask) bool {
+		return len(task.Assignees) == 0
+	}
+}
+
+// InList matches tasks in any of the lists with listIDs.
+func InList(listIDs ...string) TaskPredicate {
+	return func(task SingleTask) bool {
+		for _, id := range listIDs {
+			if task.List.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HasPriority matches tasks with any of the priorities, such as "urgent" or "low".
+func HasPriority(priorities ...string) TaskPredicate {
+	return func(task SingleTask) bool {
+		return task.Priority.Priority != "" && containsFold(priorities, task.Priority.Priority)
+	}
+}
+
+// NameContains matches tasks whose name contains substr, ignoring case.
+func NameContains(substr string) TaskPredicate {
+	substr = strings.ToLower(substr)
+	return func(task SingleTask) bool {
+		return strings.Contains(strings.ToLower(task.Name), substr)
+	}
+}
+
+// DueBetween matches tasks due within [from, to).  A zero from or to leaves that end open.
+// Tasks without a due date do not match.
+func DueBetween(from, to time.Time) TaskPredicate {
+	return dateBetween(func(task SingleTask) Timestamp { return task.DueDate }, from, to)
+}
+
+// StartBetween matches tasks starting within [from, to).  See DueBetween.
+func StartBetween(from, to time.Time) TaskPredicate {
+	return dateBetween(func(task SingleTask) Timestamp { return task.StartDate }, from, to)
+}
+
+// CreatedBetween matches tasks created within [from, to).  See DueBetween.
+func CreatedBetween(from, to time.Time) TaskPredicate {
+	return dateBetween(func(task SingleTask) Timestamp { return task.DateCreated }, from, to)
+}
+
+// UpdatedBetween matches tasks last updated within [from, to).  See DueBetween.
+func UpdatedBetween(from, to time.Time) TaskPredicate {
+	return dateBetween(func(task SingleTask) Timestamp { return task.DateUpdated }, from, to)
+}
+
+// ClosedBetween matches tasks closed within [from, to).  See DueBetween.
+func ClosedBetween(from, to time.Time) TaskPredicate {
+	return dateBetween(func(task SingleTask) Timestamp { return task.DateClosed }, from, to)
+}
+
+func dateBetween(date func(task SingleTask) Timestamp, from, to time.Time) TaskPredicate {
+	return func(task SingleTask) bool {
+		t := date(task).Time
+		if t.IsZero() {
+			return false
+		}
+		return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+	}
+}
+
+// CustomFieldEquals matches tasks where the custom field with fieldName has value, compared as text.
+// See SingleTask.CustomFieldVal.
+func CustomFieldEquals(fieldName string, value interface{}) TaskPredicate {
+	want := fmt.Sprint(value)
+	return func(task SingleTask) bool {
+		got, ok := customFieldString(task, fieldName)
+		return ok && got == want
+	}
+}
+
+// CustomFieldSet matches tasks where the custom field with fieldName has a value.
+func CustomFieldSet(fieldName string) TaskPredicate {
+	return func(task SingleTask) bool {
+		_, ok := customFieldString(task, fieldName)
+		return ok
+	}
+}
+
+func customFieldString(task SingleTask, fieldName string) (string, bool) {
+	value := task.CustomFieldVal(fieldName).Value()
+	if value == nil || value == "" {
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskOrder compares two tasks, returning a negative number if a sorts before b, a positive number if
+// it sorts after, and 0 if they are equal.
+type TaskOrder func(a, b SingleTask) int
+
+// Desc reverses order.
+func Desc(order TaskOrder) TaskOrder {
+	return func(a, b SingleTask) int {
+		return -order(a, b)
+	}
+}
+
+// ByName orders tasks by name, ignoring case.
+func ByName() TaskOrder {
+	return func(a, b SingleTask) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}
+}
+
+// ByStatus orders tasks by the name of their status.
+func ByStatus() TaskOrder {
+	return func(a, b SingleTask) int {
+		return strings.Compare(a.Status.Status, b.Status.Status)
+	}
+}
+
+// ByDueDate orders tasks by due date.  Tasks without a due date sort last.
+func ByDueDate() TaskOrder {
+	return byDate(func(task SingleTask) Timestamp { return task.DueDate })
+}
+
+// ByStartDate orders tasks by start date.  Tasks without a start date sort last.
+func ByStartDate() TaskOrder {
+	return byDate(func(task SingleTask) Timestamp { return task.StartDate })
+}
+
+// ByCreated orders tasks by creation date.
+func ByCreated() TaskOrder {
+	return byDate(func(task SingleTask) Timestamp { return task.DateCreated })
+}
+
+// ByUpdated orders tasks by the date they were last updated.
+func ByUpdated() TaskOrder {
+	return byDate(func(task SingleTask) Timestamp { return task.DateUpdated })
+}
+
+// ByPriority orders tasks from urgent to low.  Tasks without a priority sort last.
+func ByPriority() TaskOrder {
+	return byMissingLast(func(task SingleTask) (int64, bool) {
+		n, err := strconv.ParseInt(task.Priority.ID, 10, 64)
+		return n, err == nil
+	})
+}
+
+// ByPoints orders tasks by their sprint points.
+func ByPoints() TaskOrder {
+	return func(a, b SingleTask) int {
+		switch {
+		case a.Points < b.Points:
+			return -1
+		case a.Points > b.Points:
+			return 1
+		}
+		return 0
+	}
+}
+
+// ByTimeEstimate orders tasks by their time estimate.
+func ByTimeEstimate() TaskOrder {
+	return func(a, b SingleTask) int {
+		return a.TimeEstimate - b.TimeEstimate
+	}
+}
+
+// ByCustomField orders tasks by the value of the custom field with fieldName.  Numbers compare as
+// numbers and everything else as text.  Tasks without a value sort last.
+func ByCustomField(fieldName string) TaskOrder {
+	return func(a, b SingleTask) int {
+		av, aok := customFieldString(a, fieldName)
+		bv, bok := customFieldString(b, fieldName)
+		switch {
+		case !aok || !bok:
+			return missingLast(aok, bok)
+		}
+		af, aerr := strconv.ParseFloat(av, 64)
+		bf, berr := strconv.ParseFloat(bv, 64)
+		if aerr == nil && berr == nil {
+			switch {
+			case af < bf:
+				return -1
+			case af > bf:
+				return 1
+			}
+			return 0
+		}
+		return strings.Compare(av, bv)
+	}
+}
+
+func byDate(date func(task SingleTask) Timestamp) TaskOrder {
+	return byMissingLast(func(task SingleTask) (int64, bool) {
+		t := date(task)
+		return t.Millis(), !t.IsZero()
+	})
+}
+
+func byMissingLast(value func(task SingleTask) (int64, bool)) TaskOrder {
+	return func(a, b SingleTask) int {
+		av, aok := value(a)
+		bv, bok := value(b)
+		switch {
+		case !aok || !bok:
+			return missingLast(aok, bok)
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+		return 0
+	}
+}
+
+func missingLast(aok, bok bool) int {
+	switch {
+	case aok == bok:
+		return 0
+	case aok:
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const queryTasksJSON = `[
+	{
+		"id": "a", "name": "Write docs", "status": {"status": "in progress", "type": "custom"},
+		"assignees": [{"id": 1, "username": "jane"}], "tags": [{"name": "docs"}],
+		"list": {"id": "L1", "name": "Backlog"}, "priority": {"id": "3", "priority": "normal"},
+		"due_date": "1700000000000", "date_created": "1600000000000",
+		"points": 3, "time_estimate": 3600000, "time_spent": 1800000,
+		"custom_fields": [{"name": "Team", "type": "drop_down", "value": "opt-api",
+			"type_config": {"options": [{"id": "opt-web", "name": "Web", "orderindex": 0}, {"id": "opt-api", "name": "API", "orderindex": 1},
+				{"id": "opt-ios", "name": "iOS", "orderindex": 2}]}},
+			{"name": "Size", "type": "number", "value": "5"}]
+	},
+	{
+		"id": "b", "name": "Fix login", "status": {"status": "open", "type": "open"},
+		"assignees": [{"id": 1, "username": "jane"}, {"id": 2, "username": "sam"}], "tags": [{"name": "bug"}, {"name": "docs"}],
+		"list": {"id": "L2", "name": "Sprint"}, "priority": {"id": "1", "priority": "urgent"},
+		"due_date": "1650000000000", "date_created": "1610000000000",
+		"points": 5, "time_estimate": 7200000,
+		"custom_fields": [{"name": "Team", "type": "drop_down", "value": 2,
+			"type_config": {"options": [{"id": "opt-web", "name": "Web", "orderindex": 0}, {"id": "opt-api", "name": "API", "orderindex": 1},
+				{"id": "opt-ios", "name": "iOS", "orderindex": 2}]}},
+			{"name": "Size", "type": "number", "value": "13"}]
+	},
+	{
+		"id": "c", "name": "Release", "status": {"status": "done", "type": "closed"},
+		"list": {"id": "L2", "name": "Sprint"}, "date_created": "1620000000000",
+		"points": 1
+	}
+]`
+
+func queryTestTasks(t *testing.T) []SingleTask {
+	t.Helper()
+	var tasks []SingleTask
+	if err := json.Unmarshal([]byte(queryTasksJSON), &tasks); err != nil {
+		t.Fatal(err)
+	}
+	return tasks
+}
+
+func TestTaskQuery_Where(t *testing.T) {
+	tasks := queryTestTasks(t)
+	tests := []struct {
+		name       string
+		predicates []TaskPredicate
+		want       string
+	}{
+		{name: "No predicates", want: "a,b,c"},
+		{name: "Status", predicates: []TaskPredicate{HasStatus("OPEN", "done")}, want: "b,c"},
+		{name: "Status type", predicates: []TaskPredicate{Not(HasStatusType("closed"))}, want: "a,b"},
+		{name: "Any tag", predicates: []TaskPredicate{HasTag("bug", "missing")}, want: "b"},
+		{name: "All tags", predicates: []TaskPredicate{HasAllTags("docs", "bug")}, want: "b"},
+		{name: "Assignee", predicates: []TaskPredicate{AssignedTo(2, 3)}, want: "b"},
+		{name: "Unassigned", predicates: []TaskPredicate{Unassigned()}, want: "c"},
+		{name: "List", predicates: []TaskPredicate{InList("L2")}, want: "b,c"},
+		{name: "Priority", predicates: []TaskPredicate{HasPriority("urgent", "high")}, want: "b"},
+		{name: "Name", predicates: []TaskPredicate{NameContains("DOCS")}, want: "a"},
+		{
+			name:       "Due before",
+			predicates: []TaskPredicate{DueBetween(time.Time{}, time.UnixMilli(1700000000000))},
+			want:       "b",
+		},
+		{
+			name:       "Created range",
+			predicates: []TaskPredicate{CreatedBetween(time.UnixMilli(1605000000000), time.UnixMilli(1615000000000))},
+			want:       "b",
+		},
+		{name: "Drop down custom field", predicates: []TaskPredicate{CustomFieldEquals("Team", "API")}, want: "a"},
+		{name: "Drop down custom field by orderindex", predicates: []TaskPredicate{CustomFieldEquals("Team", "iOS")}, want: "b"},
+		{name: "Number custom field", predicates: []TaskPredicate{CustomFieldEquals("Size", 13)}, want: "b"},
+		{name: "Custom field set", predicates: []TaskPredicate{Not(CustomFieldSet("Team"))}, want: "c"},
+		{
+			name:       "Combined",
+			predicates: []TaskPredicate{AnyOf(HasTag("bug"), Unassigned()), AllOf(InList("L2"), HasStatusType("open"))},
+			want:       "b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := taskIDs(QueryTasks(tasks).Where(tt.predicates...).Tasks())
+			if got != tt.want {
+				t.Errorf("Tasks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskQuery_SortBy(t *testing.T) {
+	tasks := queryTestTasks(t)
+	tests := []struct {
+		name   string
+		orders []TaskOrder
+		want   string
+	}{
+		{name: "Name", orders: []TaskOrder{ByName()}, want: "b,c,a"},
+		{name: "Due date, missing last", orders: []TaskOrder{ByDueDate()}, want: "b,a,c"},
+		{name: "Descending due date", orders: []TaskOrder{Desc(ByDueDate())}, want: "c,a,b"},
+		{name: "Priority", orders: []TaskOrder{ByPriority()}, want: "b,a,c"},
+		{name: "Points", orders: []TaskOrder{Desc(ByPoints())}, want: "b,a,c"},
+		{name: "Numeric custom field", orders: []TaskOrder{ByCustomField("Size")}, want: "a,b,c"},
+		{name: "Tie break", orders: []TaskOrder{ByStatus(), Desc(ByCreated())}, want: "c,a,b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := taskIDs(QueryTasks(tasks).SortBy(tt.orders...).Tasks())
+			if got != tt.want {
+				t.Errorf("Tasks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskQuery_GroupBy(t *testing.T) {
+	tasks := queryTestTasks(t)
+	query := QueryTasks(tasks).SortBy(ByName())
+
+	groups := query.GroupBy(GroupByAssignee())
+	want := []TaskGroup{
+		{Key: "jane", Tasks: []SingleTask{tasks[1], tasks[0]}, Aggregate: TaskAggregate{Count: 2, TimeEstimate: 10800000, TimeSpent: 1800000, Points: 8}},
+		{Key: "sam", Tasks: []SingleTask{tasks[1]}, Aggregate: TaskAggregate{Count: 1, TimeEstimate: 7200000, Points: 5}},
+		{Key: "", Tasks: []SingleTask{tasks[2]}, Aggregate: TaskAggregate{Count: 1, Points: 1}},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("GroupBy(GroupByAssignee()) = %+v, want %+v", groups, want)
+	}
+
+	keys := func(groups []TaskGroup) []string {
+		var keys []string
+		for _, group := range groups {
+			keys = append(keys, group.Key)
+		}
+		return keys
+	}
+	if got, want := keys(query.GroupBy(GroupByList())), []string{"Sprint", "Backlog"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy(GroupByList()) keys = %v, want %v", got, want)
+	}
+	if got, want := keys(query.GroupBy(GroupByTag())), []string{"bug", "docs", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy(GroupByTag()) keys = %v, want %v", got, want)
+	}
+	if got, want := keys(query.GroupBy(GroupByCustomField("Team"))), []string{"iOS", "", "API"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy(GroupByCustomField()) keys = %v, want %v", got, want)
+	}
+}
+
+func TestQueryTasks_combinesResults(t *testing.T) {
+	tasks := queryTestTasks(t)
+	query := QueryTasks(tasks[:2], tasks[1:])
+
+	if got := query.Count(); got != 3 {
+		t.Errorf("Count() = %d, want 3", got)
+	}
+	want := TaskAggregate{Count: 3, TimeEstimate: 10800000, TimeSpent: 1800000, Points: 9}
+	if got := query.Aggregate(); got != want {
+		t.Errorf("Aggregate() = %+v, want %+v", got, want)
+	}
+
+	open := query.Where(HasStatusType("open"))
+	if got := query.Count(); got != 3 {
+		t.Errorf("Where() changed the original query: Count() = %d, want 3", got)
+	}
+	if task, ok := open.First(); !ok || task.ID != "b" {
+		t.Errorf("First() = %q, %v, want b, true", task.ID, ok)
+	}
+	if _, ok := query.Where(HasTag("missing")).First(); ok {
+		t.Errorf("First() on no matches returned ok")
+	}
+}
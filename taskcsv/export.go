@@ -0,0 +1,116 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package taskcsv exports Clickup tasks to CSV and creates tasks from the rows of a CSV file.
+//
+// An export can be edited in a spreadsheet and imported into another list:
+//
+//	f, _ := os.Create("tasks.csv")
+//	taskcsv.ExportList(ctx, client, f, listID, nil, nil)
+//
+//	importer := &taskcsv.Importer{Client: client, ListID: otherListID, Workspace: workspaceID}
+//	report, err := importer.Import(ctx, file)
+package taskcsv
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+	"github.com/Guitarbum722/clickup-client-go/format"
+)
+
+// DefaultColumns are the task columns written when ExportOptions.Columns is empty.  The name,
+// description, status, priority, assignees, tags, due_date and start_date columns are read back by
+// an Importer.
+var DefaultColumns = []string{
+	"id",
+	"custom_id",
+	"name",
+	"description",
+	"status",
+	"priority",
+	"assignees",
+	"tags",
+	"parent",
+	"list",
+	"due_date",
+	"start_date",
+	"date_created",
+	"date_updated",
+	"date_closed",
+	"time_estimate",
+	"url",
+}
+
+// ExportOptions controls the columns and dates of an export.  A nil *ExportOptions uses the defaults.
+type ExportOptions struct {
+	// Columns are the columns to write, in order.  They are the columns of format.Tasks, such as
+	// "name" or "cf:Sprint".  When empty, DefaultColumns are written followed by a column for
+	// every custom field set on any of the tasks.
+	Columns []string
+	// TimeFormat is the layout of dates, including custom field dates.  It defaults to
+	// time.RFC3339.  Use format.UnixMillis for unix milliseconds.
+	TimeFormat string
+	// Location is the time zone dates are written in.  It defaults to UTC.
+	Location *time.Location
+}
+
+// Write writes tasks to w as CSV with a header row.
+func Write(w io.Writer, tasks []clickup.SingleTask, opts *ExportOptions) error {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+	table := format.Tasks(tasks, &format.Options{TimeFormat: opts.TimeFormat, Location: opts.Location})
+
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = append(columns, DefaultColumns...)
+		for _, column := range table.Columns {
+			if strings.HasPrefix(column, format.CustomFieldPrefix) {
+				columns = append(columns, column)
+			}
+		}
+	}
+	if len(table.Columns) == 0 {
+		// Without any tasks there is nothing to select from, but the header is still useful.
+		table.Columns = columns
+	}
+	table, err := table.Select(columns...)
+	if err != nil {
+		return err
+	}
+	return table.Write(w, format.CSV)
+}
+
+// ExportList writes every task of listID that matches queryOpts to w.  queryOpts may be nil.
+func ExportList(ctx context.Context, client *clickup.Client, w io.Writer, listID string, queryOpts *clickup.TaskQueryOptions, opts *ExportOptions) error {
+	tasks, err := client.AllTasksForList(ctx, listID, queryOpts)
+	if err != nil {
+		return err
+	}
+	return Write(w, tasks, opts)
+}
+
+// ExportView writes every task shown in viewID to w.
+func ExportView(ctx context.Context, client *clickup.Client, w io.Writer, viewID string, opts *ExportOptions) error {
+	tasks, err := client.AllTasksForView(ctx, viewID)
+	if err != nil {
+		return err
+	}
+	return Write(w, tasks, opts)
+}
+
+// ExportWorkspace writes every task of workspaceID that matches queryOpts to w.  queryOpts may be nil.
+func ExportWorkspace(ctx context.Context, client *clickup.Client, w io.Writer, workspaceID string, queryOpts *clickup.WorkspaceTaskQueryOptions, opts *ExportOptions) error {
+	tasks, err := client.AllTasksForWorkspace(ctx, workspaceID, queryOpts)
+	if err != nil {
+		return err
+	}
+	return Write(w, tasks, opts)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package taskcsv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Guitarbum722/clickup-client-go"
+	"github.com/Guitarbum722/clickup-client-go/format"
+)
+
+func TestWrite(t *testing.T) {
+	var tasks []clickup.SingleTask
+	err := json.Unmarshal([]byte(`[
+		{"id":"a","name":"One","status":{"status":"open"},"assignees":[{"username":"ana"}],"due_date":"1656633600000",
+		 "custom_fields":[{"name":"Estimate","type":"number","value":3},
+		  {"name":"Size","type":"drop_down","value":2,"type_config":{"options":[
+		   {"id":"o1","name":"S","orderindex":0},{"id":"o2","name":"M","orderindex":1},{"id":"o3","name":"L","orderindex":2}]}}]},
+		{"id":"b","name":"Two, with comma","tags":[{"name":"x"},{"name":"y"}]}
+	]`), &tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		tasks []clickup.SingleTask
+		opts  *ExportOptions
+		want  string
+	}{
+		{
+			name:  "Selected columns",
+			tasks: tasks,
+			opts:  &ExportOptions{Columns: []string{"id", "name", "tags", "due_date", "cf:Estimate", "cf:Size"}, TimeFormat: "2006-01-02"},
+			want:  "id,name,tags,due_date,cf:Estimate,cf:Size\na,One,,2022-07-01,3,L\nb,\"Two, with comma\",\"x,y\",,,\n",
+		},
+		{
+			name:  "Unix milliseconds",
+			tasks: tasks[:1],
+			opts:  &ExportOptions{Columns: []string{"id", "due_date"}, TimeFormat: format.UnixMillis},
+			want:  "id,due_date\na,1656633600000\n",
+		},
+		{
+			name:  "Default columns end with custom fields",
+			tasks: tasks[:1],
+			want: "id,custom_id,name,description,status,priority,assignees,tags,parent,list,due_date,start_date," +
+				"date_created,date_updated,date_closed,time_estimate,url,cf:Estimate,cf:Size\n" +
+				"a,,One,,open,,ana,,,,2022-07-01T00:00:00Z,,,,,0,,3,L\n",
+		},
+		{
+			name: "No tasks",
+			opts: &ExportOptions{Columns: []string{"id", "name"}},
+			want: "id,name\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.tasks, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Write() =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestExportView(t *testing.T) {
+	var buf bytes.Buffer
+	err := ExportView(context.Background(), newTestClient(&fakeClickup{}), &buf, "v1", &ExportOptions{
+		Columns:    []string{"id", "name", "due_date", "cf:Area"},
+		TimeFormat: format.UnixMillis,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "id,name,due_date,cf:Area\na,From view,1656633600000,Frontend\n"; buf.String() != want {
+		t.Errorf("ExportView() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
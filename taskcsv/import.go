@@ -0,0 +1,513 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package taskcsv
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+	"github.com/Guitarbum722/clickup-client-go/format"
+)
+
+// Importer creates a task in a list for every row of a CSV file.  The first row is the header.
+//
+// The columns name, description, status, priority, tags, assignees, due_date and start_date set the
+// fields of the new task, and a column named "cf:" followed by a custom field name sets that custom
+// field.  Header names are matched ignoring case and other columns are ignored, so the output of Write
+// can be imported as is.  Only the name column is required.
+//
+// Rows that fail are reported and the import carries on.  Set Progress to record the rows that were
+// created, and pass them back in Completed to resume an import without creating those tasks twice.
+type Importer struct {
+	Client *clickup.Client
+	// ListID is the list the tasks are created in.
+	ListID string
+	// Columns maps headers of the file to task columns when they differ, such as
+	// {"Title": "name", "Sprint #": "cf:Sprint"}.
+	Columns map[string]string
+	// TimeFormat is the layout of dates.  When empty, dates may be RFC 3339, YYYY-MM-DD,
+	// YYYY-MM-DD HH:MM or unix milliseconds.  Use format.UnixMillis for unix milliseconds only.
+	TimeFormat string
+	// Location is the time zone of dates without one.  It defaults to UTC.
+	Location *time.Location
+	// Users resolve assignees given by username or email.  When nil, the members of Workspace are
+	// used.  Assignees may always be given by user id.
+	Users     []clickup.TeamUser
+	Workspace string
+	// Fields are the custom fields of the list.  When nil, they are requested if the file has
+	// custom field columns.
+	Fields []clickup.ListCustomField
+	// DryRun checks every row without creating any tasks.
+	DryRun bool
+	// Completed maps the rows of a previous import to the tasks created for them.  Those rows are
+	// skipped.  Rows are numbered by the line they start on, so the file must not be reordered
+	// between runs.
+	Completed map[int]string
+	// Progress receives a "row,task-id" CSV line for every task created.  ReadProgress reads them
+	// back into Completed.
+	Progress io.Writer
+}
+
+// Report is the outcome of an import.  For a dry run, Created counts the rows that would be created.
+type Report struct {
+	Rows    []RowResult
+	Created int
+	Skipped int
+	Failed  int
+}
+
+// Failures returns the rows that could not be imported.
+func (r *Report) Failures() []RowResult {
+	var failures []RowResult
+	for _, row := range r.Rows {
+		if row.Err != nil {
+			failures = append(failures, row)
+		}
+	}
+	return failures
+}
+
+// RowResult is the outcome of one row.  TaskID is empty for a dry run or a failed row.
+type RowResult struct {
+	Row     int
+	Name    string
+	Request clickup.TaskRequest
+	TaskID  string
+	Skipped bool
+	Err     error
+}
+
+// Import reads the CSV file from r and creates its tasks.  Problems with a single row are recorded in
+// the report.  An error is returned if the file or its header cannot be read, if the list's custom
+// fields or users cannot be loaded, or if ctx is done, along with the report of the rows so far.
+func (im *Importer) Import(ctx context.Context, r io.Reader) (*Report, error) {
+	report := &Report{}
+	if im.ListID == "" {
+		return report, fmt.Errorf("must provide a list id to import tasks into: %w", clickup.ErrValidation)
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return report, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns, err := im.columns(ctx, header)
+	if err != nil {
+		return report, err
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return report, nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return report, err
+			}
+			report.add(RowResult{Row: parseErr.StartLine, Err: err})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		result := RowResult{Row: line}
+		if len(record) != len(header) {
+			result.Err = fmt.Errorf("has %d fields, want %d: %w", len(record), len(header), clickup.ErrValidation)
+			report.add(result)
+			continue
+		}
+
+		if taskID, ok := im.Completed[line]; ok {
+			result.TaskID = taskID
+			result.Skipped = true
+			report.add(result)
+			continue
+		}
+
+		result.Request, result.Err = columns.request(record)
+		result.Name = result.Request.Name
+		if result.Err != nil || im.DryRun {
+			report.add(result)
+			continue
+		}
+
+		if err := im.create(ctx, &result); err != nil {
+			report.add(result)
+			return report, err
+		}
+		report.add(result)
+	}
+}
+
+func (report *Report) add(result RowResult) {
+	switch {
+	case result.Err != nil:
+		report.Failed++
+	case result.Skipped:
+		report.Skipped++
+	default:
+		report.Created++
+	}
+	report.Rows = append(report.Rows, result)
+}
+
+// create makes the task for result.  A failed request is recorded on the row, and only a done
+// context or a failure to record progress stops the import.
+func (im *Importer) create(ctx context.Context, result *RowResult) error {
+	var task *clickup.SingleTask
+	err := clickup.RetryOnRateLimit(ctx, func() error {
+		var err error
+		task, err = im.Client.CreateTask(ctx, im.ListID, result.Request)
+		return err
+	})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		result.Err = ctxErr
+		return ctxErr
+	}
+	if err != nil {
+		result.Err = err
+		return nil
+	}
+
+	result.TaskID = task.ID
+	if im.Progress != nil {
+		w := csv.NewWriter(im.Progress)
+		w.Write([]string{strconv.Itoa(result.Row), task.ID})
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return fmt.Errorf("failed to record progress: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadProgress reads the lines written to Importer.Progress into a map for Importer.Completed.
+func ReadProgress(r io.Reader) (map[int]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	completed := make(map[int]string)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return completed, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read progress: %w", err)
+		}
+		row, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read progress: invalid row %q", record[0])
+		}
+		completed[row] = record[1]
+	}
+}
+
+// column is a column of the file that sets a field of the task, or a custom field if custom is set.
+type column struct {
+	index  int
+	field  string
+	custom *clickup.ListCustomField
+}
+
+// columnSet knows how to turn a record into a task request.
+type columnSet struct {
+	im      *Importer
+	columns []column
+	users   []clickup.TeamUser
+}
+
+func (im *Importer) columns(ctx context.Context, header []string) (*columnSet, error) {
+	set := &columnSet{im: im}
+
+	hasName, hasCustom, needUsers := false, false, false
+	for i, name := range header {
+		if i == 0 {
+			// Spreadsheets often save UTF-8 files with a byte order mark.
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if mapped, ok := im.Columns[name]; ok {
+			name = mapped
+		}
+		name = strings.TrimSpace(name)
+		if len(name) > len(format.CustomFieldPrefix) && strings.EqualFold(name[:len(format.CustomFieldPrefix)], format.CustomFieldPrefix) {
+			hasCustom = true
+			set.columns = append(set.columns, column{index: i, field: name[len(format.CustomFieldPrefix):], custom: &clickup.ListCustomField{}})
+			continue
+		}
+		switch field := strings.ToLower(name); field {
+		case "name", "description", "status", "priority", "tags", "assignees", "due_date", "start_date":
+			hasName = hasName || field == "name"
+			needUsers = needUsers || field == "assignees"
+			set.columns = append(set.columns, column{index: i, field: field})
+		}
+	}
+	if !hasName {
+		return nil, fmt.Errorf("the file must have a name column: %w", clickup.ErrValidation)
+	}
+
+	if hasCustom {
+		fields := im.Fields
+		if fields == nil {
+			res, err := im.Client.CustomFieldsForList(ctx, im.ListID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load custom fields: %w", err)
+			}
+			fields = res.Fields
+		}
+		for i, c := range set.columns {
+			if c.custom == nil {
+				continue
+			}
+			field, ok := findField(fields, c.field)
+			if !ok {
+				return nil, fmt.Errorf("list %s has no custom field %q: %w", im.ListID, c.field, clickup.ErrValidation)
+			}
+			needUsers = needUsers || field.Type == "users"
+			set.columns[i].custom = &field
+		}
+	}
+
+	set.users = im.Users
+	if set.users == nil && needUsers && im.Workspace != "" {
+		users, err := workspaceUsers(ctx, im.Client, im.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		set.users = users
+	}
+	return set, nil
+}
+
+func findField(fields []clickup.ListCustomField, name string) (clickup.ListCustomField, bool) {
+	for _, field := range fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return clickup.ListCustomField{}, false
+}
+
+func workspaceUsers(ctx context.Context, client *clickup.Client, workspaceID string) ([]clickup.TeamUser, error) {
+	teams, err := client.Teams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workspace members: %w", err)
+	}
+	for _, team := range teams.Teams {
+		if team.ID != workspaceID {
+			continue
+		}
+		users := make([]clickup.TeamUser, 0, len(team.Members))
+		for _, member := range team.Members {
+			users = append(users, member.User)
+		}
+		return users, nil
+	}
+	return nil, fmt.Errorf("workspace %s was not found: %w", workspaceID, clickup.ErrValidation)
+}
+
+func (set *columnSet) request(record []string) (clickup.TaskRequest, error) {
+	var task clickup.TaskRequest
+	for _, c := range set.columns {
+		value := strings.TrimSpace(record[c.index])
+		if value == "" {
+			continue
+		}
+		if c.custom != nil {
+			v, err := set.customValue(*c.custom, value)
+			if err != nil {
+				return task, fmt.Errorf("%s%s: %w", format.CustomFieldPrefix, c.custom.Name, err)
+			}
+			task.CustomFields = append(task.CustomFields, clickup.TaskCustomField{ID: c.custom.ID, Value: v})
+			continue
+		}
+
+		var err error
+		switch c.field {
+		case "name":
+			task.Name = value
+		case "description":
+			task.Description = record[c.index]
+		case "status":
+			task.Status = value
+		case "priority":
+			var p int
+			p, err = parsePriority(value)
+			task.Priority = &p
+		case "tags":
+			task.Tags = splitList(value)
+		case "assignees":
+			task.Assignees, err = set.userIDs(value)
+		case "due_date":
+			task.DueDate, task.DueDateTime, err = set.im.timestamp(value)
+		case "start_date":
+			task.StartDate, task.StartDateTime, err = set.im.timestamp(value)
+		}
+		if err != nil {
+			return task, fmt.Errorf("%s: %w", c.field, err)
+		}
+	}
+	if task.Name == "" {
+		return task, fmt.Errorf("name is empty: %w", clickup.ErrValidation)
+	}
+	return task, nil
+}
+
+// customValue converts value to the form Clickup expects for the type of field.
+func (set *columnSet) customValue(field clickup.ListCustomField, value string) (interface{}, error) {
+	switch field.Type {
+	case "text", "short_text", "email", "url", "phone":
+		return value, nil
+	case "number", "currency", "emoji", "manual_progress":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number: %w", value, clickup.ErrValidation)
+		}
+		return n, nil
+	case "checkbox":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false: %w", value, clickup.ErrValidation)
+		}
+		return b, nil
+	case "date":
+		ts, _, err := set.im.timestamp(value)
+		if err != nil {
+			return nil, err
+		}
+		return ts.Millis(), nil
+	case "drop_down":
+		return optionID(field, value)
+	case "labels":
+		var ids []string
+		for _, label := range splitList(value) {
+			id, err := optionID(field, label)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	case "users":
+		ids, err := set.userIDs(value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]int{"add": ids}, nil
+	}
+	return nil, fmt.Errorf("custom fields of type %s cannot be imported: %w", field.Type, clickup.ErrValidation)
+}
+
+func optionID(field clickup.ListCustomField, value string) (string, error) {
+	for _, option := range field.TypeConfig.Options {
+		if option.ID == value || strings.EqualFold(option.Name, value) || strings.EqualFold(option.Label, value) {
+			return option.ID, nil
+		}
+	}
+	return "", fmt.Errorf("%q is not an option: %w", value, clickup.ErrValidation)
+}
+
+func (set *columnSet) userIDs(value string) ([]int, error) {
+	var ids []int
+	for _, name := range splitList(value) {
+		if id, err := strconv.Atoi(name); err == nil {
+			ids = append(ids, id)
+			continue
+		}
+		id, ok := findUser(set.users, name)
+		if !ok {
+			return nil, fmt.Errorf("unknown user %q: %w", name, clickup.ErrValidation)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func findUser(users []clickup.TeamUser, name string) (int, bool) {
+	for _, user := range users {
+		if strings.EqualFold(user.Username, name) || strings.EqualFold(user.Email, name) {
+			return user.ID, true
+		}
+	}
+	return 0, false
+}
+
+var priorities = map[string]int{"urgent": 1, "high": 2, "normal": 3, "low": 4}
+
+func parsePriority(s string) (int, error) {
+	if p, ok := priorities[strings.ToLower(s)]; ok {
+		return p, nil
+	}
+	if p, err := strconv.Atoi(s); err == nil && p >= 1 && p <= 4 {
+		return p, nil
+	}
+	return 0, fmt.Errorf("invalid priority %q: use urgent, high, normal or low: %w", s, clickup.ErrValidation)
+}
+
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+
+// timestamp parses a date.  hasTime is false for dates at midnight, which Clickup shows without a time.
+func (im *Importer) timestamp(s string) (ts *clickup.Timestamp, hasTime bool, err error) {
+	loc := im.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var t time.Time
+	switch {
+	case im.TimeFormat == format.UnixMillis:
+		ms, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("%q is not unix milliseconds: %w", s, clickup.ErrValidation)
+		}
+		t = clickup.TimestampFromMillis(ms).Time
+	case im.TimeFormat != "":
+		t, err = time.ParseInLocation(im.TimeFormat, s, loc)
+		if err != nil {
+			return nil, false, fmt.Errorf("%q does not match %q: %w", s, im.TimeFormat, clickup.ErrValidation)
+		}
+	default:
+		if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+			t = clickup.TimestampFromMillis(ms).Time
+			break
+		}
+		for _, layout := range dateLayouts {
+			if t, err = time.ParseInLocation(layout, s, loc); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid date %q: use YYYY-MM-DD, RFC 3339 or unix milliseconds: %w", s, clickup.ErrValidation)
+		}
+	}
+
+	t = t.In(loc)
+	hasTime = t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0
+	return clickup.NewTimestamp(t).Ptr(), hasTime, nil
+}
+
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package taskcsv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// fakeClickup answers the requests of an export or import.  Creating a task named "fail" returns an error.
+type fakeClickup struct {
+	created []string
+}
+
+func (f *fakeClickup) Do(req *http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, ""
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/field"):
+		body = `{"fields":[
+			{"id":"f-sprint","name":"Sprint","type":"drop_down","type_config":{"options":[{"id":"o1","name":"Sprint 1"},{"id":"o2","name":"Sprint 2"}]}},
+			{"id":"f-points","name":"Estimate","type":"number"},
+			{"id":"f-area","name":"Area","type":"labels","type_config":{"options":[{"id":"a1","label":"Backend"},{"id":"a2","label":"Frontend"}]}},
+			{"id":"f-kickoff","name":"Kickoff","type":"date"},
+			{"id":"f-loc","name":"Location","type":"location"}
+		]}`
+	case strings.HasSuffix(req.URL.Path, "/team"):
+		body = `{"teams":[{"id":"w1","members":[{"user":{"id":7,"username":"ana","email":"ana@example.com"}},{"user":{"id":8,"username":"bo"}}]}]}`
+	case strings.HasSuffix(req.URL.Path, "/view/v1/task/"):
+		body = `{"tasks":[{"id":"a","name":"From view","due_date":"1656633600000","custom_fields":[` +
+			`{"name":"Area","type":"labels","value":["a2"],"type_config":{"options":[{"id":"a1","label":"Backend"},{"id":"a2","label":"Frontend"}]}}` +
+			`]}],"last_page":true}`
+	case req.Method == http.MethodPost:
+		b, _ := ioutil.ReadAll(req.Body)
+		if strings.Contains(string(b), `"name":"fail"`) {
+			status, body = http.StatusBadRequest, `{"err":"Status not found","ECODE":"ITEM_114"}`
+			break
+		}
+		f.created = append(f.created, string(b))
+		body = fmt.Sprintf(`{"id":"t%d"}`, len(f.created))
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func newTestClient(doer clickup.ClientDoer) *clickup.Client {
+	return clickup.NewClient(&clickup.ClientOpts{
+		Doer:          doer,
+		Authenticator: &clickup.APITokenAuthenticator{APIToken: "pk_test"},
+	})
+}
+
+func TestImporter_Import(t *testing.T) {
+	const file = "\ufeffTitle,Description,Status,Priority,Tags,Assignees,Due,cf:Sprint,cf:Estimate,cf:Area,Notes\n" +
+		"Write docs,\"Line one\nline two\",open,high,\"docs, q3\",\"ana, 8\",2022-07-01,Sprint 2,3.5,\"Backend,Frontend\",ignored\n" +
+		"Fix bug,,,,,,2022-07-01T09:30:00Z,,,,\n"
+
+	tests := []struct {
+		name        string
+		file        string
+		importer    Importer
+		wantErr     bool
+		wantCreated []string
+		wantReport  Report
+		wantFailed  map[int]string
+	}{
+		{
+			name: "Every field",
+			file: file,
+			importer: Importer{
+				Columns:   map[string]string{"Title": "name", "Due": "due_date"},
+				Workspace: "w1",
+			},
+			wantCreated: []string{
+				`{"name":"Write docs","description":"Line one\nline two","assignees":[7,8],"tags":["docs","q3"],"status":"open","priority":2,` +
+					`"due_date":1656633600000,"custom_fields":[{"id":"f-sprint","value":"o2"},{"id":"f-points","value":3.5},{"id":"f-area","value":["a1","a2"]}]}`,
+				`{"name":"Fix bug","due_date":1656667800000,"due_date_time":true}`,
+			},
+			wantReport: Report{Created: 2},
+		},
+		{
+			name: "Row errors do not stop the import",
+			file: "name,priority,assignees,cf:Sprint,cf:Kickoff\n" +
+				"ok,,,,\n" +
+				"bad priority,soon,,,\n" +
+				"bad user,,zed,,\n" +
+				"bad option,,,Sprint 9,\n" +
+				"bad date,,,,next week\n" +
+				",low,,,\n" +
+				"short row\n" +
+				"fail,,,,\n" +
+				"also ok,,,,2022-07-02\n",
+			importer: Importer{Users: []clickup.TeamUser{{ID: 7, Username: "ana"}}},
+			wantCreated: []string{
+				`{"name":"ok"}`,
+				`{"name":"also ok","custom_fields":[{"id":"f-kickoff","value":1656720000000}]}`,
+			},
+			wantReport: Report{Created: 2, Failed: 7},
+			wantFailed: map[int]string{
+				3: `priority: invalid priority "soon"`,
+				4: `assignees: unknown user "zed"`,
+				5: `cf:Sprint: "Sprint 9" is not an option`,
+				6: `cf:Kickoff: invalid date "next week"`,
+				7: "name is empty",
+				8: "has 1 fields, want 5",
+				9: "Status not found",
+			},
+		},
+		{
+			name:       "Dry run",
+			file:       file,
+			importer:   Importer{DryRun: true, Columns: map[string]string{"Title": "name"}, Users: []clickup.TeamUser{{ID: 7, Username: "ana"}}},
+			wantReport: Report{Created: 2},
+		},
+		{
+			name: "Resume skips completed rows",
+			file: "name\none\ntwo\nthree\n",
+			importer: Importer{
+				Completed: map[int]string{2: "t-old", 3: "t-old2"},
+			},
+			wantCreated: []string{`{"name":"three"}`},
+			wantReport:  Report{Created: 1, Skipped: 2},
+		},
+		{
+			name:     "Missing name column",
+			file:     "title\nx\n",
+			importer: Importer{},
+			wantErr:  true,
+		},
+		{
+			name:     "Unknown custom field",
+			file:     "name,cf:Nope\nx,1\n",
+			importer: Importer{},
+			wantErr:  true,
+		},
+		{
+			name:       "Unsupported custom field type",
+			file:       "name,cf:Location\nx,home\n",
+			importer:   Importer{},
+			wantReport: Report{Failed: 1},
+			wantFailed: map[int]string{2: "type location cannot be imported"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doer := &fakeClickup{}
+			im := tt.importer
+			im.Client = newTestClient(doer)
+			im.ListID = "l1"
+
+			report, err := im.Import(context.Background(), strings.NewReader(tt.file))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Import() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, clickup.ErrValidation) {
+					t.Errorf("Import() error = %v, want ErrValidation", err)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(doer.created, tt.wantCreated) {
+				t.Errorf("created =\n%s\nwant\n%s", strings.Join(doer.created, "\n"), strings.Join(tt.wantCreated, "\n"))
+			}
+			if report.Created != tt.wantReport.Created || report.Skipped != tt.wantReport.Skipped || report.Failed != tt.wantReport.Failed {
+				t.Errorf("report created/skipped/failed = %d/%d/%d, want %d/%d/%d", report.Created, report.Skipped, report.Failed,
+					tt.wantReport.Created, tt.wantReport.Skipped, tt.wantReport.Failed)
+			}
+			failures := report.Failures()
+			if len(failures) != len(tt.wantFailed) {
+				t.Errorf("got %d failures, want %d: %+v", len(failures), len(tt.wantFailed), failures)
+			}
+			for _, failure := range failures {
+				want, ok := tt.wantFailed[failure.Row]
+				if !ok || !strings.Contains(failure.Err.Error(), want) {
+					t.Errorf("row %d error = %v, want it to contain %q", failure.Row, failure.Err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestImporter_Progress(t *testing.T) {
+	doer := &fakeClickup{}
+	var progress bytes.Buffer
+	im := &Importer{Client: newTestClient(doer), ListID: "l1", Progress: &progress}
+
+	file := "name\none\nfail\nthree\n"
+	if _, err := im.Import(context.Background(), strings.NewReader(file)); err != nil {
+		t.Fatal(err)
+	}
+	if want := "2,t1\n4,t2\n"; progress.String() != want {
+		t.Fatalf("progress = %q, want %q", progress.String(), want)
+	}
+
+	completed, err := ReadProgress(strings.NewReader(progress.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int]string{2: "t1", 4: "t2"}; !reflect.DeepEqual(completed, want) {
+		t.Errorf("ReadProgress() = %v, want %v", completed, want)
+	}
+
+	// The failed row is the only one created when the import is run again.
+	doer.created = nil
+	im.Completed = completed
+	report, err := im.Import(context.Background(), strings.NewReader(strings.Replace(file, "fail", "two", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Created != 1 || report.Skipped != 2 || len(doer.created) != 1 || doer.created[0] != `{"name":"two"}` {
+		t.Errorf("resumed import created %v, report %+v", doer.created, report)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 type Status struct {
@@ -32,22 +33,22 @@ type SingleTask struct {
 	Description string     `json:"description"`
 	Status      Status     `json:"status"`
 	Orderindex  string     `json:"-"`
-	DateCreated string     `json:"date_created"`
-	DateUpdated string     `json:"date_updated"`
-	DateClosed  string     `json:"date_closed"`
+	DateCreated Timestamp  `json:"date_created"`
+	DateUpdated Timestamp  `json:"date_updated"`
+	DateClosed  Timestamp  `json:"date_closed"`
 	Archived    bool       `json:"archived"`
 	Creator     TeamUser   `json:"creator"`
 	Assignees   []TeamUser `json:"assignees"`
 	Watchers    []TeamUser `json:"watchers"`
 	Checklists  []struct {
-		ID          string `json:"id"`
-		TaskID      string `json:"task_id"`
-		Name        string `json:"name"`
-		DateCreated string `json:"date_created"`
-		Orderindex  int    `json:"-"`
-		Creator     int    `json:"creator"`
-		Resolved    int    `json:"resolved"`
-		Unresolved  int    `json:"unresolved"`
+		ID          string    `json:"id"`
+		TaskID      string    `json:"task_id"`
+		Name        string    `json:"name"`
+		DateCreated Timestamp `json:"date_created"`
+		Orderindex  int       `json:"-"`
+		Creator     int       `json:"creator"`
+		Resolved    int       `json:"resolved"`
+		Unresolved  int       `json:"unresolved"`
 		Items       []struct {
 			ID         string `json:"id"`
 			Name       string `json:"name"`
@@ -60,8 +61,8 @@ type SingleTask struct {
 				Initials       string `json:"initials"`
 				ProfilePicture string `json:"profilePicture"`
 			} `json:"assignee"`
-			Resolved    bool   `json:"resolved"`
-			DateCreated string `json:"date_created"`
+			Resolved    bool      `json:"resolved"`
+			DateCreated Timestamp `json:"date_created"`
 		} `json:"items"`
 	} `json:"checklists"`
 	Tags     []Tag  `json:"tags"`
@@ -72,60 +73,44 @@ type SingleTask struct {
 		Color      string `json:"color"`
 		Orderindex string `json:"-"`
 	} `json:"priority"`
-	DueDate      string `json:"due_date"`
-	StartDate    string `json:"start_date"`
-	Points       int    `json:"points"`
-	TimeEstimate int    `json:"time_estimate"`
-	TimeSpent    int    `json:"time_spent"`
+	DueDate      Timestamp `json:"due_date"`
+	StartDate    Timestamp `json:"start_date"`
+	Points       float64   `json:"points"`
+	TimeEstimate int       `json:"time_estimate"`
+	TimeSpent    int       `json:"time_spent"`
 	CustomFields []struct {
 		ID         string `json:"id"`
 		Name       string `json:"name"`
 		Type       string `json:"type"`
 		TypeConfig struct {
-			Simple             bool   `json:"simple"`
-			Default            int    `json:"default"`
-			Placeholder        string `json:"placeholder"`
-			NewDropDown        bool   `json:"new_drop_down"`
-			SingleUser         bool   `json:"single_user"`
-			IncludeGroups      bool   `json:"include_groups"`
-			IncludeGuests      bool   `json:"include_guests"`
-			IncludeTeamMembers bool   `json:"include_team_members"`
-			Formula            string `json:"formula"`
-			CompleteOn         int    `json:"complete_on"`
-			SubtaskRollup      bool   `json:"subtask_rollup"`
-			Options            []struct {
-				ID         string `json:"id"`
-				Name       string `json:"name"`
-				Color      string `json:"color"`
-				Orderindex int    `json:"-"`
-			} `json:"options"`
-			Fields   []interface{} `json:"fields"`
-			Tracking struct {
+			Simple             bool                `json:"simple"`
+			Default            int                 `json:"default"`
+			Placeholder        string              `json:"placeholder"`
+			NewDropDown        bool                `json:"new_drop_down"`
+			SingleUser         bool                `json:"single_user"`
+			IncludeGroups      bool                `json:"include_groups"`
+			IncludeGuests      bool                `json:"include_guests"`
+			IncludeTeamMembers bool                `json:"include_team_members"`
+			Formula            string              `json:"formula"`
+			CompleteOn         int                 `json:"complete_on"`
+			SubtaskRollup      bool                `json:"subtask_rollup"`
+			Options            []CustomFieldOption `json:"options"`
+			Fields             []interface{}       `json:"fields"`
+			Tracking           struct {
 				Subtasks   bool `json:"subtasks"`
 				Checklists bool `json:"checklists"`
 			} `json:"tracking"`
 		} `json:"type_config"`
-		DateCreated    string      `json:"date_created"`
+		DateCreated    Timestamp   `json:"date_created"`
 		HideFromGuests bool        `json:"hide_from_guests"`
 		Required       bool        `json:"required"`
 		Value          interface{} `json:"value"`
 	} `json:"custom_fields"`
-	Dependencies []struct {
-		TaskID      string `json:"task_id"`
-		DependsOn   string `json:"depends_on"`
-		Type        int    `json:"type"`
-		DateCreated string `json:"date_created"`
-		Userid      string `json:"userid"`
-	} `json:"dependencies"`
-	LinkedTasks []struct {
-		TaskID      string `json:"task_id"`
-		LinkID      string `json:"link_id"`
-		DateCreated string `json:"date_created"`
-		Userid      string `json:"userid"`
-	} `json:"linked_tasks"`
-	TeamID          string `json:"team_id"`
-	URL             string `json:"url"`
-	PermissionLevel string `json:"permission_level"`
+	Dependencies    []TaskDependency `json:"dependencies"`
+	LinkedTasks     []TaskLink       `json:"linked_tasks"`
+	TeamID          string           `json:"team_id"`
+	URL             string           `json:"url"`
+	PermissionLevel string           `json:"permission_level"`
 	List            struct {
 		ID     string `json:"id"`
 		Name   string `json:"name"`
@@ -147,7 +132,7 @@ type SingleTask struct {
 		ID string `json:"id"`
 	} `json:"space"`
 	Subtasks    []SingleTask `json:"subtasks"`
-	Attachments []struct{}   `json:"attachments"`
+	Attachments []Attachment `json:"attachments"`
 }
 
 type GetTasksResponse struct {
@@ -163,8 +148,8 @@ type TaskTimeInStatusResponse struct {
 		Status    string `json:"status"`
 		Color     string `json:"color"`
 		TotalTime struct {
-			ByMinute int    `json:"by_minute"`
-			Since    string `json:"since"`
+			ByMinute int       `json:"by_minute"`
+			Since    Timestamp `json:"since"`
 		} `json:"total_time"`
 	} `json:"current_status"`
 	StatusHistory []struct {
@@ -172,8 +157,8 @@ type TaskTimeInStatusResponse struct {
 		Color     string `json:"color"`
 		Type      string `json:"type"`
 		TotalTime struct {
-			ByMinute int    `json:"by_minute"`
-			Since    string `json:"since"`
+			ByMinute int       `json:"by_minute"`
+			Since    Timestamp `json:"since"`
 		} `json:"total_time"`
 		Orderindex int `json:"-"`
 	} `json:"status_history"`
@@ -189,20 +174,21 @@ const (
 )
 
 type TaskQueryOptions struct {
-	IncludeArchived        bool
-	Page                   int
-	OrderBy                OrderByVal
-	Reverse                bool
-	IncludeSubtasks        bool
-	Statuses               []string // statuses to query
-	IncludeClosed          bool
-	Assignees              []string
-	DueDateGreaterThan     int
-	DueDateLessThan        int
-	DateCreatedGreaterThan int
-	DateCreatedLessThan    int
-	DateUpdatedGreaterThan int
-	DateUpdatedLessThan    int
+	IncludeArchived bool
+	Page            int
+	OrderBy         OrderByVal
+	Reverse         bool
+	IncludeSubtasks bool
+	Statuses        []string // statuses to query
+	IncludeClosed   bool
+	Assignees       []string // user ids
+	// Date filters are exclusive and ignored when zero.
+	DueDateGreaterThan     time.Time
+	DueDateLessThan        time.Time
+	DateCreatedGreaterThan time.Time
+	DateCreatedLessThan    time.Time
+	DateUpdatedGreaterThan time.Time
+	DateUpdatedLessThan    time.Time
 	// CustomFields map[string]interface{}
 }
 
@@ -223,28 +209,29 @@ func queryParamsFor(opts *TaskQueryOptions) *url.Values {
 	if opts.Reverse {
 		urlValues.Add("reverse", "true")
 	}
-	if len(opts.Statuses) > 0 {
-		for _, v := range opts.Statuses {
-			urlValues.Add("statuses%5B%5D", v)
-		}
+	for _, v := range opts.Statuses {
+		urlValues.Add("statuses[]", v)
+	}
+	for _, v := range opts.Assignees {
+		urlValues.Add("assignees[]", v)
 	}
-	if opts.DueDateGreaterThan > 0 {
-		urlValues.Add("due_date_gt", strconv.Itoa(opts.DueDateGreaterThan))
+	if !opts.DueDateGreaterThan.IsZero() {
+		urlValues.Add("due_date_gt", strconv.FormatInt(NewTimestamp(opts.DueDateGreaterThan).Millis(), 10))
 	}
-	if opts.DueDateLessThan > 0 {
-		urlValues.Add("due_date_lt", strconv.Itoa(opts.DueDateLessThan))
+	if !opts.DueDateLessThan.IsZero() {
+		urlValues.Add("due_date_lt", strconv.FormatInt(NewTimestamp(opts.DueDateLessThan).Millis(), 10))
 	}
-	if opts.DateCreatedGreaterThan > 0 {
-		urlValues.Add("date_created_gt", strconv.Itoa(opts.DateCreatedGreaterThan))
+	if !opts.DateCreatedGreaterThan.IsZero() {
+		urlValues.Add("date_created_gt", strconv.FormatInt(NewTimestamp(opts.DateCreatedGreaterThan).Millis(), 10))
 	}
-	if opts.DateCreatedLessThan > 0 {
-		urlValues.Add("date_created_lt", strconv.Itoa(opts.DateCreatedLessThan))
+	if !opts.DateCreatedLessThan.IsZero() {
+		urlValues.Add("date_created_lt", strconv.FormatInt(NewTimestamp(opts.DateCreatedLessThan).Millis(), 10))
 	}
-	if opts.DateUpdatedGreaterThan > 0 {
-		urlValues.Add("date_updated_gt", strconv.Itoa(opts.DateUpdatedGreaterThan))
+	if !opts.DateUpdatedGreaterThan.IsZero() {
+		urlValues.Add("date_updated_gt", strconv.FormatInt(NewTimestamp(opts.DateUpdatedGreaterThan).Millis(), 10))
 	}
-	if opts.DateUpdatedLessThan > 0 {
-		urlValues.Add("date_updated_lt", strconv.Itoa(opts.DateUpdatedLessThan))
+	if !opts.DateUpdatedLessThan.IsZero() {
+		urlValues.Add("date_updated_lt", strconv.FormatInt(NewTimestamp(opts.DateUpdatedLessThan).Millis(), 10))
 	}
 
 	switch opts.OrderBy {
@@ -313,6 +300,77 @@ func (c *Client) BulkTaskTimeInStatus(ctx context.Context, taskIDs []string, wor
 	return bulkTaskTimeInStatus, nil
 }
 
+const maxBulkTimeInStatus = 100
+
+type TimeInStatusOptions struct {
+	WorkspaceID      string
+	UseCustomTaskIDs bool
+	MaxConcurrency   int // maximum number of in flight requests.  Defaults to 4.
+}
+
+// AllTaskTimeInStatus returns the status history of any number of tasks, keyed by task id.  The ids are
+// requested with BulkTaskTimeInStatus in chunks of 100, with at most opts.MaxConcurrency requests in
+// flight, and requests that exceed the Clickup rate limit are retried once the limit resets.  A chunk of a
+// single id is requested with TaskTimeInStatus.  opts may be nil.
+func (c *Client) AllTaskTimeInStatus(ctx context.Context, taskIDs []string, opts *TimeInStatusOptions) (map[string]TaskTimeInStatusResponse, error) {
+	if opts == nil {
+		opts = &TimeInStatusOptions{}
+	}
+	if opts.UseCustomTaskIDs && opts.WorkspaceID == "" {
+		return nil, fmt.Errorf("workspaceID must be provided if querying by custom task id: %w", ErrValidation)
+	}
+	concurrency := opts.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	seen := make(map[string]bool, len(taskIDs))
+	var ids []string
+	for _, id := range taskIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	var chunks [][]string
+	for len(ids) > 0 {
+		n := maxBulkTimeInStatus
+		if len(ids) < n {
+			n = len(ids)
+		}
+		chunks = append(chunks, ids[:n])
+		ids = ids[n:]
+	}
+
+	results := make([]map[string]TaskTimeInStatusResponse, len(chunks))
+	err := runConcurrently(ctx, len(chunks), concurrency, func(ctx context.Context, i int) error {
+		return retryOnRateLimit(ctx, func() error {
+			if len(chunks[i]) == 1 {
+				status, err := c.TaskTimeInStatus(ctx, chunks[i][0], opts.WorkspaceID, opts.UseCustomTaskIDs)
+				if err != nil {
+					return err
+				}
+				results[i] = map[string]TaskTimeInStatusResponse{chunks[i][0]: *status}
+				return nil
+			}
+			var err error
+			results[i], err = c.BulkTaskTimeInStatus(ctx, chunks[i], opts.WorkspaceID, opts.UseCustomTaskIDs)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]TaskTimeInStatusResponse, len(seen))
+	for _, result := range results {
+		for id, status := range result {
+			statuses[id] = status
+		}
+	}
+	return statuses, nil
+}
+
 // TasksForList returns a listing of tasks that belong to the specified listID and fall withing the constraints of queryOpts.
 // Clickup has some rather informal paging, so the caller is responsible for inspecting the count of tasks returned, and incrementing
 // the Page in queryOpts if the number of tasks is 100.
@@ -329,6 +387,106 @@ func (c *Client) TasksForList(ctx context.Context, listID string, queryOpts *Tas
 	return &tasks, nil
 }
 
+// AllTasksForList requests every page of TasksForList for listID and returns the combined tasks.
+// queryOpts may be nil and queryOpts.Page is ignored.  Requests that exceed the Clickup rate limit are
+// retried once the limit resets.
+func (c *Client) AllTasksForList(ctx context.Context, listID string, queryOpts *TaskQueryOptions) ([]SingleTask, error) {
+	opts := TaskQueryOptions{}
+	if queryOpts != nil {
+		opts = *queryOpts
+	}
+	opts.Page = 0
+
+	var tasks []SingleTask
+	for {
+		var page *GetTasksResponse
+		err := retryOnRateLimit(ctx, func() error {
+			var err error
+			page, err = c.TasksForList(ctx, listID, &opts)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, page.Tasks...)
+		if len(page.Tasks) < MaxPageSize {
+			return tasks, nil
+		}
+		opts.Page++
+	}
+}
+
+// WorkspaceTaskQueryOptions filters the tasks of a whole workspace.  The ids narrow the search to
+// tasks in any of the given spaces, folders or lists.
+type WorkspaceTaskQueryOptions struct {
+	TaskQueryOptions
+	SpaceIDs  []string
+	FolderIDs []string
+	ListIDs   []string
+}
+
+// TasksForWorkspace searches the tasks of every list in workspaceID that the authenticated user can
+// access.  queryOpts may be nil.  Like TasksForList, a page holds at most MaxPageSize tasks and
+// queryOpts.Page selects the page.
+func (c *Client) TasksForWorkspace(ctx context.Context, workspaceID string, queryOpts *WorkspaceTaskQueryOptions) (*GetTasksResponse, error) {
+	if workspaceID == "" {
+		return nil, fmt.Errorf("must provide a workspace id to search tasks: %w", ErrValidation)
+	}
+	if queryOpts == nil {
+		queryOpts = &WorkspaceTaskQueryOptions{}
+	}
+
+	urlValues := queryParamsFor(&queryOpts.TaskQueryOptions)
+	for _, v := range queryOpts.SpaceIDs {
+		urlValues.Add("space_ids[]", v)
+	}
+	for _, v := range queryOpts.FolderIDs {
+		urlValues.Add("project_ids[]", v)
+	}
+	for _, v := range queryOpts.ListIDs {
+		urlValues.Add("list_ids[]", v)
+	}
+
+	endpoint := fmt.Sprintf("/team/%s/task?%s", workspaceID, urlValues.Encode())
+
+	var tasks GetTasksResponse
+
+	if err := c.call(ctx, http.MethodGet, endpoint, nil, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &tasks, nil
+}
+
+// AllTasksForWorkspace requests every page of TasksForWorkspace and returns the combined tasks.
+// queryOpts may be nil and queryOpts.Page is ignored.  Requests that exceed the Clickup rate limit are
+// retried once the limit resets.
+func (c *Client) AllTasksForWorkspace(ctx context.Context, workspaceID string, queryOpts *WorkspaceTaskQueryOptions) ([]SingleTask, error) {
+	opts := WorkspaceTaskQueryOptions{}
+	if queryOpts != nil {
+		opts = *queryOpts
+	}
+	opts.Page = 0
+
+	var tasks []SingleTask
+	for {
+		var page *GetTasksResponse
+		err := retryOnRateLimit(ctx, func() error {
+			var err error
+			page, err = c.TasksForWorkspace(ctx, workspaceID, &opts)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, page.Tasks...)
+		if len(page.Tasks) < MaxPageSize {
+			return tasks, nil
+		}
+		opts.Page++
+	}
+}
+
 // TaskByID queries a single task.
 func (c *Client) TaskByID(ctx context.Context, taskID, workspaceID string, useCustomTaskIDs, includeSubtasks bool) (*SingleTask, error) {
 	if useCustomTaskIDs && workspaceID == "" {
@@ -352,14 +510,26 @@ func (c *Client) TaskByID(ctx context.Context, taskID, workspaceID string, useCu
 }
 
 type TaskRequest struct {
-	Name          string   `json:"name"`
-	Description   string   `json:"description,omitempty"`
-	Tags          []string `json:"tags,omitempty"`
-	Status        string   `json:"status,omitempty"`
-	DueDate       int      `json:"due_date,omitempty"`
-	DueDateTime   bool     `json:"due_date_time,omitempty"`
-	StartDate     int      `json:"start_date,omitempty"`
-	StartDateTime bool     `json:"start_date_time,omitempty"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description,omitempty"`
+	Assignees     []int             `json:"assignees,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	Status        string            `json:"status,omitempty"`
+	Priority      *int              `json:"priority,omitempty"`
+	DueDate       *Timestamp        `json:"due_date,omitempty"`
+	DueDateTime   bool              `json:"due_date_time,omitempty"`
+	StartDate     *Timestamp        `json:"start_date,omitempty"`
+	StartDateTime bool              `json:"start_date_time,omitempty"`
+	Parent        string            `json:"parent,omitempty"` // task id, to create a subtask
+	CustomFields  []TaskCustomField `json:"custom_fields,omitempty"`
+}
+
+// TaskCustomField sets the custom field with ID on a new task.  Value must be in the form Clickup
+// expects for the type of the field, such as an option id for a drop_down field or unix milliseconds
+// for a date field.
+type TaskCustomField struct {
+	ID    string      `json:"id"`
+	Value interface{} `json:"value"`
 }
 
 // CreateTask inserts a new task into the specified list.
@@ -388,16 +558,26 @@ func (c *Client) CreateTask(ctx context.Context, listID string, task TaskRequest
 	return &newTask, nil
 }
 
+// TaskUpdateRequest uses patch semantics.  Only the fields that are explicitly set
+// with OptString, OptInt, OptBool, OptTimestamp or their Null* counterparts are sent to Clickup,
+// so a due date can be cleared with NullTimestamp() while every other field is left unchanged.
 type TaskUpdateRequest struct {
-	ID            string   `json:"id"`
-	Name          string   `json:"name"`
-	Description   string   `json:"description,omitempty"`
-	Tags          []string `json:"tags,omitempty"`
-	Status        string   `json:"status,omitempty"`
-	DueDate       int      `json:"due_date,omitempty"`
-	DueDateTime   bool     `json:"due_date_time,omitempty"`
-	StartDate     int      `json:"start_date,omitempty"`
-	StartDateTime bool     `json:"start_date_time,omitempty"`
+	ID            string            `json:"-"`
+	Name          OptionalString    `json:"name"`
+	Description   OptionalString    `json:"description"`
+	Tags          []string          `json:"tags"`
+	Status        OptionalString    `json:"status"`
+	Priority      OptionalInt       `json:"priority"`
+	DueDate       OptionalTimestamp `json:"due_date"`
+	DueDateTime   OptionalBool      `json:"due_date_time"`
+	StartDate     OptionalTimestamp `json:"start_date"`
+	StartDateTime OptionalBool      `json:"start_date_time"`
+	TimeEstimate  OptionalInt       `json:"time_estimate"`
+	Archived      OptionalBool      `json:"archived"`
+}
+
+func (t TaskUpdateRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(t)
 }
 
 // UpdateTask changes an existing task.
@@ -450,10 +630,22 @@ type CustomFieldInfo struct {
 	typ string
 }
 
+// Value returns the value of the custom field, or nil if the field is not set.
+func (c *CustomFieldInfo) Value() interface{} {
+	return c.val
+}
+
+// Type returns the type of the custom field, such as "drop_down" or "date".
+func (c *CustomFieldInfo) Type() string {
+	return c.typ
+}
+
 // CustomFieldVal finds the value from a list of arbitrary custome fields and types
 // from the task t.  fieldName is used as the target field to extract.
 // Custom fields that are of type "date" will be returned in the CustomFieldInfo
-// as a string of unix milliseconds.
+// as a string of unix milliseconds.  Drop downs are returned as the name of the
+// selected option, matched by option id or orderindex, and other fields as their
+// raw value.
 // CustomFieldInfo is an interface{} and should be handled accordingly.
 // The consumer of this library can do any of this themselves with the SingleTask
 // model.  This is simply a utility function.
@@ -467,19 +659,104 @@ func (t *SingleTask) CustomFieldVal(fieldName string) *CustomFieldInfo {
 					cf.val = ""
 					return &cf
 				}
-				cf.val = field.Value.(string)
+				cf.val, _ = field.Value.(string)
 				cf.typ = field.Type
 				return &cf
 			}
-			for _, v := range field.TypeConfig.Options {
-				if float64(v.Orderindex) == field.Value.(float64) {
-					cf.val = v.Name
-					cf.typ = field.Type
-					break
-				}
+			if len(field.TypeConfig.Options) == 0 {
+				cf.val = field.Value
+				cf.typ = field.Type
+				break
+			}
+			if name, ok := optionName(field.TypeConfig.Options, field.Value); ok {
+				cf.val = name
+				cf.typ = field.Type
 			}
 			break
 		}
 	}
 	return &cf
 }
+
+// CustomFieldStrings returns the value of the i'th custom field of t as text.  Drop downs hold the name
+// of the selected option, labels and users fields the label names and usernames, dates a string of
+// unix milliseconds and lists of other values a string per item.  It returns nil if the field is not
+// set.
+func (t *SingleTask) CustomFieldStrings(i int) []string {
+	if i < 0 || i >= len(t.CustomFields) {
+		return nil
+	}
+	field := t.CustomFields[i]
+	switch field.Type {
+	case "labels":
+		ids, _ := field.Value.([]interface{})
+		var labels []string
+		for _, id := range ids {
+			for _, option := range field.TypeConfig.Options {
+				if option.ID == id {
+					labels = append(labels, option.Label)
+				}
+			}
+		}
+		return labels
+	case "users":
+		users, _ := field.Value.([]interface{})
+		var names []string
+		for _, user := range users {
+			if user, ok := user.(map[string]interface{}); ok {
+				names = append(names, jsonText(user["username"]))
+			}
+		}
+		return names
+	}
+
+	if len(field.TypeConfig.Options) > 0 {
+		if name, ok := optionName(field.TypeConfig.Options, field.Value); ok {
+			return []string{name}
+		}
+		return nil
+	}
+	switch v := field.Value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, jsonText(item))
+		}
+		return values
+	}
+	if s := jsonText(field.Value); s != "" {
+		return []string{s}
+	}
+	return nil
+}
+
+// optionName returns the name of the drop down option that value refers to by id or orderindex.
+func optionName(options []CustomFieldOption, value interface{}) (string, bool) {
+	for _, option := range options {
+		if option.ID != "" && value == option.ID || value == float64(option.Orderindex) {
+			return option.Name, true
+		}
+	}
+	return "", false
+}
+
+// jsonText formats a value decoded from JSON.
+func jsonText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -8,9 +8,15 @@ package clickup
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -260,6 +266,59 @@ func TestClient_BulkTaskTimeInStatus(t *testing.T) {
 	}
 }
 
+func TestClient_AllTaskTimeInStatus(t *testing.T) {
+	var mu sync.Mutex
+	var bulk, single int
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			var body string
+			if ids := req.URL.Query()["task_ids"]; len(ids) > 0 {
+				bulk++
+				if len(ids) > 100 {
+					t.Errorf("bulk request for %d tasks", len(ids))
+				}
+				entries := make([]string, 0, len(ids))
+				for _, id := range ids {
+					entries = append(entries, fmt.Sprintf(`%q: {"current_status": {"status": "open"}}`, id))
+				}
+				body = "{" + strings.Join(entries, ",") + "}"
+			} else {
+				single++
+				body = `{"current_status": {"status": "done"}}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+
+	ids := []string{"t0"}
+	for i := 0; i < 201; i++ {
+		ids = append(ids, "t"+strconv.Itoa(i))
+	}
+	statuses, err := c.AllTaskTimeInStatus(context.Background(), ids, &TimeInStatusOptions{MaxConcurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 201 || bulk != 2 || single != 1 {
+		t.Errorf("got %d statuses from %d bulk and %d single requests, want 201 from 2 and 1", len(statuses), bulk, single)
+	}
+	if got := statuses["t200"].CurrentStatus.Status; got != "done" {
+		t.Errorf("status of the last task = %q, want done", got)
+	}
+
+	_, err = c.AllTaskTimeInStatus(context.Background(), ids, &TimeInStatusOptions{UseCustomTaskIDs: true})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("custom task ids without a workspace error = %v, want ErrValidation", err)
+	}
+}
+
 func TestClient_TasksForList(t *testing.T) {
 	type fields struct {
 		doer    ClientDoer
@@ -623,7 +682,7 @@ func TestClient_UpdateTask(t *testing.T) {
 			args: args{
 				task: &TaskUpdateRequest{
 					ID:          "TestID",
-					Description: "Updated description",
+					Description: OptString("Updated description"),
 				},
 			},
 			wantErr: true,
@@ -643,7 +702,7 @@ func TestClient_UpdateTask(t *testing.T) {
 			args: args{
 				task: &TaskUpdateRequest{
 					ID:          "TestID",
-					Description: "Updated description",
+					Description: OptString("Updated description"),
 				},
 			},
 			wantErr: false,
@@ -723,3 +782,134 @@ func TestClient_DeleteTask(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_AllTasksForWorkspace(t *testing.T) {
+	var queries []string
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			queries = append(queries, req.URL.RawQuery)
+			body := `{"tasks":[{"id":"last"}]}`
+			if req.URL.Query().Get("page") == "0" {
+				tasks := make([]string, MaxPageSize)
+				for i := range tasks {
+					tasks[i] = `{"id":"t"}`
+				}
+				body = `{"tasks":[` + strings.Join(tasks, ",") + `]}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+
+	tasks, err := c.AllTasksForWorkspace(context.Background(), "fakeTeamID", &WorkspaceTaskQueryOptions{
+		TaskQueryOptions: TaskQueryOptions{Page: 7, IncludeClosed: true},
+		SpaceIDs:         []string{"s1"},
+		ListIDs:          []string{"l1", "l2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != MaxPageSize+1 || tasks[MaxPageSize].ID != "last" {
+		t.Errorf("got %d tasks, want %d ending with last", len(tasks), MaxPageSize+1)
+	}
+	want := []string{
+		"include_closed=true&list_ids%5B%5D=l1&list_ids%5B%5D=l2&page=0&space_ids%5B%5D=s1",
+		"include_closed=true&list_ids%5B%5D=l1&list_ids%5B%5D=l2&page=1&space_ids%5B%5D=s1",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %v, want %v", queries, want)
+	}
+
+	if _, err := c.TasksForWorkspace(context.Background(), "", nil); !errors.Is(err, ErrValidation) {
+		t.Errorf("TasksForWorkspace() without a workspace error = %v, want ErrValidation", err)
+	}
+}
+
+func TestQueryParamsFor_arrays(t *testing.T) {
+	tests := []struct {
+		name string
+		opts TaskQueryOptions
+		want string
+	}{
+		{
+			name: "Statuses",
+			opts: TaskQueryOptions{Statuses: []string{"open", "in review"}},
+			want: "page=0&statuses%5B%5D=open&statuses%5B%5D=in+review",
+		},
+		{
+			name: "Assignees",
+			opts: TaskQueryOptions{Assignees: []string{"7", "8"}},
+			want: "assignees%5B%5D=7&assignees%5B%5D=8&page=0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queryParamsFor(&tt.opts).Encode(); got != tt.want {
+				t.Errorf("queryParamsFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSingleTask_CustomFieldVal(t *testing.T) {
+	options := `"type_config": {"options": [{"id": "o1", "name": "S", "orderindex": 0}, {"id": "o2", "name": "M", "orderindex": 1},
+		{"id": "o3", "name": "L", "orderindex": "2"}]}`
+	tests := []struct {
+		name     string
+		field    string
+		wantVal  interface{}
+		wantType string
+	}{
+		{name: "Drop down by first orderindex", field: `{"name": "Size", "type": "drop_down", "value": 0, ` + options + `}`, wantVal: "S", wantType: "drop_down"},
+		{name: "Drop down by orderindex", field: `{"name": "Size", "type": "drop_down", "value": 1, ` + options + `}`, wantVal: "M", wantType: "drop_down"},
+		{name: "Drop down by string orderindex", field: `{"name": "Size", "type": "drop_down", "value": 2, ` + options + `}`, wantVal: "L", wantType: "drop_down"},
+		{name: "Drop down by option id", field: `{"name": "Size", "type": "drop_down", "value": "o2", ` + options + `}`, wantVal: "M", wantType: "drop_down"},
+		{name: "Drop down not set", field: `{"name": "Size", "type": "drop_down", ` + options + `}`},
+		{name: "Date", field: `{"name": "Size", "type": "date", "value": "1656633600000"}`, wantVal: "1656633600000", wantType: "date"},
+		{name: "Number", field: `{"name": "Size", "type": "number", "value": "5"}`, wantVal: "5", wantType: "number"},
+		{name: "Missing field", field: `{"name": "Other", "type": "number", "value": "5"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var task SingleTask
+			if err := json.Unmarshal([]byte(`{"custom_fields": [`+tt.field+`]}`), &task); err != nil {
+				t.Fatal(err)
+			}
+			info := task.CustomFieldVal("Size")
+			if info.Value() != tt.wantVal || info.Type() != tt.wantType {
+				t.Errorf("CustomFieldVal() = %v (%s), want %v (%s)", info.Value(), info.Type(), tt.wantVal, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestSingleTask_CustomFieldStrings(t *testing.T) {
+	var task SingleTask
+	err := json.Unmarshal([]byte(`{"custom_fields": [
+		{"name": "Size", "type": "drop_down", "value": 1,
+			"type_config": {"options": [{"id": "o1", "name": "S", "orderindex": 0}, {"id": "o2", "name": "M", "orderindex": 1}]}},
+		{"name": "Areas", "type": "labels", "value": ["a2", "a1"],
+			"type_config": {"options": [{"id": "a1", "label": "API"}, {"id": "a2", "label": "Web"}]}},
+		{"name": "Reviewers", "type": "users", "value": [{"id": 1, "username": "ana"}, {"id": 2, "username": "bo"}]},
+		{"name": "Review", "type": "date", "value": "1656633600000"},
+		{"name": "Estimate", "type": "number", "value": 2.5},
+		{"name": "Related", "type": "tasks", "value": ["t1", "t2"]},
+		{"name": "Notes", "type": "text"}
+	]}`), &task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"M"}, {"Web", "API"}, {"ana", "bo"}, {"1656633600000"}, {"2.5"}, {"t1", "t2"}, nil}
+	for i := range task.CustomFields {
+		if got := task.CustomFieldStrings(i); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("CustomFieldStrings(%d) = %q, want %q", i, got, want[i])
+		}
+	}
+	if got := task.CustomFieldStrings(len(task.CustomFields)); got != nil {
+		t.Errorf("CustomFieldStrings() out of range = %q, want nil", got)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tasksync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// Checkpoint is how far a list has been synced.
+type Checkpoint struct {
+	// Watermark is the latest DateUpdated of the tasks read from the list.
+	Watermark time.Time `json:"watermark"`
+	// Reconciled is when every task of the list was last read to find deleted tasks.
+	Reconciled time.Time `json:"reconciled"`
+}
+
+// Store persists the local copy of tasks and the checkpoint of every list.  A task belongs to one list
+// at a time, the list it was last Put with.  Implementations must be safe for concurrent use.
+type Store interface {
+	// Checkpoint returns the checkpoint of a list, or the zero Checkpoint if it was never synced.
+	Checkpoint(ctx context.Context, listID string) (Checkpoint, error)
+	SetCheckpoint(ctx context.Context, listID string, checkpoint Checkpoint) error
+	// Task returns the stored copy of a task, or nil if there is none.
+	Task(ctx context.Context, taskID string) (*clickup.SingleTask, error)
+	// TaskIDs returns the ids of the tasks stored for a list.
+	TaskIDs(ctx context.Context, listID string) ([]string, error)
+	Put(ctx context.Context, listID string, task clickup.SingleTask) error
+	Delete(ctx context.Context, taskID string) error
+}
+
+// MemoryStore is a Store that keeps everything in memory.  WriteJSON and ReadMemoryStore save and load
+// it, for example to a file between runs.  The zero MemoryStore is empty and ready to use.
+type MemoryStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+	tasks       map[string]storedTask
+}
+
+type storedTask struct {
+	ListID string             `json:"list_id"`
+	Task   clickup.SingleTask `json:"task"`
+}
+
+// memoryStoreFile is the JSON form of a MemoryStore.
+type memoryStoreFile struct {
+	Checkpoints map[string]Checkpoint `json:"checkpoints"`
+	Tasks       []storedTask          `json:"tasks"`
+}
+
+// ReadMemoryStore reads a MemoryStore written by WriteJSON.
+func ReadMemoryStore(r io.Reader) (*MemoryStore, error) {
+	var file memoryStoreFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	s := &MemoryStore{checkpoints: file.Checkpoints, tasks: map[string]storedTask{}}
+	for _, t := range file.Tasks {
+		s.tasks[t.Task.ID] = t
+	}
+	return s, nil
+}
+
+// WriteJSON writes the checkpoints and tasks of s to w.
+func (s *MemoryStore) WriteJSON(w io.Writer) error {
+	s.mu.Lock()
+	file := memoryStoreFile{Checkpoints: map[string]Checkpoint{}, Tasks: []storedTask{}}
+	for id, c := range s.checkpoints {
+		file.Checkpoints[id] = c
+	}
+	for _, t := range s.tasks {
+		file.Tasks = append(file.Tasks, t)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(file.Tasks, func(i, j int) bool { return file.Tasks[i].Task.ID < file.Tasks[j].Task.ID })
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(file)
+}
+
+func (s *MemoryStore) Checkpoint(ctx context.Context, listID string) (Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[listID], nil
+}
+
+func (s *MemoryStore) SetCheckpoint(ctx context.Context, listID string, checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checkpoints == nil {
+		s.checkpoints = map[string]Checkpoint{}
+	}
+	s.checkpoints[listID] = checkpoint
+	return nil
+}
+
+func (s *MemoryStore) Task(ctx context.Context, taskID string) (*clickup.SingleTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tasks[taskID]
+	if !ok {
+		return nil, nil
+	}
+	return &t.Task, nil
+}
+
+func (s *MemoryStore) TaskIDs(ctx context.Context, listID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for id, t := range s.tasks {
+		if t.ListID == listID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *MemoryStore) Put(ctx context.Context, listID string, task clickup.SingleTask) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tasks == nil {
+		s.tasks = map[string]storedTask{}
+	}
+	s.tasks[task.ID] = storedTask{ListID: listID, Task: task}
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, taskID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tasks, taskID)
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tasksync
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := &MemoryStore{}
+	checkpoint := Checkpoint{Watermark: base, Reconciled: base.Add(time.Hour)}
+	store.SetCheckpoint(ctx, "l1", checkpoint)
+	store.Put(ctx, "l1", clickup.SingleTask{ID: "t2", Name: "Second", DateUpdated: clickup.NewTimestamp(base)})
+	store.Put(ctx, "l1", clickup.SingleTask{ID: "t1", Name: "First"})
+	store.Put(ctx, "l2", clickup.SingleTask{ID: "t3", Name: "Third"})
+	store.Put(ctx, "l2", clickup.SingleTask{ID: "t1", Name: "First, moved"})
+	store.Delete(ctx, "t3")
+
+	var b bytes.Buffer
+	if err := store.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadMemoryStore(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := loaded.Checkpoint(ctx, "l1")
+	if !got.Watermark.Equal(checkpoint.Watermark) || !got.Reconciled.Equal(checkpoint.Reconciled) {
+		t.Errorf("Checkpoint() = %+v, want %+v", got, checkpoint)
+	}
+	if got, _ := loaded.Checkpoint(ctx, "l2"); got != (Checkpoint{}) {
+		t.Errorf("Checkpoint() of a list never synced = %+v", got)
+	}
+	for list, want := range map[string][]string{"l1": {"t2"}, "l2": {"t1"}} {
+		if ids, _ := loaded.TaskIDs(ctx, list); !reflect.DeepEqual(ids, want) {
+			t.Errorf("TaskIDs(%s) = %v, want %v", list, ids, want)
+		}
+	}
+	task, _ := loaded.Task(ctx, "t2")
+	if task == nil || task.Name != "Second" || !task.DateUpdated.Equal(base) {
+		t.Errorf("Task() = %+v", task)
+	}
+	if task, _ := loaded.Task(ctx, "t3"); task != nil {
+		t.Errorf("Task() of a deleted task = %+v", task)
+	}
+
+	if _, err := ReadMemoryStore(strings.NewReader("{")); err == nil {
+		t.Error("ReadMemoryStore() of invalid JSON did not fail")
+	}
+}
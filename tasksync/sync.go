@@ -0,0 +1,224 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package tasksync keeps a local copy of the tasks of lists current without reading every task on
+// every poll.
+//
+// A Syncer remembers the latest DateUpdated it has seen in each list, its watermark, and only requests
+// the tasks updated since then, ordered by update.  Updates cannot reveal deleted tasks, so a list is
+// reconciled by reading all of its tasks on its first sync, every ReconcileEvery, or when Reconcile is
+// called.  Tasks missing from a reconciled list were deleted, archived or moved to a list that is not
+// synced.  HandleWebhook applies taskDeleted webhook events between reconciliations.
+//
+// Changes are reported after the store is updated, so a sync that fails part way returns the changes
+// stored so far along with the error, and the next sync carries on from the last stored checkpoint.
+package tasksync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// DefaultOverlap is how far before its watermark a list is read again.
+const DefaultOverlap = time.Minute
+
+// ChangeType is the kind of change to a task.
+type ChangeType string
+
+const (
+	Created ChangeType = "created"
+	Updated ChangeType = "updated"
+	Deleted ChangeType = "deleted"
+)
+
+// Change is a change to a synced task.  Task is the task as read from Clickup, or the last stored copy
+// of a deleted task.
+type Change struct {
+	Type   ChangeType         `json:"type"`
+	ListID string             `json:"list_id"`
+	TaskID string             `json:"task_id"`
+	Task   clickup.SingleTask `json:"task"`
+}
+
+// ListResult is what a sync did for one list.
+type ListResult struct {
+	ListID     string     `json:"list_id"`
+	Reconciled bool       `json:"reconciled"`
+	Read       int        `json:"read"` // tasks read from Clickup
+	Checkpoint Checkpoint `json:"checkpoint"`
+}
+
+// Result is the outcome of a sync.
+type Result struct {
+	Changes []Change     `json:"changes"`
+	Lists   []ListResult `json:"lists"`
+}
+
+// Syncer syncs the tasks of ListIDs into Store.
+type Syncer struct {
+	Client  *clickup.Client
+	Store   Store
+	ListIDs []string
+	// IncludeSubtasks syncs subtasks as well as top level tasks.  Closed tasks are always synced.
+	IncludeSubtasks bool
+	// ReconcileEvery is how often a list is read in full to find deleted tasks.  When zero, lists are
+	// reconciled on their first sync and when Reconcile is called.
+	ReconcileEvery time.Duration
+	// Overlap is how far before the watermark a list is read again, so that updates saved in Clickup
+	// while a list was being read are not missed.  Tasks that did not change since they were stored
+	// are not reported again.  It defaults to DefaultOverlap.
+	Overlap time.Duration
+
+	now func() time.Time
+}
+
+// Sync reads the tasks of every list updated since its watermark, or all of its tasks if it is due
+// to be reconciled, and stores them.
+func (s *Syncer) Sync(ctx context.Context) (*Result, error) {
+	return s.sync(ctx, false)
+}
+
+// Reconcile reads all tasks of every list, stores them and deletes the stored tasks that are gone.
+func (s *Syncer) Reconcile(ctx context.Context) (*Result, error) {
+	return s.sync(ctx, true)
+}
+
+func (s *Syncer) sync(ctx context.Context, reconcile bool) (*Result, error) {
+	result := &Result{Changes: []Change{}, Lists: []ListResult{}}
+	if s.Client == nil || s.Store == nil {
+		return result, fmt.Errorf("a syncer needs a client and a store: %w", clickup.ErrValidation)
+	}
+	for _, listID := range s.ListIDs {
+		if listID == "" {
+			return result, fmt.Errorf("must provide a list id to sync: %w", clickup.ErrValidation)
+		}
+		list, changes, err := s.syncList(ctx, listID, reconcile)
+		result.Changes = append(result.Changes, changes...)
+		if err != nil {
+			return result, fmt.Errorf("failed to sync list %s: %w", listID, err)
+		}
+		result.Lists = append(result.Lists, list)
+	}
+	return result, nil
+}
+
+func (s *Syncer) syncList(ctx context.Context, listID string, reconcile bool) (ListResult, []Change, error) {
+	list := ListResult{ListID: listID}
+	checkpoint, err := s.Store.Checkpoint(ctx, listID)
+	if err != nil {
+		return list, nil, err
+	}
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	started := now()
+	overlap := s.Overlap
+	if overlap == 0 {
+		overlap = DefaultOverlap
+	}
+
+	list.Reconciled = reconcile || checkpoint.Reconciled.IsZero() ||
+		(s.ReconcileEvery > 0 && !started.Before(checkpoint.Reconciled.Add(s.ReconcileEvery)))
+	opts := &clickup.TaskQueryOptions{
+		IncludeClosed:   true,
+		IncludeSubtasks: s.IncludeSubtasks,
+		OrderBy:         clickup.OrderByUpdated,
+	}
+	if !list.Reconciled && !checkpoint.Watermark.IsZero() {
+		opts.DateUpdatedGreaterThan = checkpoint.Watermark.Add(-overlap)
+	}
+	tasks, err := s.Client.AllTasksForList(ctx, listID, opts)
+	if err != nil {
+		return list, nil, err
+	}
+	list.Read = len(tasks)
+
+	var changes []Change
+	seen := map[string]bool{}
+	for _, task := range tasks {
+		seen[task.ID] = true
+		if task.DateUpdated.After(checkpoint.Watermark) {
+			checkpoint.Watermark = task.DateUpdated.Time
+		}
+
+		stored, err := s.Store.Task(ctx, task.ID)
+		if err != nil {
+			return list, changes, err
+		}
+		change := Change{Type: Updated, ListID: listID, TaskID: task.ID, Task: task}
+		if stored == nil {
+			change.Type = Created
+		} else if !task.DateUpdated.After(stored.DateUpdated.Time) && stored.List.ID == task.List.ID {
+			continue
+		}
+		if err := s.Store.Put(ctx, listID, task); err != nil {
+			return list, changes, err
+		}
+		changes = append(changes, change)
+	}
+
+	if list.Reconciled {
+		ids, err := s.Store.TaskIDs(ctx, listID)
+		if err != nil {
+			return list, changes, err
+		}
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			change, err := s.delete(ctx, id, listID)
+			if err != nil {
+				return list, changes, err
+			}
+			if change != nil {
+				changes = append(changes, *change)
+			}
+		}
+		checkpoint.Reconciled = started
+		if checkpoint.Watermark.IsZero() {
+			// An empty list has no watermark yet, so the next sync reads from when this one started.
+			checkpoint.Watermark = started
+		}
+	}
+
+	if err := s.Store.SetCheckpoint(ctx, listID, checkpoint); err != nil {
+		return list, changes, err
+	}
+	list.Checkpoint = checkpoint
+	return list, changes, nil
+}
+
+// HandleWebhook applies a webhook event to the store.  taskDeleted events delete the task and return
+// the change, and other events return nil as the next sync reads the updated tasks.
+func (s *Syncer) HandleWebhook(ctx context.Context, event *clickup.WebhookEventMessage) (*Change, error) {
+	if s.Store == nil {
+		return nil, fmt.Errorf("a syncer needs a store: %w", clickup.ErrValidation)
+	}
+	if event == nil || event.Event != clickup.EventTaskDeleted || event.TaskID == "" {
+		return nil, nil
+	}
+	return s.delete(ctx, event.TaskID, "")
+}
+
+// delete deletes a stored task and returns the change, or nil if it was not stored.  listID defaults
+// to the list of the stored task.
+func (s *Syncer) delete(ctx context.Context, taskID, listID string) (*Change, error) {
+	stored, err := s.Store.Task(ctx, taskID)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	if err := s.Store.Delete(ctx, taskID); err != nil {
+		return nil, err
+	}
+	if listID == "" {
+		listID = stored.List.ID
+	}
+	return &Change{Type: Deleted, ListID: listID, TaskID: taskID, Task: *stored}, nil
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tasksync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Guitarbum722/clickup-client-go"
+)
+
+// fakeDoer answers every request with body, or with the body of the list requested, and records the
+// request URIs.
+type fakeDoer struct {
+	status int
+	body   string
+	lists  map[string]string
+	uris   []string
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	d.uris = append(d.uris, req.URL.RequestURI())
+	status := d.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	body := d.body
+	for id, b := range d.lists {
+		if strings.HasPrefix(req.URL.Path, "/api/v2/list/"+id+"/") {
+			body = b
+		}
+	}
+	return &http.Response{StatusCode: status, Body: ioutil.NopCloser(strings.NewReader(body)), Request: req}, nil
+}
+
+func newTestClient(doer *fakeDoer) *clickup.Client {
+	return clickup.NewClient(&clickup.ClientOpts{Doer: doer, Authenticator: &clickup.APITokenAuthenticator{}})
+}
+
+var base = time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+
+// tasks returns a tasks response of the tasks given as id:list:minutes after base they were updated.
+func tasks(specs ...string) string {
+	var parts []string
+	for _, spec := range specs {
+		f := strings.Split(spec, ":")
+		var minutes int
+		fmt.Sscan(f[2], &minutes)
+		updated := clickup.NewTimestamp(base.Add(time.Duration(minutes) * time.Minute)).Millis()
+		parts = append(parts, fmt.Sprintf(`{"id": %q, "name": "Task %s", "date_updated": "%d", "list": {"id": %q}}`, f[0], f[0], updated, f[1]))
+	}
+	return `{"tasks": [` + strings.Join(parts, ",") + `]}`
+}
+
+func summarize(changes []Change) []string {
+	var got []string
+	for _, c := range changes {
+		got = append(got, fmt.Sprintf("%s %s %s", c.Type, c.ListID, c.TaskID))
+	}
+	return got
+}
+
+func TestSyncer(t *testing.T) {
+	doer := &fakeDoer{}
+	store := &MemoryStore{}
+	now := base.Add(time.Hour)
+	s := &Syncer{
+		Client:         newTestClient(doer),
+		Store:          store,
+		ListIDs:        []string{"l1"},
+		ReconcileEvery: 24 * time.Hour,
+		now:            func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	steps := []struct {
+		name           string
+		run            func() (*Result, error)
+		body           string
+		wantURI        string
+		wantChanges    []string
+		wantReconciled bool
+		wantWatermark  time.Time
+	}{
+		{
+			name:           "First sync reads every task",
+			run:            func() (*Result, error) { return s.Sync(ctx) },
+			body:           tasks("t1:l1:10", "t2:l1:20"),
+			wantURI:        "/api/v2/list/l1/task/?include_closed=true&order_by=updated&page=0",
+			wantChanges:    []string{"created l1 t1", "created l1 t2"},
+			wantReconciled: true,
+			wantWatermark:  base.Add(20 * time.Minute),
+		},
+		{
+			name:          "Later syncs read updates since the watermark",
+			run:           func() (*Result, error) { return s.Sync(ctx) },
+			body:          tasks("t2:l1:20", "t2b:l1:25", "t1:l1:30"),
+			wantURI:       fmt.Sprintf("/api/v2/list/l1/task/?date_updated_gt=%d&include_closed=true&order_by=updated&page=0", clickup.NewTimestamp(base.Add(19*time.Minute)).Millis()),
+			wantChanges:   []string{"created l1 t2b", "updated l1 t1"},
+			wantWatermark: base.Add(30 * time.Minute),
+		},
+		{
+			name:           "Reconcile deletes missing tasks",
+			run:            func() (*Result, error) { return s.Reconcile(ctx) },
+			body:           tasks("t1:l1:30", "t2b:l1:25"),
+			wantURI:        "/api/v2/list/l1/task/?include_closed=true&order_by=updated&page=0",
+			wantChanges:    []string{"deleted l1 t2"},
+			wantReconciled: true,
+			wantWatermark:  base.Add(30 * time.Minute),
+		},
+		{
+			name: "Reconcile when due",
+			run: func() (*Result, error) {
+				now = now.Add(25 * time.Hour)
+				return s.Sync(ctx)
+			},
+			body:           tasks("t1:l1:30"),
+			wantURI:        "/api/v2/list/l1/task/?include_closed=true&order_by=updated&page=0",
+			wantChanges:    []string{"deleted l1 t2b"},
+			wantReconciled: true,
+			wantWatermark:  base.Add(30 * time.Minute),
+		},
+	}
+	for _, step := range steps {
+		doer.body, doer.uris = step.body, nil
+		result, err := step.run()
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if want := []string{step.wantURI}; !reflect.DeepEqual(doer.uris, want) {
+			t.Errorf("%s: requests = %v, want %v", step.name, doer.uris, want)
+		}
+		if got := summarize(result.Changes); !reflect.DeepEqual(got, step.wantChanges) {
+			t.Errorf("%s: changes = %v, want %v", step.name, got, step.wantChanges)
+		}
+		list := result.Lists[0]
+		if list.Reconciled != step.wantReconciled || !list.Checkpoint.Watermark.Equal(step.wantWatermark) {
+			t.Errorf("%s: reconciled %v with watermark %s, want %v with %s",
+				step.name, list.Reconciled, list.Checkpoint.Watermark, step.wantReconciled, step.wantWatermark)
+		}
+		if stored, _ := store.Checkpoint(ctx, "l1"); stored != list.Checkpoint {
+			t.Errorf("%s: stored checkpoint = %+v, want %+v", step.name, stored, list.Checkpoint)
+		}
+	}
+
+	if ids, _ := store.TaskIDs(ctx, "l1"); !reflect.DeepEqual(ids, []string{"t1"}) {
+		t.Errorf("stored tasks = %v, want [t1]", ids)
+	}
+}
+
+func TestSyncer_movedTask(t *testing.T) {
+	doer := &fakeDoer{body: tasks(), lists: map[string]string{"l1": tasks("t1:l1:10")}}
+	store := &MemoryStore{}
+	s := &Syncer{Client: newTestClient(doer), Store: store, ListIDs: []string{"l1", "l2"}}
+	ctx := context.Background()
+	if _, err := s.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The task moved to l2 without its date_updated changing within the overlap.
+	doer.lists = map[string]string{"l2": tasks("t1:l2:10")}
+	result, err := s.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := summarize(result.Changes), []string{"updated l2 t1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+	if ids, _ := store.TaskIDs(ctx, "l2"); !reflect.DeepEqual(ids, []string{"t1"}) {
+		t.Errorf("tasks of l2 = %v, want [t1]", ids)
+	}
+
+	// Reconciling the list it left does not delete it.
+	result, err = s.Reconcile(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Changes) != 0 {
+		t.Errorf("changes = %v, want none", summarize(result.Changes))
+	}
+}
+
+func TestSyncer_HandleWebhook(t *testing.T) {
+	store := &MemoryStore{}
+	ctx := context.Background()
+	store.Put(ctx, "l1", clickup.SingleTask{ID: "t1", Name: "Gone"})
+	s := &Syncer{Store: store}
+
+	tests := []struct {
+		name  string
+		event *clickup.WebhookEventMessage
+		want  *Change
+	}{
+		{name: "Update", event: &clickup.WebhookEventMessage{Event: clickup.EventTaskUpdated, TaskID: "t1"}},
+		{name: "Unknown task", event: &clickup.WebhookEventMessage{Event: clickup.EventTaskDeleted, TaskID: "t9"}},
+		{
+			name:  "Delete",
+			event: &clickup.WebhookEventMessage{Event: clickup.EventTaskDeleted, TaskID: "t1"},
+			want:  &Change{Type: Deleted, TaskID: "t1", Task: clickup.SingleTask{ID: "t1", Name: "Gone"}},
+		},
+		{name: "Deleted already", event: &clickup.WebhookEventMessage{Event: clickup.EventTaskDeleted, TaskID: "t1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.HandleWebhook(ctx, tt.event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HandleWebhook() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncer_errors(t *testing.T) {
+	ctx := context.Background()
+	if _, err := (&Syncer{ListIDs: []string{"l1"}}).Sync(ctx); !errors.Is(err, clickup.ErrValidation) {
+		t.Errorf("Sync() without a client error = %v, want ErrValidation", err)
+	}
+
+	doer := &fakeDoer{body: tasks("t1:l1:10")}
+	store := &MemoryStore{}
+	s := &Syncer{Client: newTestClient(doer), Store: store, ListIDs: []string{"l1", "l2"}}
+	if _, err := s.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	doer.status, doer.body = http.StatusInternalServerError, `{"err": "down", "ECODE": "X"}`
+	before, _ := store.Checkpoint(ctx, "l1")
+	result, err := s.Sync(ctx)
+	if err == nil || !strings.Contains(err.Error(), "list l1") {
+		t.Errorf("Sync() error = %v, want an error for list l1", err)
+	}
+	if len(result.Changes) != 0 {
+		t.Errorf("changes = %v, want none", summarize(result.Changes))
+	}
+	if after, _ := store.Checkpoint(ctx, "l1"); after != before {
+		t.Errorf("checkpoint moved from %+v to %+v after a failed sync", before, after)
+	}
+}
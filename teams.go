@@ -28,18 +28,28 @@ type TeamsResponse struct {
 }
 
 type TeamUser struct {
-	ID             int    `json:"id"`
-	Username       string `json:"username"`
-	Email          string `json:"email"`
-	Color          string `json:"color"`
-	ProfilePicture string `json:"profilePicture"`
-	Initials       string `json:"initials"`
-	Role           int    `json:"role"`
-	CustomRole     string `json:"custom_role"`
-	LastActive     string `json:"last_active"`
-	DateJoined     string `json:"date_joined"`
-	DateInvited    string `json:"date_invited"`
+	ID             int       `json:"id"`
+	Username       string    `json:"username"`
+	Email          string    `json:"email"`
+	Color          string    `json:"color"`
+	ProfilePicture string    `json:"profilePicture"`
+	Initials       string    `json:"initials"`
+	Role           int       `json:"role"`
+	CustomRole     string    `json:"custom_role"`
+	LastActive     Timestamp `json:"last_active"`
+	DateJoined     Timestamp `json:"date_joined"`
+	DateInvited    Timestamp `json:"date_invited"`
+}
+
+// Usernames returns the usernames of users.
+func Usernames(users []TeamUser) []string {
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user.Username)
+	}
+	return names
 }
+
 type TeamMember struct {
 	User      TeamUser `json:"user"`
 	InvitedBy TeamUser `json:"invited_by"`
@@ -71,7 +81,7 @@ type Group struct {
 	Userid      int        `json:"userid"`
 	Name        string     `json:"name"`
 	Handle      string     `json:"handle"`
-	DateCreated string     `json:"date_created"`
+	DateCreated Timestamp  `json:"date_created"`
 	Initials    string     `json:"initials"`
 	Members     []TeamUser `json:"members"`
 }
@@ -116,7 +126,7 @@ type CreateGroupResponse struct {
 	Userid      int        `json:"userid"`
 	Name        string     `json:"name"`
 	Handle      string     `json:"handle"`
-	DateCreated string     `json:"date_created"`
+	DateCreated Timestamp  `json:"date_created"`
 	Initials    string     `json:"initials"`
 	Members     []TeamUser `json:"members"`
 }
@@ -144,14 +154,21 @@ func (c *Client) CreateGroup(ctx context.Context, group CreateGroupRequest) (*Cr
 	return &newGroup, nil
 }
 
+type GroupMembersUpdate struct {
+	Add    []int `json:"add,omitempty"`
+	Remove []int `json:"rem,omitempty"`
+}
+
+// UpdateGroupRequest uses patch semantics.  Only explicitly set fields are sent to Clickup.
 type UpdateGroupRequest struct {
-	ID      string
-	Name    string `json:"name,omitempty"`
-	Handle  string `json:"handle,omitempty"`
-	Members []struct {
-		Add    []int `json:"add,omitempty"`
-		Remove []int `json:"rem,omitempty"`
-	} `json:"members,omitempty"`
+	ID      string              `json:"-"`
+	Name    OptionalString      `json:"name"`
+	Handle  OptionalString      `json:"handle"`
+	Members *GroupMembersUpdate `json:"members,omitempty"`
+}
+
+func (u UpdateGroupRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
 }
 
 type UpdateGroupResponse struct {
@@ -160,7 +177,7 @@ type UpdateGroupResponse struct {
 	Userid      int        `json:"userid"`
 	Name        string     `json:"name"`
 	Handle      string     `json:"handle"`
-	DateCreated string     `json:"date_created"`
+	DateCreated Timestamp  `json:"date_created"`
 	Initials    string     `json:"initials"`
 	Members     []TeamUser `json:"members"`
 }
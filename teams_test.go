@@ -245,7 +245,7 @@ func TestClient_UpdateGroup(t *testing.T) {
 				ctx: context.Background(),
 				group: UpdateGroupRequest{
 					ID:   "123",
-					Name: "test group",
+					Name: OptString("test group"),
 				},
 			},
 			wantErr: false,
@@ -260,7 +260,7 @@ func TestClient_UpdateGroup(t *testing.T) {
 				ctx: context.Background(),
 				group: UpdateGroupRequest{
 					ID:   "",
-					Name: "test group",
+					Name: OptString("test group"),
 				},
 			},
 			wantErr: true,
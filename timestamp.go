@@ -0,0 +1,88 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a date as Clickup sends it: unix milliseconds, either as a string or a number,
+// or null when there is no date.  The zero Timestamp means no date and is sent as null.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp returns a Timestamp for t.  Clickup only stores millisecond precision.
+func NewTimestamp(t time.Time) Timestamp {
+	if t.IsZero() {
+		return Timestamp{}
+	}
+	return Timestamp{Time: t.Truncate(time.Millisecond)}
+}
+
+// TimestampFromMillis returns a Timestamp for ms unix milliseconds.  Zero and negative values
+// are treated as no date.
+func TimestampFromMillis(ms int64) Timestamp {
+	if ms <= 0 {
+		return Timestamp{}
+	}
+	return Timestamp{Time: time.Unix(0, ms*int64(time.Millisecond)).UTC()}
+}
+
+// Millis returns t as unix milliseconds, or 0 if t is zero.
+func (t Timestamp) Millis() int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// Ptr returns a pointer to t, for optional request fields.
+func (t Timestamp) Ptr() *Timestamp {
+	return &t
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return nullJSON, nil
+	}
+	return []byte(strconv.FormatInt(t.Millis(), 10)), nil
+}
+
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, nullJSON) {
+		*t = Timestamp{}
+		return nil
+	}
+
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			*t = Timestamp{}
+			return nil
+		}
+	}
+
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		// Some endpoints send fractional milliseconds.
+		f, ferr := strconv.ParseFloat(s, 64)
+		if ferr != nil {
+			return fmt.Errorf("invalid timestamp %s: %w", b, err)
+		}
+		ms = int64(f)
+	}
+	*t = TimestampFromMillis(ms)
+	return nil
+}
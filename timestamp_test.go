@@ -0,0 +1,102 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	want := time.Date(2022, 6, 1, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "String milliseconds", input: `"1654086600000"`, want: want},
+		{name: "Number milliseconds", input: `1654086600000`, want: want},
+		{name: "Fractional milliseconds", input: `"1654086600000.5"`, want: want},
+		{name: "Null", input: `null`},
+		{name: "Empty string", input: `""`},
+		{name: "Zero", input: `"0"`},
+		{name: "Not a number", input: `"tomorrow"`, wantErr: true},
+		{name: "Wrong type", input: `true`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Timestamp
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) || got.IsZero() != tt.want.IsZero() {
+				t.Errorf("json.Unmarshal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	request := TaskRequest{
+		Name:    "task",
+		DueDate: NewTimestamp(time.Date(2022, 6, 1, 12, 30, 0, 999999, time.UTC)).Ptr(),
+	}
+	b, err := json.Marshal(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"name":"task","due_date":1654086600000}`; got != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+
+	b, err = json.Marshal(struct {
+		Date Timestamp `json:"date"`
+	}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"date":null}`; got != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestTimestamp_responseModels(t *testing.T) {
+	var task SingleTask
+	input := `{"id": "a", "date_created": "1654086600000", "due_date": null, "start_date": 1654041600000}`
+	if err := json.Unmarshal([]byte(input), &task); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := task.DateCreated.Millis(), int64(1654086600000); got != want {
+		t.Errorf("DateCreated.Millis() = %d, want %d", got, want)
+	}
+	if !task.DueDate.IsZero() {
+		t.Errorf("DueDate = %v, want zero", task.DueDate)
+	}
+	if got, want := task.StartDate.Time, time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("StartDate = %v, want %v", got, want)
+	}
+}
+
+func TestQueryParamsFor_dates(t *testing.T) {
+	opts := &TaskQueryOptions{
+		DueDateGreaterThan:  time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC),
+		DateUpdatedLessThan: time.UnixMilli(1000),
+	}
+	values := queryParamsFor(opts)
+	if got, want := values.Get("due_date_gt"), "1654041600000"; got != want {
+		t.Errorf("due_date_gt = %q, want %q", got, want)
+	}
+	if got, want := values.Get("date_updated_lt"), "1000"; got != want {
+		t.Errorf("date_updated_lt = %q, want %q", got, want)
+	}
+	if values.Has("due_date_lt") || values.Has("date_created_gt") {
+		t.Errorf("zero dates should not be sent: %v", values.Encode())
+	}
+}
@@ -0,0 +1,424 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterOp compares a field of a task to the values of a FilterCondition.
+type FilterOp string
+
+const (
+	FilterEQ     FilterOp = "EQ"
+	FilterNot    FilterOp = "NOT"
+	FilterAny    FilterOp = "ANY"
+	FilterAll    FilterOp = "ALL"
+	FilterGT     FilterOp = "GT"
+	FilterLT     FilterOp = "LT"
+	FilterIsSet  FilterOp = "IS SET"
+	FilterNotSet FilterOp = "NOT SET"
+)
+
+// FilterJoin combines the expressions of a FilterGroup.
+type FilterJoin string
+
+const (
+	FilterAnd FilterJoin = "AND"
+	FilterOr  FilterJoin = "OR"
+)
+
+// Fields of a task that views can filter on.  Custom fields are filtered with the field
+// returned by CustomFieldFilterField.
+const (
+	FilterFieldStatus   = "status"
+	FilterFieldAssignee = "assignee"
+	FilterFieldTag      = "tag"
+	FilterFieldDueDate  = "dueDate"
+	FilterFieldPriority = "priority"
+
+	customFieldFilterPrefix = "cf_"
+)
+
+// CustomFieldFilterField returns the filter field of the custom field with fieldID.
+func CustomFieldFilterField(fieldID string) string {
+	return customFieldFilterPrefix + fieldID
+}
+
+// FilterExpr is a FilterCondition or a FilterGroup.
+type FilterExpr interface {
+	// Validate checks that the expression can be used as the filters of a view.
+	Validate() error
+	// String describes the expression, such as `status ANY "open", "review" AND priority EQ urgent`.
+	String() string
+
+	isFilterExpr()
+}
+
+// FilterCondition compares a single field of tasks to Values using Op.
+type FilterCondition struct {
+	Field  string
+	Op     FilterOp
+	Values []interface{}
+}
+
+// FilterGroup joins expressions with AND or OR.  Clickup views support at most two levels: a group of
+// conditions and groups of conditions.
+type FilterGroup struct {
+	Join  FilterJoin
+	Exprs []FilterExpr
+}
+
+func (FilterCondition) isFilterExpr() {}
+func (FilterGroup) isFilterExpr()     {}
+
+// And joins exprs so that tasks must match all of them.
+func And(exprs ...FilterExpr) FilterGroup {
+	return FilterGroup{Join: FilterAnd, Exprs: exprs}
+}
+
+// Or joins exprs so that tasks must match any of them.
+func Or(exprs ...FilterExpr) FilterGroup {
+	return FilterGroup{Join: FilterOr, Exprs: exprs}
+}
+
+// FilterStatus filters tasks by the names of their status.
+func FilterStatus(op FilterOp, statuses ...string) FilterCondition {
+	return newFilterCondition(FilterFieldStatus, op, stringValues(statuses))
+}
+
+// FilterAssignee filters tasks by the ids of the users assigned to them.
+func FilterAssignee(op FilterOp, userIDs ...int) FilterCondition {
+	values := make([]interface{}, 0, len(userIDs))
+	for _, id := range userIDs {
+		values = append(values, id)
+	}
+	return newFilterCondition(FilterFieldAssignee, op, values)
+}
+
+// FilterTag filters tasks by the names of their tags.
+func FilterTag(op FilterOp, tags ...string) FilterCondition {
+	return newFilterCondition(FilterFieldTag, op, stringValues(tags))
+}
+
+// FilterDueDate filters tasks by due date.  Use the zero time with FilterIsSet and FilterNotSet.
+func FilterDueDate(op FilterOp, date time.Time) FilterCondition {
+	var values []interface{}
+	if !date.IsZero() {
+		values = append(values, date.UnixNano()/int64(time.Millisecond))
+	}
+	return newFilterCondition(FilterFieldDueDate, op, values)
+}
+
+// FilterPriority filters tasks by priority, from 1 (urgent) to 4 (low).
+func FilterPriority(op FilterOp, priorities ...int) FilterCondition {
+	values := make([]interface{}, 0, len(priorities))
+	for _, priority := range priorities {
+		values = append(values, priority)
+	}
+	return newFilterCondition(FilterFieldPriority, op, values)
+}
+
+// FilterCustomField filters tasks by the value of the custom field with fieldID.
+func FilterCustomField(fieldID string, op FilterOp, values ...interface{}) FilterCondition {
+	return newFilterCondition(CustomFieldFilterField(fieldID), op, values)
+}
+
+func newFilterCondition(field string, op FilterOp, values []interface{}) FilterCondition {
+	if values == nil {
+		values = []interface{}{}
+	}
+	return FilterCondition{Field: field, Op: op, Values: values}
+}
+
+func stringValues(strs []string) []interface{} {
+	values := make([]interface{}, 0, len(strs))
+	for _, s := range strs {
+		values = append(values, s)
+	}
+	return values
+}
+
+// Validate checks that Op can be used with Field and has the right number of values.
+func (c FilterCondition) Validate() error {
+	if c.Field == "" {
+		return fmt.Errorf("filter condition must have a field: %w", ErrValidation)
+	}
+
+	custom := strings.HasPrefix(c.Field, customFieldFilterPrefix)
+	switch c.Op {
+	case FilterIsSet, FilterNotSet:
+		if len(c.Values) != 0 {
+			return fmt.Errorf("%s filter on %s takes no values: %w", c.Op, c.Field, ErrValidation)
+		}
+	case FilterGT, FilterLT:
+		if c.Field != FilterFieldDueDate && c.Field != FilterFieldPriority && !custom {
+			return fmt.Errorf("%s filter cannot be used with %s: %w", c.Op, c.Field, ErrValidation)
+		}
+		if len(c.Values) != 1 {
+			return fmt.Errorf("%s filter on %s takes a single value: %w", c.Op, c.Field, ErrValidation)
+		}
+	case FilterAll:
+		if c.Field != FilterFieldAssignee && c.Field != FilterFieldTag && !custom {
+			return fmt.Errorf("%s filter cannot be used with %s: %w", c.Op, c.Field, ErrValidation)
+		}
+		fallthrough
+	case FilterEQ, FilterNot, FilterAny:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%s filter on %s needs a value: %w", c.Op, c.Field, ErrValidation)
+		}
+	default:
+		return fmt.Errorf("unknown filter operator %q: %w", c.Op, ErrValidation)
+	}
+
+	return nil
+}
+
+func (c FilterCondition) String() string {
+	if len(c.Values) == 0 {
+		return c.Field + " " + string(c.Op)
+	}
+	values := make([]string, 0, len(c.Values))
+	for _, value := range c.Values {
+		values = append(values, formatFilterValue(c.Field, value))
+	}
+	return c.Field + " " + string(c.Op) + " " + strings.Join(values, ", ")
+}
+
+var priorityNames = map[int64]string{1: "urgent", 2: "high", 3: "normal", 4: "low"}
+
+func formatFilterValue(field string, value interface{}) string {
+	var n int64
+	var isNumber bool
+	switch v := value.(type) {
+	case int:
+		n, isNumber = int64(v), true
+	case int64:
+		n, isNumber = v, true
+	case float64:
+		n, isNumber = int64(v), float64(int64(v)) == v
+	case string:
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || (field != FilterFieldDueDate && field != FilterFieldPriority) {
+			return strconv.Quote(v)
+		}
+		n, isNumber = parsed, true
+	}
+
+	switch {
+	case isNumber && field == FilterFieldDueDate:
+		return time.Unix(0, n*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+	case isNumber && field == FilterFieldPriority && priorityNames[n] != "":
+		return priorityNames[n]
+	case isNumber:
+		return strconv.FormatInt(n, 10)
+	}
+	return fmt.Sprint(value)
+}
+
+// Validate checks the join, every expression of the group and that groups are nested no more than
+// the two levels a view supports.
+func (g FilterGroup) Validate() error {
+	return g.validate(0)
+}
+
+func (g FilterGroup) validate(depth int) error {
+	if g.Join != FilterAnd && g.Join != FilterOr {
+		return fmt.Errorf("unknown filter join %q: %w", g.Join, ErrValidation)
+	}
+	for _, expr := range g.Exprs {
+		expr, err := filterNode(expr)
+		if err != nil {
+			return err
+		}
+		if group, ok := expr.(FilterGroup); ok {
+			if depth > 0 {
+				return fmt.Errorf("view filters support groups nested only one level deep: %w", ErrValidation)
+			}
+			if err := group.validate(depth + 1); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := expr.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterNode returns expr as a FilterCondition or FilterGroup value, dereferencing pointers to either.
+func filterNode(expr FilterExpr) (FilterExpr, error) {
+	switch e := expr.(type) {
+	case FilterCondition, FilterGroup:
+		return e, nil
+	case *FilterCondition:
+		if e != nil {
+			return *e, nil
+		}
+	case *FilterGroup:
+		if e != nil {
+			return *e, nil
+		}
+	default:
+		if expr != nil {
+			return nil, fmt.Errorf("unsupported filter expression %T: %w", expr, ErrValidation)
+		}
+	}
+	return nil, fmt.Errorf("filter group contains a nil expression: %w", ErrValidation)
+}
+
+func (g FilterGroup) String() string {
+	parts := make([]string, 0, len(g.Exprs))
+	for _, expr := range g.Exprs {
+		if node, err := filterNode(expr); err == nil {
+			expr = node
+		}
+		if group, ok := expr.(FilterGroup); ok && len(group.Exprs) > 1 {
+			parts = append(parts, "("+group.String()+")")
+			continue
+		}
+		parts = append(parts, fmt.Sprint(expr))
+	}
+	return strings.Join(parts, " "+string(g.Join)+" ")
+}
+
+// SetExpression replaces the filter fields of f with expr.  A nil expr removes all filters.  Search
+// and the other settings of f are kept.
+func (f *ViewFilters) SetExpression(expr FilterExpr) error {
+	f.Op = string(FilterAnd)
+	f.Fields = []ViewFilterField{}
+	f.FilterGroups = nil
+	f.FilterGroupOps = nil
+	if expr == nil {
+		return nil
+	}
+	expr, err := filterNode(expr)
+	if err != nil {
+		return err
+	}
+	if err := expr.Validate(); err != nil {
+		return err
+	}
+
+	root, ok := expr.(FilterGroup)
+	if !ok {
+		root = And(expr)
+	}
+	f.Op = string(root.Join)
+
+	groups := make([]FilterGroup, 0, len(root.Exprs))
+	nested := false
+	for _, e := range root.Exprs {
+		e, _ = filterNode(e)
+		group, ok := e.(FilterGroup)
+		if ok {
+			nested = true
+		} else {
+			group = And(e)
+		}
+		groups = append(groups, group)
+	}
+
+	for _, group := range groups {
+		indexes := make([]int, 0, len(group.Exprs))
+		for _, e := range group.Exprs {
+			e, _ = filterNode(e)
+			condition, ok := e.(FilterCondition)
+			if !ok {
+				return fmt.Errorf("view filters support groups nested only one level deep: %w", ErrValidation)
+			}
+			indexes = append(indexes, len(f.Fields))
+			f.Fields = append(f.Fields, viewFilterField(condition, len(f.Fields)))
+		}
+		if nested {
+			f.FilterGroups = append(f.FilterGroups, indexes)
+			f.FilterGroupOps = append(f.FilterGroupOps, string(group.Join))
+		}
+	}
+
+	return nil
+}
+
+func viewFilterField(condition FilterCondition, idx int) ViewFilterField {
+	values := condition.Values
+	if values == nil {
+		values = []interface{}{}
+	}
+	return ViewFilterField{
+		Field:  condition.Field,
+		Op:     string(condition.Op),
+		Idx:    idx,
+		Values: values,
+	}
+}
+
+// Expression returns the filter fields of f as a FilterGroup, or nil if f has no filter fields.
+// Filters made in Clickup can use operators this package does not model; the expression is still
+// returned for display along with the validation error.
+func (f ViewFilters) Expression() (FilterExpr, error) {
+	if len(f.Fields) == 0 {
+		return nil, nil
+	}
+
+	join := FilterJoin(strings.ToUpper(f.Op))
+	if join == "" {
+		join = FilterAnd
+	}
+	root := FilterGroup{Join: join}
+
+	if len(f.FilterGroups) == 0 {
+		for _, field := range f.Fields {
+			root.Exprs = append(root.Exprs, filterCondition(field))
+		}
+		return root, root.Validate()
+	}
+
+	grouped := make(map[int]bool, len(f.Fields))
+	for i, indexes := range f.FilterGroups {
+		group := FilterGroup{Join: FilterAnd}
+		if i < len(f.FilterGroupOps) && f.FilterGroupOps[i] != "" {
+			group.Join = FilterJoin(strings.ToUpper(f.FilterGroupOps[i]))
+		}
+		for _, index := range indexes {
+			if index < 0 || index >= len(f.Fields) {
+				return nil, fmt.Errorf("filter group refers to missing field %d: %w", index, ErrValidation)
+			}
+			grouped[index] = true
+			group.Exprs = append(group.Exprs, filterCondition(f.Fields[index]))
+		}
+		switch len(group.Exprs) {
+		case 0:
+		case 1:
+			root.Exprs = append(root.Exprs, group.Exprs[0])
+		default:
+			root.Exprs = append(root.Exprs, group)
+		}
+	}
+	for i, field := range f.Fields {
+		if !grouped[i] {
+			root.Exprs = append(root.Exprs, filterCondition(field))
+		}
+	}
+
+	return root, root.Validate()
+}
+
+func filterCondition(field ViewFilterField) FilterCondition {
+	values := field.Values
+	if values == nil {
+		values = []interface{}{}
+	}
+	return FilterCondition{
+		Field:  field.Field,
+		Op:     FilterOp(strings.ToUpper(field.Op)),
+		Values: values,
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2022, John Moore
+// All rights reserved.
+
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package clickup
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestViewFilters_SetExpression(t *testing.T) {
+	due := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	status := FilterStatus(FilterAny, "open", "review")
+	priority := FilterPriority(FilterEQ, 1)
+	inner := And(FilterAssignee(FilterEQ, 1), FilterDueDate(FilterLT, due))
+	outer := Or(&inner, &priority, FilterTag(FilterNotSet))
+	deep := Or(And(FilterTag(FilterIsSet)))
+	tests := []struct {
+		name       string
+		expr       FilterExpr
+		wantJSON   string
+		wantString string
+		wantErr    bool
+	}{
+		{
+			name:       "Single condition",
+			expr:       FilterStatus(FilterAny, "open", "review"),
+			wantJSON:   `{"op":"AND","fields":[{"field":"status","op":"ANY","idx":0,"values":["open","review"]}],"search":"","search_custom_fields":false,"search_description":false,"search_name":false,"show_closed":false}`,
+			wantString: `status ANY "open", "review"`,
+		},
+		{
+			name: "Or of and groups",
+			expr: Or(
+				And(FilterAssignee(FilterEQ, 1), FilterDueDate(FilterLT, due)),
+				FilterPriority(FilterEQ, 1),
+				FilterTag(FilterNotSet),
+			),
+			wantJSON:   `{"op":"OR","fields":[{"field":"assignee","op":"EQ","idx":0,"values":[1]},{"field":"dueDate","op":"LT","idx":1,"values":[1654041600000]},{"field":"priority","op":"EQ","idx":2,"values":[1]},{"field":"tag","op":"NOT SET","idx":3,"values":[]}],"filter_groups":[[0,1],[2],[3]],"filter_group_ops":["AND","AND","AND"],"search":"","search_custom_fields":false,"search_description":false,"search_name":false,"show_closed":false}`,
+			wantString: `(assignee EQ 1 AND dueDate LT 2022-06-01T00:00:00Z) OR priority EQ urgent OR tag NOT SET`,
+		},
+		{
+			name:       "Pointer condition",
+			expr:       &status,
+			wantJSON:   `{"op":"AND","fields":[{"field":"status","op":"ANY","idx":0,"values":["open","review"]}],"search":"","search_custom_fields":false,"search_description":false,"search_name":false,"show_closed":false}`,
+			wantString: `status ANY "open", "review"`,
+		},
+		{
+			name:       "Pointer groups",
+			expr:       &outer,
+			wantJSON:   `{"op":"OR","fields":[{"field":"assignee","op":"EQ","idx":0,"values":[1]},{"field":"dueDate","op":"LT","idx":1,"values":[1654041600000]},{"field":"priority","op":"EQ","idx":2,"values":[1]},{"field":"tag","op":"NOT SET","idx":3,"values":[]}],"filter_groups":[[0,1],[2],[3]],"filter_group_ops":["AND","AND","AND"],"search":"","search_custom_fields":false,"search_description":false,"search_name":false,"show_closed":false}`,
+			wantString: `(assignee EQ 1 AND dueDate LT 2022-06-01T00:00:00Z) OR priority EQ urgent OR tag NOT SET`,
+		},
+		{
+			name:    "Fail pointer groups nested too deep",
+			expr:    And(&deep),
+			wantErr: true,
+		},
+		{
+			name:    "Fail nil pointer condition",
+			expr:    And(FilterTag(FilterIsSet), (*FilterCondition)(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "Fail groups nested too deep",
+			expr:    And(Or(And(FilterTag(FilterIsSet)))),
+			wantErr: true,
+		},
+		{
+			name:    "Fail greater than on status",
+			expr:    FilterStatus(FilterGT, "open"),
+			wantErr: true,
+		},
+		{
+			name:    "Fail all on priority",
+			expr:    FilterPriority(FilterAll, 1, 2),
+			wantErr: true,
+		},
+		{
+			name:    "Fail is set with values",
+			expr:    FilterCustomField("field-id", FilterIsSet, "x"),
+			wantErr: true,
+		},
+		{
+			name:    "Fail equal without values",
+			expr:    FilterTag(FilterEQ),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filters ViewFilters
+			err := filters.SetExpression(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ViewFilters.SetExpression() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got := mustMarshal(t, filters); got != tt.wantJSON {
+				t.Errorf("ViewFilters.SetExpression() = %s, want %s", got, tt.wantJSON)
+			}
+
+			// read back from the view JSON
+			var decoded ViewFilters
+			if err := json.Unmarshal([]byte(tt.wantJSON), &decoded); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			expr, err := decoded.Expression()
+			if err != nil {
+				t.Fatalf("ViewFilters.Expression() error = %v", err)
+			}
+			if got := expr.String(); got != tt.wantString {
+				t.Errorf("ViewFilters.Expression() = %s, want %s", got, tt.wantString)
+			}
+			var again ViewFilters
+			if err := again.SetExpression(expr); err != nil {
+				t.Fatalf("ViewFilters.SetExpression() round trip error = %v", err)
+			}
+			if got := mustMarshal(t, again); got != tt.wantJSON {
+				t.Errorf("ViewFilters round trip = %s, want %s", got, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestViewFilters_Expression(t *testing.T) {
+	tests := []struct {
+		name       string
+		filters    ViewFilters
+		wantString string
+		wantNil    bool
+		wantErr    bool
+	}{
+		{
+			name:    "No fields",
+			wantNil: true,
+		},
+		{
+			name: "Ungrouped fields are kept",
+			filters: ViewFilters{
+				Op: "and",
+				Fields: []ViewFilterField{
+					{Field: "tag", Op: "ANY", Values: []interface{}{"a", "b"}},
+					{Field: "cf_1", Op: "GT", Values: []interface{}{2.5}},
+					{Field: "priority", Op: "IS SET"},
+				},
+				FilterGroups:   [][]int{{0, 1}},
+				FilterGroupOps: []string{"or"},
+			},
+			wantString: `(tag ANY "a", "b" OR cf_1 GT 2.5) AND priority IS SET`,
+		},
+		{
+			name: "Unknown operator is described and reported",
+			filters: ViewFilters{
+				Op:     "AND",
+				Fields: []ViewFilterField{{Field: "dueDate", Op: "today"}},
+			},
+			wantString: "dueDate TODAY",
+			wantErr:    true,
+		},
+		{
+			name: "Missing field index",
+			filters: ViewFilters{
+				Fields:       []ViewFilterField{{Field: "tag", Op: "IS SET"}},
+				FilterGroups: [][]int{{3}},
+			},
+			wantNil: true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := tt.filters.Expression()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ViewFilters.Expression() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (expr == nil) != tt.wantNil {
+				t.Fatalf("ViewFilters.Expression() = %v, wantNil %v", expr, tt.wantNil)
+			}
+			if expr != nil && expr.String() != tt.wantString {
+				t.Errorf("ViewFilters.Expression() = %s, want %s", expr, tt.wantString)
+			}
+		})
+	}
+}
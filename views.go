@@ -7,7 +7,9 @@
 package clickup
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,64 +18,87 @@ import (
 )
 
 type SingleView struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	Type   string `json:"type"`
-	Parent struct {
-		ID   string `json:"id"`
-		Type int    `json:"type"`
-	} `json:"parent"`
-	Grouping struct {
-		Field     string   `json:"field"`
-		Dir       int      `json:"dir"`
-		Collapsed []string `json:"collapsed"`
-		Ignore    bool     `json:"ignore"`
-	} `json:"grouping"`
-	Filters struct {
-		Op     string `json:"op"`
-		Fields []struct {
-			Field string `json:"field"`
-			Op    string `json:"op"`
-			Idx   int    `json:"idx"`
-		} `json:"fields"`
-		Search             string `json:"search"`
-		SearchCustomFields bool   `json:"search_custom_fields"`
-		SearchDescription  bool   `json:"search_description"`
-		SearchName         bool   `json:"search_name"`
-		ShowClosed         bool   `json:"show_closed"`
-	} `json:"filters"`
-	Columns struct {
-		Fields []struct {
-			Field  string `json:"field"`
-			Idx    int    `json:"idx"`
-			Width  int    `json:"width"`
-			Hidden bool   `json:"hidden"`
-		} `json:"fields"`
-	} `json:"columns"`
-	TeamSidebar struct {
-		AssignedComments bool `json:"assigned_comments"`
-		UnassignedTasks  bool `json:"unassigned_tasks"`
-	} `json:"team_sidebar"`
-	Settings struct {
-		ShowTaskLocations      bool `json:"show_task_locations"`
-		ShowSubtasks           int  `json:"show_subtasks"`
-		ShowSubtaskParentNames bool `json:"show_subtask_parent_names"`
-		ShowClosedSubtasks     bool `json:"show_closed_subtasks"`
-		ShowAssignees          bool `json:"show_assignees"`
-		ShowImages             bool `json:"show_images"`
-		ShowTimer              bool `json:"show_timer"`
-		MeComments             bool `json:"me_comments"`
-		MeSubtasks             bool `json:"me_subtasks"`
-		MeChecklists           bool `json:"me_checklists"`
-		ShowEmptyStatuses      bool `json:"show_empty_statuses"`
-		AutoWrap               bool `json:"auto_wrap"`
-		TimeInStatusView       int  `json:"time_in_status_view"`
-	} `json:"settings"`
-	DateCreated string `json:"date_created"`
-	Creator     int    `json:"creator"`
-	Visibility  string `json:"visibility"`
-	Protected   bool   `json:"protected"`
-	Orderindex  int    `json:"-"`
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Type        string          `json:"type"`
+	Parent      ViewParent      `json:"parent"`
+	Grouping    ViewGrouping    `json:"grouping"`
+	Filters     ViewFilters     `json:"filters"`
+	Columns     ViewColumns     `json:"columns"`
+	TeamSidebar ViewTeamSidebar `json:"team_sidebar"`
+	Settings    ViewSettings    `json:"settings"`
+	DateCreated Timestamp       `json:"date_created"`
+	Creator     int             `json:"creator"`
+	Visibility  string          `json:"visibility"`
+	Protected   bool            `json:"protected"`
+	Orderindex  int             `json:"-"`
+}
+
+// ViewParent is the team, space, folder or list a view belongs to.  Type is Clickup's numeric
+// parent type, see ViewListType.ParentType.
+type ViewParent struct {
+	ID   string `json:"id"`
+	Type int    `json:"type"`
+}
+
+type ViewGrouping struct {
+	Field     string   `json:"field"`
+	Dir       int      `json:"dir"`
+	Collapsed []string `json:"collapsed"`
+	Ignore    bool     `json:"ignore"`
+}
+
+type ViewFilterField struct {
+	Field  string        `json:"field"`
+	Op     string        `json:"op"`
+	Idx    int           `json:"idx"`
+	Values []interface{} `json:"values"`
+}
+
+// ViewFilters are the filters of a view.  Filters can be built and read as a FilterExpr with
+// SetExpression and Expression.
+type ViewFilters struct {
+	Op                 string            `json:"op"`
+	Fields             []ViewFilterField `json:"fields"`
+	FilterGroups       [][]int           `json:"filter_groups,omitempty"`    // indexes into Fields
+	FilterGroupOps     []string          `json:"filter_group_ops,omitempty"` // how the fields of each group are joined
+	Search             string            `json:"search"`
+	SearchCustomFields bool              `json:"search_custom_fields"`
+	SearchDescription  bool              `json:"search_description"`
+	SearchName         bool              `json:"search_name"`
+	ShowClosed         bool              `json:"show_closed"`
+}
+
+type ViewColumnField struct {
+	Field  string `json:"field"`
+	Idx    int    `json:"idx"`
+	Width  int    `json:"width"`
+	Hidden bool   `json:"hidden"`
+}
+
+type ViewColumns struct {
+	Fields []ViewColumnField `json:"fields"`
+}
+
+type ViewTeamSidebar struct {
+	AssignedComments bool `json:"assigned_comments"`
+	UnassignedTasks  bool `json:"unassigned_tasks"`
+}
+
+type ViewSettings struct {
+	ShowTaskLocations      bool `json:"show_task_locations"`
+	ShowSubtasks           int  `json:"show_subtasks"`
+	ShowSubtaskParentNames bool `json:"show_subtask_parent_names"`
+	ShowClosedSubtasks     bool `json:"show_closed_subtasks"`
+	ShowAssignees          bool `json:"show_assignees"`
+	ShowImages             bool `json:"show_images"`
+	ShowTimer              bool `json:"show_timer"`
+	MeComments             bool `json:"me_comments"`
+	MeSubtasks             bool `json:"me_subtasks"`
+	MeChecklists           bool `json:"me_checklists"`
+	ShowEmptyStatuses      bool `json:"show_empty_statuses"`
+	AutoWrap               bool `json:"auto_wrap"`
+	TimeInStatusView       int  `json:"time_in_status_view"`
 }
 
 type GetViewResponse struct {
@@ -126,6 +151,23 @@ func (v ViewListType) String() string {
 	}
 }
 
+// ParentType returns the numeric type Clickup uses for a view parent of this ViewListType, or 0 if
+// v is unknown.
+func (v ViewListType) ParentType() int {
+	switch v {
+	case TypeTeam:
+		return 7
+	case TypeSpace:
+		return 4
+	case TypeFolder:
+		return 5
+	case TypeList:
+		return 6
+	default:
+		return 0
+	}
+}
+
 // ViewsFor uses viewListType to return views for a team, space, forlder, or list.  See ViewListType.
 // id represents the id of the corresponding ViewListType.
 func (c *Client) ViewsFor(ctx context.Context, viewListType ViewListType, id string) (*GetViewsResponse, error) {
@@ -145,6 +187,124 @@ func (c *Client) ViewsFor(ctx context.Context, viewListType ViewListType, id str
 	return &views, nil
 }
 
+type ViewType string
+
+const (
+	ViewTypeList         ViewType = "list"
+	ViewTypeBoard        ViewType = "board"
+	ViewTypeCalendar     ViewType = "calendar"
+	ViewTypeGantt        ViewType = "gantt"
+	ViewTypeTable        ViewType = "table"
+	ViewTypeTimeline     ViewType = "timeline"
+	ViewTypeWorkload     ViewType = "workload"
+	ViewTypeActivity     ViewType = "activity"
+	ViewTypeMap          ViewType = "map"
+	ViewTypeConversation ViewType = "conversation"
+)
+
+// Valid reports whether v is a view type that can be created through the API.
+func (v ViewType) Valid() bool {
+	switch v {
+	case ViewTypeList, ViewTypeBoard, ViewTypeCalendar, ViewTypeGantt, ViewTypeTable, ViewTypeTimeline,
+		ViewTypeWorkload, ViewTypeActivity, ViewTypeMap, ViewTypeConversation:
+		return true
+	}
+	return false
+}
+
+// CreateViewRequest describes a new view on the team, space, folder or list with ParentID.
+// Grouping, Filters, Columns, TeamSidebar and Settings are left to Clickup's defaults when nil.
+type CreateViewRequest struct {
+	ParentType  ViewListType     `json:"-"`
+	ParentID    string           `json:"-"`
+	Name        string           `json:"name"`
+	Type        ViewType         `json:"type"`
+	Grouping    *ViewGrouping    `json:"grouping,omitempty"`
+	Filters     *ViewFilters     `json:"filters,omitempty"`
+	Columns     *ViewColumns     `json:"columns,omitempty"`
+	TeamSidebar *ViewTeamSidebar `json:"team_sidebar,omitempty"`
+	Settings    *ViewSettings    `json:"settings,omitempty"`
+}
+
+// CreateView adds a new view to the team, space, folder or list with view.ParentID.
+func (c *Client) CreateView(ctx context.Context, view CreateViewRequest) (*GetViewResponse, error) {
+	parentType := view.ParentType.String()
+	if parentType == "UNKNOWN_VIEW_LIST_TYPE" {
+		return nil, fmt.Errorf("invalid ViewListType %d: %w", view.ParentType, ErrValidation)
+	}
+	if view.ParentID == "" {
+		return nil, fmt.Errorf("must provide a %s id to create a view: %w", parentType, ErrValidation)
+	}
+	if view.Name == "" {
+		return nil, fmt.Errorf("must provide a name for a new view: %w", ErrValidation)
+	}
+	if !view.Type.Valid() {
+		return nil, fmt.Errorf("invalid view type %q: %w", view.Type, ErrValidation)
+	}
+
+	b, err := json.Marshal(view)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize new view: %w", err)
+	}
+	buf := bytes.NewBuffer(b)
+
+	endpoint := fmt.Sprintf("/%s/%s/view", parentType, view.ParentID)
+
+	var newView GetViewResponse
+
+	if err := c.call(ctx, http.MethodPost, endpoint, buf, &newView); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &newView, nil
+}
+
+// UpdateViewRequest uses patch semantics.  Only explicitly set fields are sent to Clickup.
+type UpdateViewRequest struct {
+	ID          string           `json:"-"`
+	Name        OptionalString   `json:"name"`
+	Type        ViewType         `json:"type,omitempty"`
+	Parent      *ViewParent      `json:"parent,omitempty"`
+	Grouping    *ViewGrouping    `json:"grouping,omitempty"`
+	Filters     *ViewFilters     `json:"filters,omitempty"`
+	Columns     *ViewColumns     `json:"columns,omitempty"`
+	TeamSidebar *ViewTeamSidebar `json:"team_sidebar,omitempty"`
+	Settings    *ViewSettings    `json:"settings,omitempty"`
+}
+
+func (u UpdateViewRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
+}
+
+// UpdateView changes the existing view with view.ID.
+func (c *Client) UpdateView(ctx context.Context, view UpdateViewRequest) (*GetViewResponse, error) {
+	if view.ID == "" {
+		return nil, fmt.Errorf("must provide a view id to update a view: %w", ErrValidation)
+	}
+	if view.Type != "" && !view.Type.Valid() {
+		return nil, fmt.Errorf("invalid view type %q: %w", view.Type, ErrValidation)
+	}
+	if view.Parent != nil && (view.Parent.ID == "" || view.Parent.Type == 0) {
+		return nil, fmt.Errorf("must provide a parent id and type to move a view: %w", ErrValidation)
+	}
+
+	b, err := json.Marshal(view)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize view: %w", err)
+	}
+	buf := bytes.NewBuffer(b)
+
+	endpoint := fmt.Sprintf("/view/%s", view.ID)
+
+	var updatedView GetViewResponse
+
+	if err := c.call(ctx, http.MethodPut, endpoint, buf, &updatedView); err != nil {
+		return nil, fmt.Errorf("failed to make clickup request: %w", err)
+	}
+
+	return &updatedView, nil
+}
+
 // DeleteView removes an existing view with viewID.
 func (c *Client) DeleteView(ctx context.Context, viewID string) error {
 	if viewID == "" {
@@ -170,3 +330,24 @@ func (c *Client) TasksForView(ctx context.Context, viewID string, page int) (*Ta
 
 	return &tasks, nil
 }
+
+// AllTasksForView requests every page of TasksForView for viewID and returns the combined tasks.
+// Requests that exceed the Clickup rate limit are retried once the limit resets.
+func (c *Client) AllTasksForView(ctx context.Context, viewID string) ([]SingleTask, error) {
+	var tasks []SingleTask
+	for page := 0; ; page++ {
+		var res *TasksForViewResponse
+		err := retryOnRateLimit(ctx, func() error {
+			var err error
+			res, err = c.TasksForView(ctx, viewID, page)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, res.Tasks...)
+		if res.LastPage || len(res.Tasks) == 0 {
+			return tasks, nil
+		}
+	}
+}
@@ -287,3 +287,164 @@ func TestClient_DeleteView(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_CreateView(t *testing.T) {
+	tests := []struct {
+		name     string
+		view     CreateViewRequest
+		wantPath string
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name: "Success board view on list",
+			view: CreateViewRequest{
+				ParentType: TypeList,
+				ParentID:   "list-id",
+				Name:       "Board",
+				Type:       ViewTypeBoard,
+				Grouping:   &ViewGrouping{Field: "status"},
+			},
+			wantPath: "/list/list-id/view",
+			wantBody: `{"name":"Board","type":"board","grouping":{"field":"status","dir":0,"collapsed":null,"ignore":false}}`,
+		},
+		{
+			name:     "Success team view",
+			view:     CreateViewRequest{ParentType: TypeTeam, ParentID: "team-id", Name: "Everything", Type: ViewTypeGantt},
+			wantPath: "/team/team-id/view",
+			wantBody: `{"name":"Everything","type":"gantt"}`,
+		},
+		{
+			name:    "Fail invalid view type",
+			view:    CreateViewRequest{ParentType: TypeList, ParentID: "list-id", Name: "Kanban", Type: "kanban"},
+			wantErr: true,
+		},
+		{
+			name:    "Fail invalid parent type",
+			view:    CreateViewRequest{ParentType: ViewListType(9), ParentID: "id", Name: "Board", Type: ViewTypeBoard},
+			wantErr: true,
+		},
+		{
+			name:    "Fail missing parent id",
+			view:    CreateViewRequest{ParentType: TypeSpace, Name: "Board", Type: ViewTypeBoard},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					b, _ := ioutil.ReadAll(req.Body)
+					if req.URL.Path != tt.wantPath || string(b) != tt.wantBody {
+						t.Errorf("request = %s %s, want %s %s", req.URL.Path, b, tt.wantPath, tt.wantBody)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"view": {"id": "view-id", "type": "board"}}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			got, err := c.CreateView(context.Background(), tt.view)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.CreateView() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.View.ID != "view-id" {
+				t.Errorf("Client.CreateView() id = %s, want view-id", got.View.ID)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateView(t *testing.T) {
+	tests := []struct {
+		name     string
+		view     UpdateViewRequest
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "Success rename only",
+			view:     UpdateViewRequest{ID: "view-id", Name: OptString("Renamed")},
+			wantBody: `{"name":"Renamed"}`,
+		},
+		{
+			name: "Success move and change type",
+			view: UpdateViewRequest{
+				ID:     "view-id",
+				Type:   ViewTypeTable,
+				Parent: &ViewParent{ID: "folder-id", Type: TypeFolder.ParentType()},
+			},
+			wantBody: `{"type":"table","parent":{"id":"folder-id","type":5}}`,
+		},
+		{
+			name:    "Fail invalid type",
+			view:    UpdateViewRequest{ID: "view-id", Type: "spreadsheet"},
+			wantErr: true,
+		},
+		{
+			name:    "Fail incomplete parent",
+			view:    UpdateViewRequest{ID: "view-id", Parent: &ViewParent{ID: "folder-id"}},
+			wantErr: true,
+		},
+		{
+			name:    "Fail missing id",
+			view:    UpdateViewRequest{Name: OptString("Renamed")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+					b, _ := ioutil.ReadAll(req.Body)
+					if req.Method != http.MethodPut || req.URL.Path != "/view/view-id" || string(b) != tt.wantBody {
+						t.Errorf("request = %s %s %s, want PUT /view/view-id %s", req.Method, req.URL.Path, b, tt.wantBody)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"view": {"id": "view-id"}}`)),
+						Request:    req,
+					}, nil
+				}),
+				authenticator: &APITokenAuthenticator{},
+			}
+			if _, err := c.UpdateView(context.Background(), tt.view); (err != nil) != tt.wantErr {
+				t.Errorf("Client.UpdateView() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_AllTasksForView(t *testing.T) {
+	var pages []string
+	c := &Client{
+		doer: newMockClientDoer(func(req *http.Request) (*http.Response, error) {
+			page := req.URL.Query().Get("page")
+			pages = append(pages, page)
+			body := `{"tasks":[{"id":"a"},{"id":"b"}],"last_page":false}`
+			if page == "1" {
+				body = `{"tasks":[{"id":"c"}],"last_page":true}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+		authenticator: &APITokenAuthenticator{},
+	}
+
+	tasks, err := c.AllTasksForView(context.Background(), "fake-view-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := taskIDs(tasks); got != "a,b,c" {
+		t.Errorf("tasks = %s, want a,b,c", got)
+	}
+	if strings.Join(pages, ",") != "0,1" {
+		t.Errorf("requested pages %v, want 0,1", pages)
+	}
+}
@@ -19,39 +19,121 @@ import (
 )
 
 type WebhookEventMessage struct {
-	Event        WebhookEvent `json:"event"`
-	HistoryItems []struct {
-		ID       string `json:"id"`
-		Type     int    `json:"type"`
-		Date     string `json:"date"`
-		Field    string `json:"field"`
-		ParentID string `json:"parent_id"`
-		Data     struct {
-			StatusType string `json:"status_type"`
-		} `json:"data"`
-		User struct {
-			ID             int    `json:"id"`
-			Username       string `json:"username"`
-			Email          string `json:"email"`
-			Color          string `json:"color"`
-			Initials       string `json:"initials"`
-			ProfilePicture string `json:"profilePicture"`
-		} `json:"user"`
-		Before struct {
-			Status     string `json:"status"`
-			Color      string `json:"color"`
-			Orderindex int    `json:"-"`
-			Type       string `json:"type"`
-		} `json:"before"`
-		After struct {
-			Status     string `json:"status"`
-			Color      string `json:"color"`
-			Orderindex int    `json:"-"`
-			Type       string `json:"type"`
-		} `json:"after"`
-	} `json:"history_items"`
-	TaskID    string `json:"task_id"`
-	WebhookID string `json:"webhook_id"`
+	Event        WebhookEvent         `json:"event"`
+	HistoryItems []WebhookHistoryItem `json:"history_items"`
+	TaskID       string               `json:"task_id"`
+	WebhookID    string               `json:"webhook_id"`
+}
+
+// WebhookHistoryItem is a change described by a WebhookEventMessage.
+type WebhookHistoryItem struct {
+	ID       string    `json:"id"`
+	Type     int       `json:"type"`
+	Date     Timestamp `json:"date"`
+	Field    string    `json:"field"`
+	ParentID string    `json:"parent_id"`
+	Data     struct {
+		StatusType string `json:"status_type"`
+	} `json:"data"`
+	// User is the user who made the change.
+	User WebhookHistoryUser `json:"user"`
+	// Before and After are the values of Field around the change.
+	Before WebhookHistoryValue `json:"before"`
+	After  WebhookHistoryValue `json:"after"`
+}
+
+// WebhookHistoryValue is the value of a field before or after a change, which depends on the field:
+// a status for status changes, the assignee for assignee_add and assignee_rem, and a string of unix
+// milliseconds for dates.  Status, Color and Type are decoded from objects, so status changes read as
+// they always have, and Raw holds the value as it was received.
+type WebhookHistoryValue struct {
+	Status     string `json:"status"`
+	Color      string `json:"color"`
+	Orderindex int    `json:"-"`
+	Type       string `json:"type"`
+	// Raw is the JSON of the value.  It is empty or null when the field was not set.
+	Raw json.RawMessage `json:"-"`
+}
+
+// NewWebhookHistoryValue returns the value that v is encoded as.  A nil v is null.
+func NewWebhookHistoryValue(v interface{}) (WebhookHistoryValue, error) {
+	var value WebhookHistoryValue
+	b, err := json.Marshal(v)
+	if err != nil {
+		return value, err
+	}
+	err = value.UnmarshalJSON(b)
+	return value, err
+}
+
+// IsNull reports whether the field was not set.
+func (v WebhookHistoryValue) IsNull() bool {
+	return len(v.Raw) == 0 || bytes.Equal(v.Raw, nullJSON)
+}
+
+// Decode unmarshals Raw into dst.  A null value leaves dst unchanged.
+func (v WebhookHistoryValue) Decode(dst interface{}) error {
+	if v.IsNull() {
+		return nil
+	}
+	return json.Unmarshal(v.Raw, dst)
+}
+
+// User returns the value of an assignee change.
+func (v WebhookHistoryValue) User() (WebhookHistoryUser, error) {
+	var user WebhookHistoryUser
+	err := v.Decode(&user)
+	return user, err
+}
+
+// Timestamp returns the value of a date change, which is zero when the date was not set.
+func (v WebhookHistoryValue) Timestamp() (Timestamp, error) {
+	var t Timestamp
+	err := v.Decode(&t)
+	return t, err
+}
+
+// MarshalJSON encodes Raw, or the status if Raw is empty.
+func (v WebhookHistoryValue) MarshalJSON() ([]byte, error) {
+	switch {
+	case len(v.Raw) > 0:
+		return v.Raw, nil
+	case v.Status == "" && v.Color == "" && v.Type == "":
+		return nullJSON, nil
+	}
+	return json.Marshal(WebhookHistoryStatus{Status: v.Status, Color: v.Color, Type: v.Type})
+}
+
+// UnmarshalJSON accepts any value, and decodes Status, Color and Type from objects.
+func (v *WebhookHistoryValue) UnmarshalJSON(b []byte) error {
+	*v = WebhookHistoryValue{Raw: append(json.RawMessage(nil), b...)}
+	if b = bytes.TrimSpace(b); len(b) == 0 || b[0] != '{' {
+		return nil
+	}
+	var status WebhookHistoryStatus
+	if err := json.Unmarshal(b, &status); err != nil {
+		return err
+	}
+	v.Status, v.Color, v.Type = status.Status, status.Color, status.Type
+	return nil
+}
+
+// WebhookHistoryUser is a user in a WebhookHistoryItem.
+type WebhookHistoryUser struct {
+	ID             int    `json:"id"`
+	Username       string `json:"username"`
+	Email          string `json:"email"`
+	Color          string `json:"color"`
+	Initials       string `json:"initials"`
+	ProfilePicture string `json:"profilePicture"`
+}
+
+// WebhookHistoryStatus is the value of a status change.
+type WebhookHistoryStatus struct {
+	Status     string `json:"status"`
+	Color      string `json:"color"`
+	Orderindex int    `json:"-"`
+	Type       string `json:"type"`
 }
 
 type WebhookEvent string
@@ -214,14 +296,19 @@ func (c *Client) CreateWebhook(ctx context.Context, workspaceID string, webhook
 	return &newWebhook, nil
 }
 
+// UpdateWebhookRequest uses patch semantics.  Only explicitly set fields are sent to Clickup.
 type UpdateWebhookRequest struct {
-	ID       string         `json:"id"`
-	Endpoint string         `json:"endpoint,omitempty"`
-	Events   []WebhookEvent `json:"events,omitempty"`
-	TaskID   string         `json:"task_id,omitempty"`
-	ListID   string         `json:"list_id,omitempty"`
-	FolderID string         `json:"folder_id,omitempty"`
-	Status   string         `json:"status,omitempty"`
+	ID       string         `json:"-"`
+	Endpoint OptionalString `json:"endpoint"`
+	Events   []WebhookEvent `json:"events"`
+	TaskID   OptionalString `json:"task_id"`
+	ListID   OptionalString `json:"list_id"`
+	FolderID OptionalString `json:"folder_id"`
+	Status   OptionalString `json:"status"`
+}
+
+func (u UpdateWebhookRequest) MarshalJSON() ([]byte, error) {
+	return marshalPatch(u)
 }
 
 // UpdateWebhook changes an existing webhook.
@@ -9,6 +9,7 @@ package clickup
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -254,7 +255,7 @@ func TestClient_UpdateWebhook(t *testing.T) {
 				ctx: context.Background(),
 				webhook: &UpdateWebhookRequest{
 					ID:       "webhook id",
-					Endpoint: "https://endpoint.clickup",
+					Endpoint: OptString("https://endpoint.clickup"),
 				},
 			},
 			wantErr: false,
@@ -269,7 +270,7 @@ func TestClient_UpdateWebhook(t *testing.T) {
 				ctx: context.Background(),
 				webhook: &UpdateWebhookRequest{
 					ID:       "",
-					Endpoint: "https://endpoint.clickup",
+					Endpoint: OptString("https://endpoint.clickup"),
 				},
 			},
 			wantErr: true,
@@ -358,3 +359,71 @@ func TestClient_CreateWebhook(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookEventMessage_historyValues(t *testing.T) {
+	user := `{"id": 183, "username": "John", "email": "john@company.com", "color": "#7b68ee", "initials": "J", "profilePicture": null}`
+	messages := []string{
+		`{"event": "taskStatusUpdated", "task_id": "1vj37mc", "webhook_id": "w1", "history_items": [{"id": "1", "type": 1,
+			"date": "1642736724064", "field": "status", "parent_id": "162641062", "data": {"status_type": "custom"}, "source": null,
+			"user": ` + user + `,
+			"before": {"status": "to do", "color": "#f9d900", "orderindex": 0, "type": "open"},
+			"after": {"status": "in progress", "color": "#7C4DFF", "orderindex": 1, "type": "custom"}}]}`,
+		`{"event": "taskAssigneeUpdated", "task_id": "1vj37mc", "webhook_id": "w1", "history_items": [{"id": "2", "type": 1,
+			"date": "1642736810530", "field": "assignee_add", "parent_id": "162641062", "data": {}, "source": null,
+			"user": ` + user + `,
+			"after": {"id": 184, "username": "Sam", "email": "sam@company.com", "color": "#7b68ee", "initials": "S", "profilePicture": null}}]}`,
+		`{"event": "taskDueDateUpdated", "task_id": "1vj37mc", "webhook_id": "w1", "history_items": [{"id": "3", "type": 1,
+			"date": "1642734631523", "field": "due_date", "parent_id": "162641062", "data": {}, "source": null,
+			"user": ` + user + `, "before": null, "after": "1643104800000"}]}`,
+	}
+	var items []WebhookHistoryItem
+	for _, m := range messages {
+		var message WebhookEventMessage
+		if err := json.Unmarshal([]byte(m), &message); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		items = append(items, message.HistoryItems[0])
+	}
+
+	before, after := items[0].Before, items[0].After
+	if before.Status != "to do" || after.Status != "in progress" || after.Type != "custom" || items[0].User.ID != 183 {
+		t.Errorf("status change = %+v > %+v by %+v", before, after, items[0].User)
+	}
+
+	assignee, err := items[1].After.User()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !items[1].Before.IsNull() || assignee.ID != 184 || assignee.Username != "Sam" || items[1].User.ID != 183 {
+		t.Errorf("assignee change = %s > %+v by %+v", items[1].Before.Raw, assignee, items[1].User)
+	}
+
+	due, err := items[2].After.Timestamp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wasDue, err := items[2].Before.Timestamp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !items[2].Before.IsNull() || !wasDue.IsZero() || due.Millis() != 1643104800000 {
+		t.Errorf("due date change = %s > %s", items[2].Before.Raw, items[2].After.Raw)
+	}
+
+	// Values are encoded again as they were received, and statuses set without Raw as objects.
+	b, err := json.Marshal(items[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"before":null,"after":"1643104800000"`) {
+		t.Errorf("json.Marshal() = %s", b)
+	}
+	var item WebhookHistoryItem
+	item.After.Status = "done"
+	if b, err = json.Marshal(item); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"before":null,"after":{"status":"done","color":"","type":""}`) {
+		t.Errorf("json.Marshal() = %s", b)
+	}
+}